@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/adequatica/punto-banco-golango/internal/analysis"
+	"github.com/adequatica/punto-banco-golango/internal/rendering"
+	"github.com/adequatica/punto-banco-golango/internal/rules"
+)
+
+func main() {
+	rulesFile := flag.String("rules", "", "path to a JSON or TOML file with table rules")
+	decks := flag.Int("decks", 0, "number of decks in the shoe, overrides the table rules")
+	asJSON := flag.Bool("json", false, "print the analysis as JSON")
+	flag.Parse()
+
+	tableRules := rules.DefaultTableRules()
+	if *rulesFile != "" {
+		var err error
+		tableRules, err = rules.LoadTableRules(*rulesFile)
+		if err != nil {
+			fmt.Printf("Alas, table rules error has happened: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if *decks != 0 {
+		tableRules.NumberOfDecks = *decks
+		if err := tableRules.Validate(); err != nil {
+			fmt.Printf("Alas, table rules error has happened: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	shoe, err := analysis.AnalyzeShoe(tableRules)
+	if err != nil {
+		fmt.Printf("Alas, analysis error has happened: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(shoe); err != nil {
+			fmt.Printf("Alas, analysis error has happened: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	fmt.Printf("Exact odds of a coup from a fresh shoe of %d decks (%d cards)\n", tableRules.NumberOfDecks, shoe.Cards)
+	fmt.Printf("Banco commission: %s, Égalité pays %g to 1\n", rendering.FormatPercentage(tableRules.BancoCommission*100), tableRules.TiePayout)
+	fmt.Printf("Coups are counted over all %d ordered sequences of six cards\n", shoe.Sequences)
+	fmt.Println(rendering.RenderAnalysisTable(shoe))
+}
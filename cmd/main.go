@@ -1,12 +1,17 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/adequatica/punto-banco-golango/internal/analysis"
+	"github.com/adequatica/punto-banco-golango/internal/deck"
 	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
 	"github.com/adequatica/punto-banco-golango/internal/rendering"
+	"github.com/adequatica/punto-banco-golango/internal/rules"
 	"github.com/adequatica/punto-banco-golango/internal/statistics"
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
@@ -20,18 +25,19 @@ type keyMap struct {
 	Enter key.Binding
 
 	Stats key.Binding
+	Odds  key.Binding
 	Reset key.Binding
 	Quit  key.Binding
 }
 
 func (k keyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Up, k.Down, k.Enter, k.Stats, k.Reset, k.Quit}
+	return []key.Binding{k.Up, k.Down, k.Enter, k.Stats, k.Odds, k.Reset, k.Quit}
 }
 
 func (k keyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.Up, k.Down, k.Enter},    // first column
-		{k.Stats, k.Reset, k.Quit}, // second column
+		{k.Up, k.Down, k.Enter},            // first column
+		{k.Stats, k.Odds, k.Reset, k.Quit}, // second column
 	}
 }
 
@@ -52,6 +58,10 @@ var defaultKeys = keyMap{
 		key.WithKeys("s", "S", "ы", "Ы"),
 		key.WithHelp("S", "— show/hide statistics"),
 	),
+	Odds: key.NewBinding(
+		key.WithKeys("o", "O", "щ", "Щ"),
+		key.WithHelp("O", "— show/hide odds of the next coup"),
+	),
 	Reset: key.NewBinding(
 		key.WithKeys("r", "R", "к", "К"),
 		key.WithHelp("R", "— reset the game"),
@@ -74,11 +84,19 @@ const (
 
 type model struct {
 	stateUI           UIstate
+	seed              int64
+	shuffler          deck.Shuffler
+	tableRules        rules.TableRules
 	stateGame         puntobanco.GameResultState
 	statistics        statistics.SessionStatistics
 	showStatistics    bool
+	showOdds          bool
+	nextCoupOdds      *analysis.NextCoupOdds // Computed once per coup while the odds are shown
+	nextCoupOddsErr   error
 	cursor            int
 	bettingOptions    []string
+	sideBetOptions    []string
+	sideBets          map[puntobanco.SideBetType]bool
 	afterRoundOptions []string
 	selectedOption    string
 	keys              keyMap
@@ -87,17 +105,25 @@ type model struct {
 	spinnerStartTime  time.Time
 }
 
-func initialModel() model {
+func initialModel(seed int64, tableRules rules.TableRules) model {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 
+	shuffler := deck.NewShuffler(seed)
+
 	return model{
 		stateUI:           stateIsBetting,
-		stateGame:         puntobanco.GetNewGameResultState(),
+		seed:              seed,
+		shuffler:          shuffler,
+		tableRules:        tableRules,
+		stateGame:         puntobanco.GetNewGameResultState(tableRules, shuffler),
 		statistics:        statistics.NewSessionStatistics(),
 		showStatistics:    false,
+		showOdds:          false,
 		cursor:            0,
 		bettingOptions:    puntobanco.GetBettingOptions(),
+		sideBetOptions:    puntobanco.GetSideBettingOptions(),
+		sideBets:          make(map[puntobanco.SideBetType]bool),
 		afterRoundOptions: defaultAfterRoundOptions,
 		selectedOption:    "",
 		keys:              defaultKeys,
@@ -118,6 +144,38 @@ func tick() tea.Cmd {
 
 var spinnerTimeout = 500 * time.Millisecond // 500ms total duration
 
+// Side bets are listed under the main bets on the betting screen
+func (m model) numberOfBettingOptions() int {
+	return len(m.bettingOptions) + len(m.sideBetOptions)
+}
+
+// Side bets chosen for the next coup in the order of the options
+func (m model) selectedSideBets() []puntobanco.SideBetType {
+	var sideBets []puntobanco.SideBetType
+	for _, option := range m.sideBetOptions {
+		if m.sideBets[puntobanco.SideBetType(option)] {
+			sideBets = append(sideBets, puntobanco.SideBetType(option))
+		}
+	}
+	return sideBets
+}
+
+// Odds of the next coup are computed when the coup changes and not on every render, as the analysis takes a while
+func (m *model) updateNextCoupOdds() {
+	m.nextCoupOdds = nil
+	m.nextCoupOddsErr = nil
+	if !m.showOdds {
+		return
+	}
+
+	next, err := analysis.AnalyzeNextCoup(m.stateGame.GetShoe(), m.tableRules)
+	if err != nil {
+		m.nextCoupOddsErr = err
+		return
+	}
+	m.nextCoupOdds = &next
+}
+
 func (m model) Init() tea.Cmd {
 	return nil
 }
@@ -137,7 +195,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if m.cursor > 0 {
 					m.cursor--
 				} else {
-					m.cursor = len(m.bettingOptions) - 1
+					m.cursor = m.numberOfBettingOptions() - 1
 				}
 			case stateIsAfterRound:
 				if m.cursor > 0 {
@@ -150,7 +208,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, m.keys.Down):
 			switch m.stateUI {
 			case stateIsBetting:
-				if m.cursor < len(m.bettingOptions)-1 {
+				if m.cursor < m.numberOfBettingOptions()-1 {
 					m.cursor++
 				} else {
 					m.cursor = 0
@@ -166,6 +224,13 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, m.keys.Enter):
 			switch m.stateUI {
 			case stateIsBetting:
+				// Side bets are toggled, and the coup is dealt once the main bet is selected
+				if m.cursor >= len(m.bettingOptions) {
+					sideBet := puntobanco.SideBetType(m.sideBetOptions[m.cursor-len(m.bettingOptions)])
+					m.sideBets[sideBet] = !m.sideBets[sideBet]
+					return m, nil
+				}
+
 				// Store the selected betting choice
 				m.selectedOption = m.bettingOptions[m.cursor]
 
@@ -187,11 +252,13 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				case "Reset the game":
 					// Switch to betting state with a new game session
 					m.stateUI = stateIsBetting
-					m.stateGame = puntobanco.GetNewGameResultState()
+					m.stateGame = puntobanco.GetNewGameResultState(m.tableRules, m.shuffler)
 					m.statistics.ResetStatistics()
+					m.sideBets = make(map[puntobanco.SideBetType]bool)
 					m.cursor = 0
 					m.selectedOption = ""
 					m.spinnerStartTime = time.Time{} // Reset spinner timeout
+					m.updateNextCoupOdds()
 				case "Quit":
 					return m, tea.Quit
 				}
@@ -200,13 +267,19 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, m.keys.Reset):
 			// Switch to betting state with a new game session
 			m.stateUI = stateIsBetting
-			m.stateGame = puntobanco.GetNewGameResultState()
+			m.stateGame = puntobanco.GetNewGameResultState(m.tableRules, m.shuffler)
 			m.statistics.ResetStatistics()
+			m.sideBets = make(map[puntobanco.SideBetType]bool)
 			m.cursor = 0
 			m.selectedOption = ""
+			m.updateNextCoupOdds()
 
 		case key.Matches(msg, m.keys.Stats):
 			m.showStatistics = !m.showStatistics
+
+		case key.Matches(msg, m.keys.Odds):
+			m.showOdds = !m.showOdds
+			m.updateNextCoupOdds()
 		}
 
 	// Spinner tick
@@ -223,20 +296,24 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Check if timeout have passed
 			if time.Since(m.spinnerStartTime) >= spinnerTimeout {
 				// Animation complete, play the game and switch to after round state
-				gameResult, err := puntobanco.PlayPuntoBanco(m.stateGame.GetShoe())
+				gameResult, err := puntobanco.PlayPuntoBanco(m.stateGame.GetShoe(), m.tableRules, m.shuffler)
 				if err != nil {
 					fmt.Printf("Alas, game error has happened: %v\n", err)
 					// Reset game's session
-					m.stateGame = puntobanco.GetNewGameResultState()
+					m.stateGame = puntobanco.GetNewGameResultState(m.tableRules, m.shuffler)
 					m.statistics.ResetStatistics()
 				} else {
 					m.stateGame = gameResult
 
 					if gameResult.GetResult() != nil {
 						m.statistics.UpdateStatistics(*gameResult.GetResult(), puntobanco.BetType(m.selectedOption))
+						for _, sideBet := range m.selectedSideBets() {
+							m.statistics.UpdateSideBetStatistics(gameResult.IsSideBetWon(sideBet))
+						}
 					}
 				}
 
+				m.updateNextCoupOdds()
 				m.stateUI = stateIsAfterRound
 				m.cursor = 0
 				return m, nil
@@ -266,9 +343,32 @@ func (m model) View() string {
 			s += fmt.Sprintf("%s %s\n", cursor, choice)
 		}
 
+		// Side bets with their payouts
+		s += "\nAdd side bets:\n\n"
+
+		for i, choice := range m.sideBetOptions {
+			cursor := " "
+			if m.cursor == len(m.bettingOptions)+i {
+				cursor = ">"
+			}
+
+			checked := " "
+			if m.sideBets[puntobanco.SideBetType(choice)] {
+				checked = "x"
+			}
+
+			s += fmt.Sprintf("%s [%s] %s %.0f:1\n", cursor, checked, choice, puntobanco.SideBetType(choice).Payout())
+		}
+
 		// Show statistics if enabled
 		if m.showStatistics {
 			s += fmt.Sprintf("\n%s", rendering.RenderStatisticsTable(&m.statistics))
+			s += fmt.Sprintf("\nSeed: %d", m.seed)
+		}
+
+		// Show odds of the next coup if enabled
+		if m.showOdds {
+			s += fmt.Sprintf("\n\n%s", rendering.RenderNextCoupOdds(m.nextCoupOdds, m.nextCoupOddsErr))
 		}
 
 	case stateIsProgress:
@@ -278,9 +378,19 @@ func (m model) View() string {
 	case stateIsAfterRound:
 		// Header
 		s += fmt.Sprintf("You bet on %s", m.selectedOption)
+		sideBets := m.selectedSideBets()
+		if len(sideBets) > 0 {
+			names := make([]string, 0, len(sideBets))
+			for _, sideBet := range sideBets {
+				names = append(names, string(sideBet))
+			}
+			s += fmt.Sprintf(" with %s", strings.Join(names, ", "))
+		}
 
 		// Show game result state
 		s += rendering.RenderGameResultState(&m.stateGame, m.selectedOption)
+		s += rendering.RenderSideBetResults(&m.stateGame, sideBets)
+		s += rendering.RenderShoeState(&m.stateGame)
 
 		for i, choice := range m.afterRoundOptions {
 			cursor := " "
@@ -294,6 +404,12 @@ func (m model) View() string {
 		// Show statistics if enabled
 		if m.showStatistics {
 			s += fmt.Sprintf("\n%s", rendering.RenderStatisticsTable(&m.statistics))
+			s += fmt.Sprintf("\nSeed: %d", m.seed)
+		}
+
+		// Show odds of the next coup if enabled
+		if m.showOdds {
+			s += fmt.Sprintf("\n\n%s", rendering.RenderNextCoupOdds(m.nextCoupOdds, m.nextCoupOddsErr))
 		}
 	}
 
@@ -304,7 +420,32 @@ func (m model) View() string {
 }
 
 func main() {
-	p := tea.NewProgram(initialModel())
+	seed := flag.Int64("seed", 0, "seed for shuffling the shoe to replay the same game (random if omitted)")
+	rulesFile := flag.String("rules", "", "path to a JSON or TOML file with table rules")
+	flag.Parse()
+
+	// Any seed can be replayed, including 0, so only an omitted seed is random
+	isSeedSet := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "seed" {
+			isSeedSet = true
+		}
+	})
+	if !isSeedSet {
+		*seed = deck.NewRandomSeed()
+	}
+
+	tableRules := rules.DefaultTableRules()
+	if *rulesFile != "" {
+		var err error
+		tableRules, err = rules.LoadTableRules(*rulesFile)
+		if err != nil {
+			fmt.Printf("Alas, table rules error has happened: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	p := tea.NewProgram(initialModel(*seed, tableRules))
 
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("Alas, UI error has happened: %v\n", err)
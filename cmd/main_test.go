@@ -2,25 +2,37 @@ package main
 
 import (
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/adequatica/punto-banco-golango/internal/deck"
 	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
+	"github.com/adequatica/punto-banco-golango/internal/rules"
 	"github.com/adequatica/punto-banco-golango/internal/statistics"
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
 )
 
 func TestInitialModel(t *testing.T) {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 
+	var seed int64 = 42
+
 	expectedModel := model{
 		stateUI:           stateIsBetting,
-		stateGame:         puntobanco.GetNewGameResultState(),
+		seed:              seed,
+		tableRules:        rules.DefaultTableRules(),
+		stateGame:         puntobanco.GetNewGameResultState(rules.DefaultTableRules(), deck.NewShuffler(seed)),
 		statistics:        statistics.NewSessionStatistics(),
 		showStatistics:    false,
+		showOdds:          false,
 		cursor:            0,
 		bettingOptions:    puntobanco.GetBettingOptions(),
+		sideBetOptions:    puntobanco.GetSideBettingOptions(),
+		sideBets:          make(map[puntobanco.SideBetType]bool),
 		afterRoundOptions: defaultAfterRoundOptions,
 		selectedOption:    "",
 		keys:              defaultKeys,
@@ -28,7 +40,7 @@ func TestInitialModel(t *testing.T) {
 		spinner:           s,
 	}
 
-	actualModel := initialModel()
+	actualModel := initialModel(seed, rules.DefaultTableRules())
 
 	// Compare stateUI
 	if actualModel.stateUI != expectedModel.stateUI {
@@ -40,6 +52,19 @@ func TestInitialModel(t *testing.T) {
 		t.Errorf("stateGame.Result mismatch: got %v, want %v", actualModel.stateGame.GetResult(), expectedModel.stateGame.GetResult())
 	}
 
+	// Compare seed and the shoe made from it
+	if actualModel.seed != expectedModel.seed {
+		t.Errorf("seed mismatch: got %d, want %d", actualModel.seed, expectedModel.seed)
+	}
+	if !reflect.DeepEqual(actualModel.stateGame.GetShoe(), expectedModel.stateGame.GetShoe()) {
+		t.Errorf("stateGame shoe mismatch: shoes made with the same seed should be equal")
+	}
+
+	// Compare table rules
+	if actualModel.tableRules != expectedModel.tableRules {
+		t.Errorf("tableRules mismatch: got %v, want %v", actualModel.tableRules, expectedModel.tableRules)
+	}
+
 	// Compare statistics
 	if !reflect.DeepEqual(actualModel.statistics, expectedModel.statistics) {
 		t.Errorf("statistics mismatch: got %v, want %v", actualModel.statistics, expectedModel.statistics)
@@ -50,6 +75,11 @@ func TestInitialModel(t *testing.T) {
 		t.Errorf("statistics show: got %v, want %v", actualModel.statistics, expectedModel.statistics)
 	}
 
+	// Compare odds show
+	if actualModel.showOdds != expectedModel.showOdds {
+		t.Errorf("odds show: got %v, want %v", actualModel.showOdds, expectedModel.showOdds)
+	}
+
 	// Compare cursor
 	if actualModel.cursor != expectedModel.cursor {
 		t.Errorf("cursor mismatch: got %d, want %d", actualModel.cursor, expectedModel.cursor)
@@ -60,6 +90,14 @@ func TestInitialModel(t *testing.T) {
 		t.Errorf("bettingOptions length mismatch: got %d, want %d", len(actualModel.bettingOptions), len(expectedModel.bettingOptions))
 	}
 
+	// Compare side bets, none are placed at the start
+	if !reflect.DeepEqual(actualModel.sideBetOptions, expectedModel.sideBetOptions) {
+		t.Errorf("sideBetOptions mismatch: got %v, want %v", actualModel.sideBetOptions, expectedModel.sideBetOptions)
+	}
+	if !reflect.DeepEqual(actualModel.sideBets, expectedModel.sideBets) {
+		t.Errorf("sideBets mismatch: got %v, want %v", actualModel.sideBets, expectedModel.sideBets)
+	}
+
 	// Compare after round options
 	if !reflect.DeepEqual(actualModel.afterRoundOptions, expectedModel.afterRoundOptions) {
 		t.Errorf("afterRoundOptions mismatch: got %v, want %v", actualModel.afterRoundOptions, expectedModel.afterRoundOptions)
@@ -85,3 +123,95 @@ func TestInitialModel(t *testing.T) {
 		t.Errorf("spinner should have different instance")
 	}
 }
+
+func TestUpdate_ToggleOdds(t *testing.T) {
+	m := initialModel(42, rules.DefaultTableRules())
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	m = updated.(model)
+	if !m.showOdds {
+		t.Fatalf("O should show odds of the next coup")
+	}
+	if view := m.View(); !strings.Contains(view, "Odds of the next coup from") || !strings.Contains(view, "Expected value") {
+		t.Errorf("view should contain odds of the next coup")
+	}
+
+	// Odds are computed once per coup in Update, so the view only renders them
+	if m.nextCoupOdds == nil || m.nextCoupOddsErr != nil {
+		t.Fatalf("odds of the next coup should be computed once they are shown, got error %v", m.nextCoupOddsErr)
+	}
+	before := m.nextCoupOdds
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(model)
+	m.spinnerStartTime = time.Time{}
+	updated, _ = m.Update(tickMsg(time.Now()))
+	m = updated.(model)
+	if m.nextCoupOdds == before || m.nextCoupOdds.CardsLeft != len(m.stateGame.GetShoe().Cards) {
+		t.Errorf("odds should be computed again for the next coup")
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("O")})
+	m = updated.(model)
+	if m.showOdds || m.nextCoupOdds != nil {
+		t.Errorf("O should hide odds of the next coup")
+	}
+}
+
+func TestUpdate_SideBets(t *testing.T) {
+	m := initialModel(42, rules.DefaultTableRules())
+	press := func(keys string) {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(keys)})
+		m = updated.(model)
+	}
+
+	// Up from the first main bet wraps to the last side bet
+	press("k")
+	if m.cursor != len(m.bettingOptions)+len(m.sideBetOptions)-1 {
+		t.Fatalf("cursor = %d should be on the last side bet", m.cursor)
+	}
+	press(" ")
+	if !m.sideBets[puntobanco.PerfectPair] || m.stateUI != stateIsBetting {
+		t.Fatalf("selecting a side bet should toggle it and stay on the betting screen")
+	}
+	if view := m.View(); !strings.Contains(view, "[x] Perfect pair 25:1") || !strings.Contains(view, "[ ] Either pair 5:1") {
+		t.Errorf("view should show the chosen side bets, got %s", view)
+	}
+
+	// Toggle the side bet off and on again
+	press(" ")
+	if m.sideBets[puntobanco.PerfectPair] {
+		t.Fatalf("selecting a chosen side bet should remove it")
+	}
+	press(" ")
+
+	// Down wraps to the first main bet, which deals the coup
+	press("j")
+	press(" ")
+	if m.stateUI != stateIsProgress || m.selectedOption != string(puntobanco.PuntoPlayer) {
+		t.Fatalf("selecting a main bet should deal the coup, got state %v and bet %q", m.stateUI, m.selectedOption)
+	}
+
+	m.spinnerStartTime = time.Time{}
+	updated, _ := m.Update(tickMsg(time.Now()))
+	m = updated.(model)
+	if m.stateUI != stateIsAfterRound {
+		t.Fatalf("coup should be dealt after the animation")
+	}
+	if m.statistics.SideBets != 1 {
+		t.Errorf("statistics should count the side bet, got %d", m.statistics.SideBets)
+	}
+	if view := m.View(); !strings.Contains(view, "You bet on Punto (player) with Perfect pair") || !strings.Contains(view, "Perfect pair: you") {
+		t.Errorf("view should show the result of the side bet, got %s", view)
+	}
+
+	// Side bets are kept for the next round and cleared with the game
+	press(" ")
+	if m.stateUI != stateIsBetting || !m.sideBets[puntobanco.PerfectPair] {
+		t.Errorf("side bets should be kept for the next round")
+	}
+	press("r")
+	if len(m.selectedSideBets()) != 0 {
+		t.Errorf("side bets should be cleared with the game")
+	}
+}
@@ -0,0 +1,321 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/adequatica/punto-banco-golango/internal/deck"
+	"github.com/adequatica/punto-banco-golango/internal/rendering"
+	"github.com/adequatica/punto-banco-golango/internal/simulator"
+)
+
+// Exit codes of the headless mode
+const (
+	exitOK          = 0
+	exitError       = 1
+	exitUsage       = 2
+	exitInterrupted = 130
+)
+
+type outputFormat string
+
+const (
+	formatTable outputFormat = "table"
+	formatJSON  outputFormat = "json"
+	formatCSV   outputFormat = "csv"
+	// CSV of the survival curve, a row for each round of the curve
+	formatSurvival outputFormat = "survival"
+)
+
+func parseOutputFormat(format string) (outputFormat, error) {
+	switch outputFormat(strings.ToLower(format)) {
+	case formatTable:
+		return formatTable, nil
+	case formatJSON:
+		return formatJSON, nil
+	case formatCSV:
+		return formatCSV, nil
+	case formatSurvival:
+		return formatSurvival, nil
+	default:
+		return "", fmt.Errorf("unknown output format %q, use table, json, csv or survival", format)
+	}
+}
+
+// Options of a simulation run from the command line without the TUI
+type headlessOptions struct {
+	strategy       string
+	numSimulations int
+	saveData       bool
+	format         string
+	seed           *int64
+	settings       simulator.Settings
+}
+
+type headlessReport struct {
+	Strategy       string                             `json:"strategy"`
+	NumSimulations int                                `json:"numberOfSimulations"`
+	Seed           int64                              `json:"seed"`
+	Bankroll       float64                            `json:"bankroll"`
+	MinimumBet     float64                            `json:"minimumBet"`
+	MaximumBet     float64                            `json:"maximumBet"`
+	IsComplete     bool                               `json:"isComplete"`
+	Stats          simulator.MultipleSimulationsStats `json:"stats"`
+}
+
+func (o headlessOptions) validate() (simulator.StrategyType, outputFormat, error) {
+	strategy, err := simulator.ParseStrategy(o.strategy)
+	if err != nil {
+		return "", "", fmt.Errorf("%w, available strategies: %s", err, strings.Join(strategySlugs(), ", "))
+	}
+
+	format, err := parseOutputFormat(o.format)
+	if err != nil {
+		return "", "", err
+	}
+
+	if o.numSimulations <= 0 || o.numSimulations > maxNumberOfSimulations {
+		return "", "", fmt.Errorf("number of games should be between 1 and %d, got %d", maxNumberOfSimulations, o.numSimulations)
+	}
+
+	if o.saveData && o.numSimulations > maxNumberOfSimulationsToSave {
+		return "", "", fmt.Errorf("data can be saved for at most %d games, got %d", maxNumberOfSimulationsToSave, o.numSimulations)
+	}
+
+	if err := o.settings.Validate(); err != nil {
+		return "", "", err
+	}
+
+	return strategy, format, nil
+}
+
+func strategySlugs() []string {
+	options := simulator.GetStrategyOptions()
+	slugs := make([]string, 0, len(options))
+	for _, option := range options {
+		slugs = append(slugs, simulator.StrategySlug(simulator.StrategyType(option)))
+	}
+	return slugs
+}
+
+// Run simulations without the TUI, print the stats to stdout, and return the exit code.
+// An interrupted run prints the partial results of the finished games.
+func runHeadless(ctx context.Context, options headlessOptions, stdout io.Writer, stderr io.Writer) int {
+	strategy, format, err := options.validate()
+	if err != nil {
+		fmt.Fprintf(stderr, "Invalid options: %v\n", err)
+		return exitUsage
+	}
+
+	seed := deck.NewRandomSeed()
+	if options.seed != nil {
+		seed = *options.seed
+	}
+
+	stats, err := simulator.RunMultipleSimulations(ctx, strategy, options.settings, options.numSimulations, options.saveData, seed, nil)
+	isInterrupted := errors.Is(err, context.Canceled)
+	if err != nil && !isInterrupted {
+		fmt.Fprintf(stderr, "Failed to run simulations: %v\n", err)
+		return exitError
+	}
+
+	report := headlessReport{
+		Strategy:       string(strategy),
+		NumSimulations: options.numSimulations,
+		Seed:           seed,
+		Bankroll:       options.settings.Bankroll,
+		MinimumBet:     options.settings.Rules.MinimumBet,
+		MaximumBet:     options.settings.Rules.MaximumBet,
+		IsComplete:     !isInterrupted,
+		Stats:          stats,
+	}
+
+	if err := writeReport(stdout, report, format); err != nil {
+		fmt.Fprintf(stderr, "Failed to write results: %v\n", err)
+		return exitError
+	}
+
+	if isInterrupted {
+		fmt.Fprintf(stderr, "Simulation was interrupted after %d of %d games\n", stats.TotalSimulations, options.numSimulations)
+		return exitInterrupted
+	}
+
+	return exitOK
+}
+
+func writeReport(w io.Writer, report headlessReport, format outputFormat) error {
+	switch format {
+	case formatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+
+	case formatCSV:
+		return writeReportCSV(w, report)
+
+	case formatSurvival:
+		return writeSurvivalCSV(w, report)
+
+	default:
+		header := fmt.Sprintf("Results for %s strategy (%d simulations)\n", report.Strategy, report.Stats.TotalSimulations)
+		header += fmt.Sprintf("Seed to reproduce the results: %d\n", report.Seed)
+		_, err := fmt.Fprintf(w, "%s%s\n%s\n%s", header, rendering.RenderSimulatorTable(&report.Stats), rendering.RenderDistributionTable(&report.Stats), rendering.RenderSurvivalSummary(&report.Stats))
+		return err
+	}
+}
+
+// One header row and one row of values, so reports of several runs can be concatenated
+func writeReportCSV(w io.Writer, report headlessReport) error {
+	stats := report.Stats
+	formatFloat := func(value float64) string {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+
+	fields := [][2]string{
+		{"strategy", report.Strategy},
+		{"numberOfSimulations", strconv.Itoa(report.NumSimulations)},
+		{"seed", strconv.FormatInt(report.Seed, 10)},
+		{"bankroll", formatFloat(report.Bankroll)},
+		{"minimumBet", formatFloat(report.MinimumBet)},
+		{"maximumBet", formatFloat(report.MaximumBet)},
+		{"isComplete", strconv.FormatBool(report.IsComplete)},
+		{"totalSimulations", strconv.Itoa(stats.TotalSimulations)},
+		{"avgRoundsPerGame", formatFloat(stats.AvgRoundsPerGame)},
+		{"minRoundsPlayed", strconv.Itoa(stats.MinRoundsPlayed)},
+		{"maxRoundsPlayed", strconv.Itoa(stats.MaxRoundsPlayed)},
+		{"avgRoundsObservedPerGame", formatFloat(stats.AvgRoundsObservedPerGame)},
+		{"betRate", formatFloat(stats.BetRate)},
+		{"avgWinsPerGame", formatFloat(stats.AvgWinsPerGames)},
+		{"minWins", strconv.Itoa(stats.MinWins)},
+		{"maxWins", strconv.Itoa(stats.MaxWins)},
+		{"winRate", formatFloat(stats.WinRate)},
+		{"gamesWithZeroWins", strconv.Itoa(stats.GamesWithZeroWins)},
+		{"zeroWinsRate", formatFloat(stats.ZeroWinsRate)},
+		{"avgPushesPerGame", formatFloat(stats.AvgPushesPerGame)},
+		{"maxPushes", strconv.Itoa(stats.MaxPushes)},
+		{"pushRate", formatFloat(stats.PushRate)},
+		{"avgMaxWinsStreak", formatFloat(stats.AvgMaxWinsStreak)},
+		{"maxWinsStreak", strconv.Itoa(stats.MaxWinsStreak)},
+		{"avgMaxLossStreak", formatFloat(stats.AvgMaxLossStreak)},
+		{"maxLossStreak", strconv.Itoa(stats.MaxLossStreak)},
+		{"avgMaxBankrollReached", formatFloat(stats.AvgMaxBankrollReached)},
+		{"maxBankrollRecorded", formatFloat(stats.MaxBankrollReacorded)},
+		{"gamesWithProfitableBankroll", strconv.Itoa(stats.GamesWithProfitableBankroll)},
+		{"profitableBankrollRate", formatFloat(stats.ProfitableBankrollRate)},
+		{"gamesWithProfitableEnd", strconv.Itoa(stats.GamesWithProfitableEnd)},
+		{"profitableEndGamesRate", formatFloat(stats.ProfitableEndGamesRate)},
+		{"gamesBusted", strconv.Itoa(stats.GamesBusted)},
+		{"bustedRate", formatFloat(stats.BustedRate)},
+		{"gamesWithGoalHit", strconv.Itoa(stats.GamesWithGoalHit)},
+		{"goalHitRate", formatFloat(stats.GoalHitRate)},
+		{"gamesWithLossLimit", strconv.Itoa(stats.GamesWithLossLimit)},
+		{"lossLimitRate", formatFloat(stats.LossLimitRate)},
+		{"gamesWithRoundCap", strconv.Itoa(stats.GamesWithRoundCap)},
+		{"roundCapRate", formatFloat(stats.RoundCapRate)},
+		{"gamesWithBetExceedingBankroll", strconv.Itoa(stats.GamesWithBetExceedingBankroll)},
+		{"betExceedsBankrollRate", formatFloat(stats.BetExceedsBankrollRate)},
+		{"gamesWithTableMaximumExit", strconv.Itoa(stats.GamesWithTableMaximumExit)},
+		{"tableMaximumExitRate", formatFloat(stats.TableMaximumExitRate)},
+		{"gamesWithShoeCap", strconv.Itoa(stats.GamesWithShoeCap)},
+		{"shoeCapRate", formatFloat(stats.ShoeCapRate)},
+		{"gamesWithSitOutCap", strconv.Itoa(stats.GamesWithSitOutCap)},
+		{"sitOutCapRate", formatFloat(stats.SitOutCapRate)},
+		{"avgTableMaximumHitsPerGame", formatFloat(stats.AvgTableMaximumHitsPerGame)},
+		{"gamesWithTableMaximumHit", strconv.Itoa(stats.GamesWithTableMaximumHit)},
+		{"tableMaximumHitRate", formatFloat(stats.TableMaximumHitRate)},
+		{"totalWagered", formatFloat(stats.TotalWagered)},
+		{"avgWageredPerGame", formatFloat(stats.AvgWageredPerGame)},
+		{"avgPaidOutPerGame", formatFloat(stats.AvgPaidOutPerGame)},
+		{"avgCommissionPerGame", formatFloat(stats.AvgCommissionPerGame)},
+		{"totalNetResult", formatFloat(stats.TotalNetResult)},
+		{"avgNetResultPerGame", formatFloat(stats.AvgNetResultPerGame)},
+		{"realisedHouseEdge", formatFloat(stats.RealisedHouseEdge)},
+		{"theoreticalHouseEdge", formatFloat(stats.TheoreticalHouseEdge)},
+		{"avgSideBetsWageredPerGame", formatFloat(stats.AvgSideBetsWageredPerGame)},
+		{"sideBetWinRate", formatFloat(stats.SideBetWinRate)},
+	}
+
+	// Summary of each distribution without the histogram, e.g. finalBankrollMedian
+	distributions := []struct {
+		name         string
+		distribution simulator.Distribution
+	}{
+		{"roundsPlayed", stats.RoundsPlayedDistribution},
+		{"finalBankroll", stats.FinalBankrollDistribution},
+		{"peakBankroll", stats.PeakBankrollDistribution},
+		{"winsStreak", stats.WinsStreakDistribution},
+		{"lossStreak", stats.LossStreakDistribution},
+	}
+	for _, d := range distributions {
+		fields = append(fields,
+			[2]string{d.name + "StdDev", formatFloat(d.distribution.StdDev)},
+			[2]string{d.name + "P5", formatFloat(d.distribution.P5)},
+			[2]string{d.name + "P25", formatFloat(d.distribution.P25)},
+			[2]string{d.name + "Median", formatFloat(d.distribution.Median)},
+			[2]string{d.name + "P75", formatFloat(d.distribution.P75)},
+			[2]string{d.name + "P95", formatFloat(d.distribution.P95)},
+			[2]string{d.name + "P99", formatFloat(d.distribution.P99)},
+		)
+	}
+
+	// Bounds of each confidence interval, e.g. winRateLow and winRateHigh
+	intervals := []struct {
+		name     string
+		interval simulator.ConfidenceInterval
+	}{
+		{"avgRoundsPerGame", stats.AvgRoundsPerGameInterval},
+		{"winRate", stats.WinRateInterval},
+		{"zeroWinsRate", stats.ZeroWinsRateInterval},
+		{"avgMaxBankrollReached", stats.AvgMaxBankrollReachedInterval},
+		{"profitableBankrollRate", stats.ProfitableBankrollRateInterval},
+		{"profitableEndGamesRate", stats.ProfitableEndGamesRateInterval},
+		{"avgNetResultPerGame", stats.AvgNetResultPerGameInterval},
+	}
+	for _, i := range intervals {
+		fields = append(fields,
+			[2]string{i.name + "Low", formatFloat(i.interval.Low)},
+			[2]string{i.name + "High", formatFloat(i.interval.High)},
+		)
+	}
+
+	header := make([]string, 0, len(fields))
+	values := make([]string, 0, len(fields))
+	for _, field := range fields {
+		header = append(header, field[0])
+		values = append(values, field[1])
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.WriteAll([][]string{header, values}); err != nil {
+		return fmt.Errorf("Failed to write CSV: %w", err)
+	}
+
+	return nil
+}
+
+// Survival curve with the strategy and the seed on every row, so curves of several runs can be concatenated
+func writeSurvivalCSV(w io.Writer, report headlessReport) error {
+	records := [][]string{{"strategy", "seed", "round", "survival", "atRisk"}}
+	for _, point := range report.Stats.Survival.Curve {
+		records = append(records, []string{
+			report.Strategy,
+			strconv.FormatInt(report.Seed, 10),
+			strconv.Itoa(point.Round),
+			strconv.FormatFloat(point.Survival, 'f', -1, 64),
+			strconv.Itoa(point.AtRisk),
+		})
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.WriteAll(records); err != nil {
+		return fmt.Errorf("Failed to write CSV: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,279 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/adequatica/punto-banco-golango/internal/simulator"
+)
+
+func TestParseOutputFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		want    outputFormat
+		wantErr bool
+	}{
+		{name: "table", format: "table", want: formatTable},
+		{name: "json", format: "json", want: formatJSON},
+		{name: "csv in upper case", format: "CSV", want: formatCSV},
+		{name: "survival", format: "survival", want: formatSurvival},
+		{name: "unknown format", format: "xml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseOutputFormat(tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseOutputFormat(%q) error = %v, wantErr %v", tt.format, err, tt.wantErr)
+			}
+			if result != tt.want {
+				t.Errorf("parseOutputFormat(%q) = %s should be %s", tt.format, result, tt.want)
+			}
+		})
+	}
+}
+
+func defaultHeadlessOptions() headlessOptions {
+	return headlessOptions{
+		strategy:       "bet-on-banco-banker",
+		numSimulations: 5,
+		format:         "table",
+		seed:           fixedSeed(42),
+		settings:       simulator.DefaultSettings(),
+	}
+}
+
+func TestRunHeadless_InvalidOptions(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(o *headlessOptions)
+	}{
+		{
+			name:   "unknown strategy",
+			modify: func(o *headlessOptions) { o.strategy = "unknown" },
+		},
+		{
+			name:   "unknown format",
+			modify: func(o *headlessOptions) { o.format = "xml" },
+		},
+		{
+			name:   "zero games",
+			modify: func(o *headlessOptions) { o.numSimulations = 0 },
+		},
+		{
+			name:   "too many games",
+			modify: func(o *headlessOptions) { o.numSimulations = maxNumberOfSimulations + 1 },
+		},
+		{
+			name: "too many games to save",
+			modify: func(o *headlessOptions) {
+				o.numSimulations = maxNumberOfSimulationsToSave + 1
+				o.saveData = true
+			},
+		},
+		{
+			name:   "bankroll below minimum bet",
+			modify: func(o *headlessOptions) { o.settings.Bankroll = 1 },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := defaultHeadlessOptions()
+			tt.modify(&options)
+
+			var stdout, stderr bytes.Buffer
+			exitCode := runHeadless(context.Background(), options, &stdout, &stderr)
+
+			if exitCode != exitUsage {
+				t.Errorf("exit code = %d should be %d", exitCode, exitUsage)
+			}
+			if stdout.Len() != 0 {
+				t.Errorf("nothing should be printed to stdout, got %q", stdout.String())
+			}
+			if !strings.Contains(stderr.String(), "Invalid options") {
+				t.Errorf("stderr should explain the error, got %q", stderr.String())
+			}
+		})
+	}
+}
+
+func TestRunHeadless_Formats(t *testing.T) {
+	t.Run("table", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		exitCode := runHeadless(context.Background(), defaultHeadlessOptions(), &stdout, &stderr)
+
+		if exitCode != exitOK {
+			t.Fatalf("exit code = %d should be %d: %s", exitCode, exitOK, stderr.String())
+		}
+		for _, expected := range []string{
+			"Results for Bet on Banco (banker) strategy (5 simulations)",
+			"Seed to reproduce the results: 42",
+			"Statistics category",
+			"Median",
+			"Median rounds to ruin",
+		} {
+			if !strings.Contains(stdout.String(), expected) {
+				t.Errorf("table output should contain: %s", expected)
+			}
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		options := defaultHeadlessOptions()
+		options.format = "json"
+
+		var stdout, stderr bytes.Buffer
+		exitCode := runHeadless(context.Background(), options, &stdout, &stderr)
+		if exitCode != exitOK {
+			t.Fatalf("exit code = %d should be %d: %s", exitCode, exitOK, stderr.String())
+		}
+
+		var report headlessReport
+		if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+			t.Fatalf("output should be valid JSON: %v", err)
+		}
+		if report.Strategy != string(simulator.BetOnBanco) || report.Seed != 42 || !report.IsComplete {
+			t.Errorf("unexpected report: %+v", report)
+		}
+		if report.Stats.TotalSimulations != 5 {
+			t.Errorf("TotalSimulations = %d should be 5", report.Stats.TotalSimulations)
+		}
+		if len(report.Stats.FinalBankrollDistribution.Histogram) == 0 {
+			t.Errorf("report should have the histogram of final bankroll")
+		}
+		if interval := report.Stats.WinRateInterval; interval.Low > report.Stats.WinRate || interval.High < report.Stats.WinRate {
+			t.Errorf("report should have the confidence interval %+v of win rate %f", interval, report.Stats.WinRate)
+		}
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		options := defaultHeadlessOptions()
+		options.format = "csv"
+
+		var stdout, stderr bytes.Buffer
+		exitCode := runHeadless(context.Background(), options, &stdout, &stderr)
+		if exitCode != exitOK {
+			t.Fatalf("exit code = %d should be %d: %s", exitCode, exitOK, stderr.String())
+		}
+
+		records, err := csv.NewReader(&stdout).ReadAll()
+		if err != nil {
+			t.Fatalf("output should be valid CSV: %v", err)
+		}
+		if len(records) != 2 {
+			t.Fatalf("CSV should have a header and a row of values, got %d rows", len(records))
+		}
+		if records[0][0] != "strategy" || records[1][0] != string(simulator.BetOnBanco) {
+			t.Errorf("first column should be the strategy, got %q and %q", records[0][0], records[1][0])
+		}
+		if records[0][2] != "seed" || records[1][2] != "42" {
+			t.Errorf("third column should be the seed, got %q and %q", records[0][2], records[1][2])
+		}
+		if !slices.Contains(records[0], "finalBankrollMedian") {
+			t.Errorf("CSV should have the median of final bankroll")
+		}
+		if !slices.Contains(records[0], "winRateLow") || !slices.Contains(records[0], "winRateHigh") {
+			t.Errorf("CSV should have the confidence interval of win rate")
+		}
+	})
+}
+
+func TestRunHeadless_SurvivalFormat(t *testing.T) {
+	options := defaultHeadlessOptions()
+	options.format = "survival"
+
+	var stdout, stderr bytes.Buffer
+	exitCode := runHeadless(context.Background(), options, &stdout, &stderr)
+	if exitCode != exitOK {
+		t.Fatalf("exit code = %d should be %d: %s", exitCode, exitOK, stderr.String())
+	}
+
+	records, err := csv.NewReader(&stdout).ReadAll()
+	if err != nil {
+		t.Fatalf("output should be valid CSV: %v", err)
+	}
+	if len(records) < 2 || !slices.Equal(records[0], []string{"strategy", "seed", "round", "survival", "atRisk"}) {
+		t.Fatalf("CSV should have a header and the rows of the curve, got %v", records)
+	}
+	// Every game is alive before the first round
+	if first := records[1]; first[2] != "0" || first[3] != "100" || first[4] != "5" {
+		t.Errorf("first row should be round 0 with every game alive, got %v", first)
+	}
+}
+
+func TestRunHeadless_Interrupted(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	options := defaultHeadlessOptions()
+	options.numSimulations = 1000
+	options.format = "json"
+
+	var stdout, stderr bytes.Buffer
+	exitCode := runHeadless(ctx, options, &stdout, &stderr)
+
+	if exitCode != exitInterrupted {
+		t.Errorf("exit code = %d should be %d", exitCode, exitInterrupted)
+	}
+
+	var report headlessReport
+	if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+		t.Fatalf("partial results should be printed as valid JSON: %v", err)
+	}
+	if report.IsComplete {
+		t.Errorf("report of an interrupted run should not be complete")
+	}
+}
+
+func TestRunHeadless_SaveError(t *testing.T) {
+	// A file in place of the datasets directory cannot be written into
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile("datasets", nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	options := defaultHeadlessOptions()
+	options.saveData = true
+
+	var stdout, stderr bytes.Buffer
+	exitCode := runHeadless(context.Background(), options, &stdout, &stderr)
+
+	if exitCode != exitError {
+		t.Errorf("exit code = %d should be %d", exitCode, exitError)
+	}
+	if !strings.Contains(stderr.String(), "Failed to save simulation data") {
+		t.Errorf("stderr should report the save error, got %q", stderr.String())
+	}
+}
+
+func TestRunHeadless_ZeroSeed(t *testing.T) {
+	options := defaultHeadlessOptions()
+	options.seed = fixedSeed(0)
+	options.format = "json"
+
+	var stdout, stderr bytes.Buffer
+	if exitCode := runHeadless(context.Background(), options, &stdout, &stderr); exitCode != exitOK {
+		t.Fatalf("exit code = %d should be %d: %s", exitCode, exitOK, stderr.String())
+	}
+
+	var report headlessReport
+	if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+		t.Fatalf("results should be printed as valid JSON: %v", err)
+	}
+	// Seed 0 is a seed like any other, not a request for a random one
+	if report.Seed != 0 {
+		t.Errorf("seed = %d should be 0", report.Seed)
+	}
+	want, _ := simulator.RunMultipleSimulations(context.Background(), simulator.BetOnBanco, options.settings, options.numSimulations, false, 0, nil)
+	if report.Stats.AvgRoundsPerGame != want.AvgRoundsPerGame {
+		t.Errorf("results of seed 0 should be reproduced, got %.2f rounds per game instead of %.2f", report.Stats.AvgRoundsPerGame, want.AvgRoundsPerGame)
+	}
+}
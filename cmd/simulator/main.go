@@ -1,15 +1,24 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"os"
+	"os/signal"
 	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
+	"github.com/adequatica/punto-banco-golango/internal/deck"
 	"github.com/adequatica/punto-banco-golango/internal/rendering"
+	"github.com/adequatica/punto-banco-golango/internal/rules"
 	"github.com/adequatica/punto-banco-golango/internal/simulator"
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
@@ -18,27 +27,32 @@ import (
 var (
 	defaultNumberOfSimulations = 10000
 	// Limit of 1M simulations needs just to prevent too long calculations in case of input mistake
-	maxNumberOfSimulations = 999999 // This number of simulations take ~ 25 minutes depends on choosen strategy
+	maxNumberOfSimulations = 999999 // This number of simulations take ~ 25 minutes on a single core depends on choosen strategy
 	// Limit 10K cause storing data for a large number of simulations may cause memory exhaustion
 	// Even 10K simulations can create a .gz file larger than 200 MB that contains a JSON file larger than 4.4 GB
 	maxNumberOfSimulationsToSave = 10000
 )
 
 type keyMap struct {
-	Up    key.Binding
-	Down  key.Binding
-	Enter key.Binding
-	Quit  key.Binding
+	Up            key.Binding
+	Down          key.Binding
+	Enter         key.Binding
+	Toggle        key.Binding
+	Distributions key.Binding
+	Trajectories  key.Binding
+	Survival      key.Binding
+	Cancel        key.Binding
+	Quit          key.Binding
 }
 
 func (k keyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Up, k.Down, k.Enter, k.Quit}
+	return []key.Binding{k.Up, k.Down, k.Toggle, k.Enter, k.Distributions, k.Trajectories, k.Survival, k.Cancel, k.Quit}
 }
 
 func (k keyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.Up, k.Down},    // first column
-		{k.Enter, k.Quit}, // second column
+		{k.Up, k.Down, k.Toggle, k.Enter},                               // first column
+		{k.Distributions, k.Trajectories, k.Survival, k.Cancel, k.Quit}, // second column
 	}
 }
 
@@ -55,6 +69,35 @@ var defaultKeys = keyMap{
 		key.WithKeys("enter", " "),
 		key.WithHelp("ENTER/SPACE", "— select"),
 	),
+	// Enabled only while choosing strategies
+	Toggle: key.NewBinding(
+		key.WithKeys("x", "X", "ч", "Ч"),
+		key.WithHelp("X", "— add to comparison"),
+	),
+	// Enabled only on the results of a single strategy
+	Distributions: key.NewBinding(
+		key.WithKeys("h", "H", "р", "Р"),
+		key.WithHelp("H", "— distributions"),
+		key.WithDisabled(),
+	),
+	// Enabled only on the results of a single strategy
+	Trajectories: key.NewBinding(
+		key.WithKeys("g", "G", "п", "П"),
+		key.WithHelp("G", "— bankroll charts"),
+		key.WithDisabled(),
+	),
+	// Enabled only on the results of a single strategy
+	Survival: key.NewBinding(
+		key.WithKeys("r", "R", "к", "К"),
+		key.WithHelp("R", "— risk of ruin"),
+		key.WithDisabled(),
+	),
+	// Enabled only while a simulation is running
+	Cancel: key.NewBinding(
+		key.WithKeys("c", "C", "с", "С"),
+		key.WithHelp("C", "— cancel simulation"),
+		key.WithDisabled(),
+	),
 	Quit: key.NewBinding(
 		key.WithKeys("q", "Q", "й", "Й", "ctrl+c", "esc"),
 		key.WithHelp("Q/CTRL+C", "— quit"),
@@ -70,23 +113,45 @@ const (
 	stateShowResults
 )
 
+// Sub-views of the results of a single strategy
+type resultsView int
+
+const (
+	viewStatistics resultsView = iota
+	viewDistributions
+	viewTrajectories
+	viewSurvival
+)
+
 type model struct {
 	stateUI            UIstate
 	cursor             int
 	strategyOptions    []string
 	selectedStrategy   simulator.StrategyType
+	comparedStrategies map[simulator.StrategyType]bool // Strategies marked for a comparison on the same shoes
 	textInput          textinput.Model
 	numSimulations     int
 	saveData           bool
+	settings           simulator.Settings
+	seed               *int64 // Fixed seed from the command line, nil means a new random seed for each run
+	simulationSeed     int64
 	stats              simulator.MultipleSimulationsStats
+	resultsView        resultsView
+	comparison         *simulator.StrategyComparison
 	keys               keyMap
 	help               help.Model
 	spinner            spinner.Model
+	progress           progress.Model
+	progressCh         chan simulator.Progress
+	lastProgress       simulator.Progress
+	cancelSimulation   context.CancelFunc
+	isCancelled        bool
 	simulationStart    time.Time
 	simulationDuration time.Duration
+	simulationErr      error // Error of the last simulation, shown over the strategy selection
 }
 
-func InitialModel() model {
+func InitialModel(seed *int64, settings simulator.Settings) model {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 
@@ -97,14 +162,18 @@ func InitialModel() model {
 	ti.Width = 6
 
 	return model{
-		stateUI:         stateSelectStrategy,
-		cursor:          0,
-		strategyOptions: simulator.GetStrategyOptions(),
-		textInput:       ti,
-		numSimulations:  0,
-		keys:            defaultKeys,
-		help:            help.New(),
-		spinner:         s,
+		stateUI:            stateSelectStrategy,
+		cursor:             0,
+		strategyOptions:    simulator.GetStrategyOptions(),
+		comparedStrategies: make(map[simulator.StrategyType]bool),
+		textInput:          ti,
+		numSimulations:     0,
+		settings:           settings,
+		seed:               seed,
+		keys:               defaultKeys,
+		help:               help.New(),
+		spinner:            s,
+		progress:           progress.New(progress.WithDefaultGradient(), progress.WithWidth(48)),
 	}
 }
 
@@ -114,12 +183,95 @@ type simulationCompleteMsg struct {
 	err   error
 }
 
-func runSimulation(strategy simulator.StrategyType, numSimulations int, saveData bool) tea.Cmd {
+// Comparison completion message
+type comparisonCompleteMsg struct {
+	comparison simulator.StrategyComparison
+	err        error
+}
+
+// Simulation progress message
+type simulationProgressMsg simulator.Progress
+
+func runSimulation(
+	ctx context.Context,
+	strategy simulator.StrategyType,
+	settings simulator.Settings,
+	numSimulations int,
+	saveData bool,
+	seed int64,
+	progressCh chan simulator.Progress,
+) tea.Cmd {
 	return func() tea.Msg {
-		// Run the simulation with error handling
-		stats := simulator.RunMultipleSimulations(strategy, numSimulations, saveData)
-		// Note: If RunMultipleSimulations could return an error, we would handle it here
-		return simulationCompleteMsg{stats: stats, err: nil}
+		// Progress channel is closed after the last report, so waitForProgress stops listening
+		defer close(progressCh)
+
+		stats, err := simulator.RunMultipleSimulations(ctx, strategy, settings, numSimulations, saveData, seed, func(p simulator.Progress) {
+			// Replace the report the UI has not read yet, so simulations never wait for rendering
+			select {
+			case <-progressCh:
+			default:
+			}
+			progressCh <- p
+		})
+		return simulationCompleteMsg{stats: stats, err: err}
+	}
+}
+
+// Every strategy plays each game on the same shoes, progress counts the games played by all strategies
+func runComparison(
+	ctx context.Context,
+	strategies []simulator.StrategyType,
+	settings simulator.Settings,
+	numSimulations int,
+	seed int64,
+	progressCh chan simulator.Progress,
+) tea.Cmd {
+	return func() tea.Msg {
+		defer close(progressCh)
+
+		comparison, err := simulator.RunStrategyComparison(ctx, strategies, settings, numSimulations, seed, func(p simulator.Progress) {
+			select {
+			case <-progressCh:
+			default:
+			}
+			progressCh <- p
+		})
+		return comparisonCompleteMsg{comparison: comparison, err: err}
+	}
+}
+
+func waitForProgress(progressCh chan simulator.Progress) tea.Cmd {
+	return func() tea.Msg {
+		p, ok := <-progressCh
+		if !ok {
+			return nil
+		}
+		return simulationProgressMsg(p)
+	}
+}
+
+// Strategies marked for a comparison in the order of the menu
+func (m model) markedStrategies() []simulator.StrategyType {
+	var strategies []simulator.StrategyType
+	for _, option := range m.strategyOptions {
+		if m.comparedStrategies[simulator.StrategyType(option)] {
+			strategies = append(strategies, simulator.StrategyType(option))
+		}
+	}
+	return strategies
+}
+
+// A single marked strategy runs on its own
+func (m model) isComparison() bool {
+	return len(m.markedStrategies()) > 1
+}
+
+// The key of a sub-view switches to it, or back to the statistics when it is shown
+func (m *model) toggleResultsView(view resultsView) {
+	if m.resultsView == view {
+		m.resultsView = viewStatistics
+	} else {
+		m.resultsView = view
 	}
 }
 
@@ -134,8 +286,43 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch {
 
 		case key.Matches(msg, m.keys.Quit):
+			if m.cancelSimulation != nil {
+				m.cancelSimulation()
+			}
 			return m, tea.Quit
 
+		case key.Matches(msg, m.keys.Cancel):
+			if m.stateUI == stateRunningSimulation && m.cancelSimulation != nil {
+				m.cancelSimulation()
+				m.isCancelled = true
+				m.keys.Cancel.SetEnabled(false)
+			}
+
+		case key.Matches(msg, m.keys.Toggle):
+			if m.stateUI == stateSelectStrategy && m.cursor >= 0 && m.cursor < len(m.strategyOptions) {
+				strategy := simulator.StrategyType(m.strategyOptions[m.cursor])
+				if m.comparedStrategies[strategy] {
+					delete(m.comparedStrategies, strategy)
+				} else {
+					m.comparedStrategies[strategy] = true
+				}
+			}
+
+		case key.Matches(msg, m.keys.Distributions):
+			if m.stateUI == stateShowResults {
+				m.toggleResultsView(viewDistributions)
+			}
+
+		case key.Matches(msg, m.keys.Trajectories):
+			if m.stateUI == stateShowResults {
+				m.toggleResultsView(viewTrajectories)
+			}
+
+		case key.Matches(msg, m.keys.Survival):
+			if m.stateUI == stateShowResults {
+				m.toggleResultsView(viewSurvival)
+			}
+
 		case key.Matches(msg, m.keys.Up):
 			switch m.stateUI {
 			case stateSelectStrategy:
@@ -146,7 +333,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			case stateEnterSimulations:
 				// Toggle save data option when Up is pressed
-				if num, err := strconv.Atoi(m.textInput.Value()); err == nil && num > 0 && num <= maxNumberOfSimulationsToSave {
+				if num, err := strconv.Atoi(m.textInput.Value()); err == nil && num > 0 && num <= maxNumberOfSimulationsToSave && !m.isComparison() {
 					m.saveData = !m.saveData
 				}
 			}
@@ -161,7 +348,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			case stateEnterSimulations:
 				// Toggle save data option when Down is pressed
-				if num, err := strconv.Atoi(m.textInput.Value()); err == nil && num > 0 && num <= maxNumberOfSimulationsToSave {
+				if num, err := strconv.Atoi(m.textInput.Value()); err == nil && num > 0 && num <= maxNumberOfSimulationsToSave && !m.isComparison() {
 					m.saveData = !m.saveData
 				}
 			}
@@ -169,46 +356,88 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, m.keys.Enter):
 			switch m.stateUI {
 			case stateSelectStrategy:
-				// Store the selected strategy with bounds checking
-				if len(m.strategyOptions) > 0 && m.cursor >= 0 && m.cursor < len(m.strategyOptions) {
+				marked := m.markedStrategies()
+				switch {
+				case len(marked) > 1:
+					// Marked strategies are compared, there is no single selected strategy
+					m.selectedStrategy = ""
+				case len(marked) == 1:
+					m.selectedStrategy = marked[0]
+				case len(m.strategyOptions) > 0 && m.cursor >= 0 && m.cursor < len(m.strategyOptions):
+					// Store the selected strategy with bounds checking
 					m.selectedStrategy = simulator.StrategyType(m.strategyOptions[m.cursor])
-					m.stateUI = stateEnterSimulations
-					m.textInput.SetValue(fmt.Sprintf("%d", defaultNumberOfSimulations))
-					m.textInput.Focus()
-					m.saveData = false // Reset to default NO
-				} else {
+				default:
 					// Handle invalid state
 					m.cursor = 0
+					return m, nil
 				}
+				m.stateUI = stateEnterSimulations
+				m.textInput.SetValue(fmt.Sprintf("%d", defaultNumberOfSimulations))
+				m.textInput.Focus()
+				m.saveData = false // Reset to default NO
+				m.keys.Toggle.SetEnabled(false)
 
 			case stateEnterSimulations:
 				// Parse number of simulations with validation
 				if num, err := strconv.Atoi(m.textInput.Value()); err == nil && num > 0 && num <= maxNumberOfSimulations {
 					m.numSimulations = num
-					// Only save data if <= maxNumberOfSimulationsToSave simulations
-					if num > maxNumberOfSimulationsToSave {
+					// Only save data if <= maxNumberOfSimulationsToSave simulations of a single strategy
+					if num > maxNumberOfSimulationsToSave || m.isComparison() {
 						m.saveData = false
 					}
+					m.simulationSeed = deck.NewRandomSeed()
+					if m.seed != nil {
+						m.simulationSeed = *m.seed
+					}
 					m.stateUI = stateRunningSimulation
 					m.simulationStart = time.Now()
+					m.lastProgress = simulator.Progress{TotalSimulations: m.numSimulations}
+					m.isCancelled = false
+					m.simulationErr = nil
+
+					ctx, cancel := context.WithCancel(context.Background())
+					m.cancelSimulation = cancel
+					m.progressCh = make(chan simulator.Progress, 1)
+					m.keys.Cancel.SetEnabled(true)
+
+					if m.isComparison() {
+						// Start running all strategies on the same shoes
+						return m, tea.Batch(
+							m.spinner.Tick,
+							runComparison(ctx, m.markedStrategies(), m.settings, m.numSimulations, m.simulationSeed, m.progressCh),
+							waitForProgress(m.progressCh),
+						)
+					}
+
 					// Start running simulation
 					return m, tea.Batch(
 						m.spinner.Tick,
-						runSimulation(m.selectedStrategy, m.numSimulations, m.saveData),
+						runSimulation(ctx, m.selectedStrategy, m.settings, m.numSimulations, m.saveData, m.simulationSeed, m.progressCh),
+						waitForProgress(m.progressCh),
 					)
 				}
 
 			case stateShowResults:
-				// Return to strategy selection with complete reset
+				// Return to strategy selection with complete reset,
+				// strategies stay marked, so the comparison can be run again
 				m.stateUI = stateSelectStrategy
 				m.cursor = 0
 				m.selectedStrategy = ""
 				m.textInput.SetValue("")
 				m.numSimulations = 0
 				m.saveData = false
+				m.simulationSeed = 0
 				m.stats = simulator.MultipleSimulationsStats{}
+				m.resultsView = viewStatistics
+				m.keys.Distributions.SetEnabled(false)
+				m.keys.Trajectories.SetEnabled(false)
+				m.keys.Survival.SetEnabled(false)
+				m.comparison = nil
+				m.lastProgress = simulator.Progress{}
+				m.isCancelled = false
 				m.simulationDuration = 0
 				m.simulationStart = time.Time{}
+				m.keys.Toggle.SetEnabled(true)
 			}
 
 		default:
@@ -226,17 +455,53 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.spinner, cmd = m.spinner.Update(msg)
 		return m, cmd
 
+	// Simulation progress
+	case simulationProgressMsg:
+		if m.stateUI == stateRunningSimulation {
+			m.lastProgress = simulator.Progress(msg)
+			return m, waitForProgress(m.progressCh)
+		}
+
 	// Simulation completion
 	case simulationCompleteMsg:
 		if m.stateUI == stateRunningSimulation {
-			if msg.err != nil {
-				// Handle simulation error - could add error state here
-				fmt.Printf("Simulation error: %v\n", msg.err)
+			if m.cancelSimulation != nil {
+				m.cancelSimulation()
+				m.cancelSimulation = nil
+			}
+			m.keys.Cancel.SetEnabled(false)
+
+			if msg.err != nil && !errors.Is(msg.err, context.Canceled) {
+				m.simulationErr = msg.err
 				m.stateUI = stateSelectStrategy
+				m.keys.Toggle.SetEnabled(true)
 			} else {
 				m.stats = msg.stats
 				m.simulationDuration = time.Since(m.simulationStart)
 				m.stateUI = stateShowResults
+				m.keys.Distributions.SetEnabled(true)
+				m.keys.Trajectories.SetEnabled(true)
+				m.keys.Survival.SetEnabled(true)
+			}
+		}
+
+	// Comparison completion
+	case comparisonCompleteMsg:
+		if m.stateUI == stateRunningSimulation {
+			if m.cancelSimulation != nil {
+				m.cancelSimulation()
+				m.cancelSimulation = nil
+			}
+			m.keys.Cancel.SetEnabled(false)
+
+			if msg.err != nil && !errors.Is(msg.err, context.Canceled) {
+				m.simulationErr = msg.err
+				m.stateUI = stateSelectStrategy
+				m.keys.Toggle.SetEnabled(true)
+			} else {
+				m.comparison = &msg.comparison
+				m.simulationDuration = time.Since(m.simulationStart)
+				m.stateUI = stateShowResults
 			}
 		}
 	}
@@ -244,11 +509,22 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+func formatStrategies(strategies []simulator.StrategyType) string {
+	names := make([]string, 0, len(strategies))
+	for _, strategy := range strategies {
+		names = append(names, string(strategy))
+	}
+	return strings.Join(names, ", ")
+}
+
 func (m model) View() string {
 	var s string
 
 	switch m.stateUI {
 	case stateSelectStrategy:
+		if m.simulationErr != nil {
+			s += fmt.Sprintf("Simulation error: %v\n\n", m.simulationErr)
+		}
 		s += "Select a betting strategy:\n\n"
 
 		for i, strategy := range m.strategyOptions {
@@ -257,10 +533,24 @@ func (m model) View() string {
 				cursor = ">"
 			}
 
-			s += fmt.Sprintf("%s %s\n", cursor, strategy)
+			mark := " "
+			if m.comparedStrategies[simulator.StrategyType(strategy)] {
+				mark = "x"
+			}
+
+			s += fmt.Sprintf("%s [%s] %s\n", cursor, mark, strategy)
 		}
 
+		s += "\nPress X to mark strategies to compare them on the same shoes"
+
 	case stateEnterSimulations:
+		if m.isComparison() {
+			s += fmt.Sprintf("Selected strategies to compare: %s\n\n", formatStrategies(m.markedStrategies()))
+			s += "Enter number of simulations to run for each strategy:\n"
+			s += m.textInput.View()
+			s += "\n\nPress ENTER to start simulation"
+			break
+		}
 		s += fmt.Sprintf("Selected strategy: %s\n\n", m.selectedStrategy)
 		s += "Enter number of simulations to run:\n"
 		s += m.textInput.View()
@@ -277,11 +567,51 @@ func (m model) View() string {
 		s += "\n\nPress ENTER to start simulation"
 
 	case stateRunningSimulation:
-		s += fmt.Sprintf("Running %d simulations for %s\n\n", m.numSimulations, m.selectedStrategy)
-		s += fmt.Sprintf("%s Simulation in progress...\n", m.spinner.View())
+		if m.isComparison() {
+			s += fmt.Sprintf("Running %d simulations for each of %s\n\n", m.numSimulations, formatStrategies(m.markedStrategies()))
+		} else {
+			s += fmt.Sprintf("Running %d simulations for %s\n\n", m.numSimulations, m.selectedStrategy)
+		}
+		if m.isCancelled {
+			s += fmt.Sprintf("%s Cancelling simulation...\n\n", m.spinner.View())
+		} else {
+			s += fmt.Sprintf("%s Simulation in progress...\n\n", m.spinner.View())
+		}
+
+		percent := 0.0
+		if m.lastProgress.TotalSimulations > 0 {
+			percent = float64(m.lastProgress.CompletedSimulations) / float64(m.lastProgress.TotalSimulations)
+		}
+		s += m.progress.ViewAs(percent) + "\n\n"
+		s += rendering.RenderSimulatorProgress(&m.lastProgress, time.Since(m.simulationStart).Seconds())
 
 	case stateShowResults:
-		s += rendering.RenderSimulatorStatistics(&m.stats, m.selectedStrategy, m.numSimulations, m.simulationDuration.Seconds())
+		if m.comparison != nil {
+			if games := m.comparison.Strategies[0].Stats.TotalSimulations; games < m.numSimulations {
+				s += fmt.Sprintf("Comparison was cancelled after %d of %d games, partial results are shown\n\n", games, m.numSimulations)
+			}
+			s += rendering.RenderComparisonStatistics(m.comparison, m.numSimulations, m.simulationDuration.Seconds())
+			s += fmt.Sprintf("Seed to reproduce the results: %d\n", m.simulationSeed)
+			s += "\nPress ENTER to run another simulation"
+			break
+		}
+		if m.stats.TotalSimulations < m.numSimulations {
+			s += fmt.Sprintf("Simulation was cancelled after %d of %d games, partial results are shown\n\n", m.stats.TotalSimulations, m.numSimulations)
+		}
+		switch m.resultsView {
+		case viewDistributions:
+			s += fmt.Sprintf("Distributions of values of games for %s strategy (%d simulations)\n", m.selectedStrategy, m.stats.TotalSimulations)
+			s += rendering.RenderSimulatorDistributions(&m.stats)
+		case viewTrajectories:
+			s += fmt.Sprintf("Bankroll trajectories of games for %s strategy (%d simulations)\n\n", m.selectedStrategy, m.stats.TotalSimulations)
+			s += rendering.RenderBankrollTrajectories(&m.stats) + "\n"
+		case viewSurvival:
+			s += fmt.Sprintf("Risk of ruin of games for %s strategy (%d simulations)\n\n", m.selectedStrategy, m.stats.TotalSimulations)
+			s += rendering.RenderSurvivalAnalysis(&m.stats)
+		default:
+			s += rendering.RenderSimulatorStatistics(&m.stats, m.selectedStrategy, m.stats.TotalSimulations, m.simulationDuration.Seconds())
+		}
+		s += fmt.Sprintf("Seed to reproduce the results: %d\n", m.simulationSeed)
 		s += "\nPress ENTER to run another simulation"
 	}
 
@@ -292,7 +622,134 @@ func (m model) View() string {
 }
 
 func main() {
-	p := tea.NewProgram(InitialModel())
+	seed := flag.Int64("seed", 0, "seed for shuffling the shoes to reproduce a simulation (random if omitted)")
+	rulesFile := flag.String("rules", "", "path to a JSON or TOML file with table rules")
+	workers := flag.Int("workers", 0, "number of parallel workers running simulations (GOMAXPROCS if 0)")
+	bankroll := flag.Float64("bankroll", simulator.DefaultBankroll, "starting bankroll of every game")
+	bet := flag.Float64("bet", rules.DefaultMinimumBet, "minimum (standard) bet, overrides the table rules")
+	tableMaximum := flag.Float64("table-max", 0, "table maximum bet, overrides the table rules (no limit if 0)")
+	tableMaximumPolicy := flag.String("table-max-policy", string(simulator.TableMaximumCap), "what progressions do over the table maximum: cap, reset or stop, e.g. cap,martingale-on-banco=reset")
+	stopWin := flag.String("stop-win", "", "walk away once the profit reaches an amount, e.g. 200, or a percentage of the bankroll, e.g. 20%")
+	stopLoss := flag.String("stop-loss", "", "walk away once the loss reaches an amount, e.g. 500, or a percentage of the bankroll, e.g. 50%")
+	maxRounds := flag.Int("max-rounds", 0, "maximum number of rounds of a game (no cap if 0)")
+	maxShoes := flag.Int("max-shoes", 0, "maximum number of shoes of a game (no cap if 0, 100 for card counting strategies)")
+	maxSitOutShoes := flag.Int("max-sit-out-shoes", simulator.DefaultSitOutMaxShoes, "maximum number of shoes in a row without a bet before a game ends (no cap if 0)")
+	patternStake := flag.String("pattern-stake", string(simulator.StakeFlat), "stake of pattern strategies: flat, martingale, dalembert or fibonacci")
+	labouchereLine := flag.String("labouchere-line", simulator.FormatLabouchereLine(simulator.DefaultLabouchereLine()), "starting line of Labouchère strategies in units of the minimum bet")
+	// Headless mode: the simulation runs without the TUI once a strategy is given
+	strategy := flag.String("strategy", "", "strategy name or short name, e.g. martingale-on-punto, to run without the TUI")
+	numSimulations := flag.Int("games", defaultNumberOfSimulations, "number of games to simulate without the TUI")
+	saveData := flag.Bool("save", false, "save data of the simulated games into the datasets directory without the TUI")
+	format := flag.String("format", string(formatTable), "output format without the TUI: table, json, csv, or survival for the CSV of the survival curve")
+	flag.Parse()
+
+	isFlagSet := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) {
+		isFlagSet[f.Name] = true
+	})
+
+	// Any seed can be reproduced, including 0, so only an omitted seed is random
+	var seedOption *int64
+	if isFlagSet["seed"] {
+		seedOption = seed
+	}
+
+	settings := simulator.DefaultSettings()
+	if *rulesFile != "" {
+		tableRules, err := rules.LoadTableRules(*rulesFile)
+		if err != nil {
+			fmt.Printf("Alas, table rules error has happened: %v\n", err)
+			os.Exit(1)
+		}
+		settings.Rules = tableRules
+	}
+
+	settings.Workers = *workers
+	if isFlagSet["bankroll"] {
+		settings.Bankroll = *bankroll
+	}
+	if isFlagSet["bet"] {
+		settings.Rules.MinimumBet = *bet
+	}
+	if isFlagSet["table-max"] {
+		settings.Rules.MaximumBet = *tableMaximum
+	}
+	if isFlagSet["table-max-policy"] {
+		policy, strategyPolicies, err := simulator.ParseTableMaximumPolicies(*tableMaximumPolicy)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid options: %v\n", err)
+			os.Exit(exitUsage)
+		}
+		settings.TableMaximumPolicy = policy
+		settings.StrategyTableMaximumPolicies = strategyPolicies
+	}
+	if isFlagSet["stop-win"] {
+		limit, err := simulator.ParseSessionLimit(*stopWin)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid options: %v\n", err)
+			os.Exit(exitUsage)
+		}
+		settings.StopWin = limit
+	}
+	if isFlagSet["stop-loss"] {
+		limit, err := simulator.ParseSessionLimit(*stopLoss)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid options: %v\n", err)
+			os.Exit(exitUsage)
+		}
+		settings.StopLoss = limit
+	}
+	settings.MaxRounds = *maxRounds
+	settings.MaxShoes = *maxShoes
+	settings.SitOutMaxShoes = *maxSitOutShoes
+	if isFlagSet["pattern-stake"] {
+		stake, err := simulator.ParseStake(*patternStake)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid options: %v\n", err)
+			os.Exit(exitUsage)
+		}
+		settings.PatternStake = stake
+	}
+	if isFlagSet["labouchere-line"] {
+		line, err := simulator.ParseLabouchereLine(*labouchereLine)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid options: %v\n", err)
+			os.Exit(exitUsage)
+		}
+		settings.LabouchereLine = line
+	}
+
+	isHeadless := isFlagSet["strategy"]
+	for _, name := range []string{"games", "save", "format"} {
+		if isFlagSet[name] && !isHeadless {
+			fmt.Fprintf(os.Stderr, "Invalid options: --%s requires --strategy\n", name)
+			os.Exit(exitUsage)
+		}
+	}
+
+	if isHeadless {
+		// Interruption stops the simulation and prints the partial results
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		exitCode := runHeadless(ctx, headlessOptions{
+			strategy:       *strategy,
+			numSimulations: *numSimulations,
+			saveData:       *saveData,
+			format:         *format,
+			seed:           seedOption,
+			settings:       settings,
+		}, os.Stdout, os.Stderr)
+		stop()
+		os.Exit(exitCode)
+	}
+
+	if err := settings.Validate(); err != nil {
+		fmt.Printf("Alas, settings error has happened: %v\n", err)
+		os.Exit(1)
+	}
+
+	p := tea.NewProgram(InitialModel(seedOption, settings))
 
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("Alas, UI error has happened: %v\n", err)
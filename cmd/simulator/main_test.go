@@ -1,16 +1,24 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/adequatica/punto-banco-golango/internal/simulator"
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
 )
 
+func fixedSeed(seed int64) *int64 {
+	return &seed
+}
+
 func TestInitialModel(t *testing.T) {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
@@ -18,6 +26,8 @@ func TestInitialModel(t *testing.T) {
 	ti := textinput.New()
 	ti.Placeholder = fmt.Sprintf("%d", defaultNumberOfSimulations)
 
+	seed := fixedSeed(42)
+
 	expectedModel := model{
 		stateUI:         stateSelectStrategy,
 		cursor:          0,
@@ -25,12 +35,14 @@ func TestInitialModel(t *testing.T) {
 		textInput:       ti,
 		numSimulations:  0,
 		saveData:        false,
+		settings:        simulator.DefaultSettings(),
+		seed:            seed,
 		keys:            defaultKeys,
 		help:            help.New(),
 		spinner:         s,
 	}
 
-	actualModel := InitialModel()
+	actualModel := InitialModel(seed, simulator.DefaultSettings())
 
 	// Compare stateUI
 	if actualModel.stateUI != expectedModel.stateUI {
@@ -62,6 +74,16 @@ func TestInitialModel(t *testing.T) {
 		t.Errorf("saveData mismatch: got %v, want %v", actualModel.saveData, expectedModel.saveData)
 	}
 
+	// Compare settings
+	if !reflect.DeepEqual(actualModel.settings, expectedModel.settings) {
+		t.Errorf("settings mismatch: got %v, want %v", actualModel.settings, expectedModel.settings)
+	}
+
+	// Compare seed
+	if actualModel.seed == nil || *actualModel.seed != *expectedModel.seed {
+		t.Errorf("seed mismatch: got %v, want %d", actualModel.seed, *expectedModel.seed)
+	}
+
 	// Compare keys
 	if !reflect.DeepEqual(actualModel.keys, expectedModel.keys) {
 		t.Errorf("keys mismatch: got %v, want %v", actualModel.keys, expectedModel.keys)
@@ -77,3 +99,263 @@ func TestInitialModel(t *testing.T) {
 		t.Errorf("spinner should have different instance")
 	}
 }
+
+func TestUpdate_SimulationProgress(t *testing.T) {
+	m := InitialModel(fixedSeed(42), simulator.DefaultSettings())
+	m.stateUI = stateRunningSimulation
+	m.numSimulations = 100
+	m.progressCh = make(chan simulator.Progress, 1)
+
+	progress := simulator.Progress{CompletedSimulations: 40, TotalSimulations: 100}
+	updated, cmd := m.Update(simulationProgressMsg(progress))
+
+	actualModel := updated.(model)
+	if !reflect.DeepEqual(actualModel.lastProgress, progress) {
+		t.Errorf("lastProgress mismatch: got %v, want %v", actualModel.lastProgress, progress)
+	}
+	if cmd == nil {
+		t.Errorf("model should keep listening for progress")
+	}
+}
+
+func TestUpdate_CancelSimulation(t *testing.T) {
+	m := InitialModel(fixedSeed(42), simulator.DefaultSettings())
+
+	if m.keys.Cancel.Enabled() {
+		t.Fatalf("cancel key should be disabled before a simulation is started")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.stateUI = stateRunningSimulation
+	m.numSimulations = 100
+	m.cancelSimulation = cancel
+	m.keys.Cancel.SetEnabled(true)
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	actualModel := updated.(model)
+
+	if ctx.Err() == nil {
+		t.Errorf("simulation context should be cancelled")
+	}
+	if !actualModel.isCancelled {
+		t.Errorf("isCancelled should be true")
+	}
+
+	partialStats := simulator.MultipleSimulationsStats{TotalSimulations: 40}
+	updated, _ = actualModel.Update(simulationCompleteMsg{stats: partialStats, err: context.Canceled})
+	actualModel = updated.(model)
+
+	if actualModel.stateUI != stateShowResults {
+		t.Errorf("stateUI mismatch: got %v, want %v", actualModel.stateUI, stateShowResults)
+	}
+	if !reflect.DeepEqual(actualModel.stats, partialStats) {
+		t.Errorf("partial stats should be shown: got %v, want %v", actualModel.stats, partialStats)
+	}
+	if !strings.Contains(actualModel.View(), "cancelled after 40 of 100 games") {
+		t.Errorf("results should mention the cancelled simulation")
+	}
+}
+
+func TestUpdate_SimulationError(t *testing.T) {
+	m := InitialModel(fixedSeed(42), simulator.DefaultSettings())
+	m.stateUI = stateRunningSimulation
+	m.numSimulations = 100
+
+	updated, _ := m.Update(simulationCompleteMsg{err: errors.New("Failed to save simulation data: disk is full")})
+	actualModel := updated.(model)
+
+	if actualModel.stateUI != stateSelectStrategy {
+		t.Errorf("stateUI mismatch: got %v, want %v", actualModel.stateUI, stateSelectStrategy)
+	}
+	if !strings.Contains(actualModel.View(), "Simulation error: Failed to save simulation data: disk is full") {
+		t.Errorf("strategy selection should show the simulation error")
+	}
+
+	// Error is cleared once the next simulation starts
+	updated, _ = actualModel.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	actualModel = updated.(model)
+	actualModel.textInput.SetValue("1")
+	updated, _ = actualModel.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	actualModel = updated.(model)
+	if actualModel.cancelSimulation != nil {
+		defer actualModel.cancelSimulation()
+	}
+
+	if actualModel.simulationErr != nil {
+		t.Errorf("simulation error should be cleared by the next run, got %v", actualModel.simulationErr)
+	}
+}
+
+func TestUpdate_ToggleComparedStrategies(t *testing.T) {
+	m := InitialModel(fixedSeed(42), simulator.DefaultSettings())
+	toggle := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}}
+	down := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}}
+
+	updated, _ := m.Update(toggle)
+	updated, _ = updated.Update(down)
+	updated, _ = updated.Update(toggle)
+	actualModel := updated.(model)
+
+	want := []simulator.StrategyType{simulator.StrategyType(m.strategyOptions[0]), simulator.StrategyType(m.strategyOptions[1])}
+	if got := actualModel.markedStrategies(); !reflect.DeepEqual(got, want) {
+		t.Errorf("markedStrategies() = %v should be %v", got, want)
+	}
+	if !actualModel.isComparison() {
+		t.Errorf("two marked strategies should be compared")
+	}
+
+	// Toggling a marked strategy unmarks it, a single marked strategy runs on its own
+	updated, _ = actualModel.Update(toggle)
+	actualModel = updated.(model)
+	if actualModel.isComparison() {
+		t.Errorf("single marked strategy should not be compared")
+	}
+
+	updated, _ = actualModel.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	actualModel = updated.(model)
+	if actualModel.stateUI != stateEnterSimulations {
+		t.Fatalf("stateUI mismatch: got %v, want %v", actualModel.stateUI, stateEnterSimulations)
+	}
+	if actualModel.selectedStrategy != want[0] {
+		t.Errorf("selectedStrategy = %s should be the marked %s", actualModel.selectedStrategy, want[0])
+	}
+	if actualModel.keys.Toggle.Enabled() {
+		t.Errorf("toggle key should be disabled outside of the strategy selection")
+	}
+}
+
+func TestUpdate_ComparisonComplete(t *testing.T) {
+	m := InitialModel(fixedSeed(42), simulator.DefaultSettings())
+	m.comparedStrategies[simulator.MartingaleOnBanco] = true
+	m.comparedStrategies[simulator.ParoliOnBanco] = true
+	m.stateUI = stateRunningSimulation
+	m.numSimulations = 10
+
+	comparison := simulator.StrategyComparison{
+		Strategies: []simulator.StrategyStats{
+			{Strategy: simulator.MartingaleOnBanco, Stats: simulator.MultipleSimulationsStats{TotalSimulations: 10, WinRate: 45}},
+			{Strategy: simulator.ParoliOnBanco, Stats: simulator.MultipleSimulationsStats{TotalSimulations: 10, WinRate: 46}},
+		},
+		Differences: []simulator.PairedDifference{
+			{Strategy: simulator.ParoliOnBanco, Baseline: simulator.MartingaleOnBanco, Metric: simulator.MetricWins, MeanDifference: 1},
+		},
+	}
+	updated, _ := m.Update(comparisonCompleteMsg{comparison: comparison})
+	actualModel := updated.(model)
+
+	if actualModel.stateUI != stateShowResults {
+		t.Fatalf("stateUI mismatch: got %v, want %v", actualModel.stateUI, stateShowResults)
+	}
+	view := actualModel.View()
+	for _, want := range []string{"Comparison of 2 strategies", string(simulator.MartingaleOnBanco), string(simulator.ParoliOnBanco), "Paired differences"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("comparison results should contain %q", want)
+		}
+	}
+
+	// Strategies stay marked for the next comparison
+	updated, _ = actualModel.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	actualModel = updated.(model)
+	if actualModel.comparison != nil || !actualModel.isComparison() || !actualModel.keys.Toggle.Enabled() {
+		t.Errorf("returning to the strategy selection should clear the results and keep the marked strategies")
+	}
+}
+
+func TestUpdate_ComparisonError(t *testing.T) {
+	m := InitialModel(fixedSeed(42), simulator.DefaultSettings())
+	m.comparedStrategies[simulator.MartingaleOnBanco] = true
+	m.comparedStrategies[simulator.ParoliOnBanco] = true
+	m.stateUI = stateRunningSimulation
+	m.numSimulations = 10
+
+	updated, _ := m.Update(comparisonCompleteMsg{err: errors.New("Failed to deal game 3")})
+	actualModel := updated.(model)
+
+	if actualModel.stateUI != stateSelectStrategy || actualModel.comparison != nil {
+		t.Errorf("comparison error should return to the strategy selection without results")
+	}
+	if !strings.Contains(actualModel.View(), "Simulation error: Failed to deal game 3") {
+		t.Errorf("strategy selection should show the comparison error")
+	}
+}
+
+func TestUpdate_ToggleDistributions(t *testing.T) {
+	m := InitialModel(fixedSeed(42), simulator.DefaultSettings())
+	distributions := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'h'}}
+
+	if m.keys.Distributions.Enabled() {
+		t.Fatalf("distributions key should be disabled before the results are shown")
+	}
+
+	m.stateUI = stateRunningSimulation
+	m.numSimulations = 10
+	stats, _ := simulator.RunMultipleSimulations(context.Background(), simulator.BetOnBanco, simulator.DefaultSettings(), 10, false, 42, nil)
+	updated, _ := m.Update(simulationCompleteMsg{stats: stats})
+	updated, _ = updated.Update(distributions)
+	actualModel := updated.(model)
+
+	if actualModel.resultsView != viewDistributions {
+		t.Fatalf("distributions should be shown")
+	}
+	if !strings.Contains(actualModel.View(), "Distributions of values of games") {
+		t.Errorf("results should show the distributions")
+	}
+
+	updated, _ = actualModel.Update(distributions)
+	if updated.(model).resultsView != viewStatistics {
+		t.Errorf("distributions key should switch back to the statistics")
+	}
+}
+
+func TestUpdate_ToggleSurvival(t *testing.T) {
+	m := InitialModel(fixedSeed(42), simulator.DefaultSettings())
+	m.stateUI = stateRunningSimulation
+	m.numSimulations = 10
+	stats, _ := simulator.RunMultipleSimulations(context.Background(), simulator.BetOnBanco, simulator.DefaultSettings(), 10, false, 42, nil)
+	updated, _ := m.Update(simulationCompleteMsg{stats: stats})
+
+	survival := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}}
+	updated, _ = updated.Update(survival)
+	actualModel := updated.(model)
+	if actualModel.resultsView != viewSurvival {
+		t.Fatalf("risk of ruin should be shown")
+	}
+	if !strings.Contains(actualModel.View(), "Games alive by round") {
+		t.Errorf("results should show the survival curve")
+	}
+
+	updated, _ = actualModel.Update(survival)
+	if updated.(model).resultsView != viewStatistics {
+		t.Errorf("risk of ruin key should switch back to the statistics")
+	}
+}
+
+func TestUpdate_ToggleTrajectories(t *testing.T) {
+	m := InitialModel(fixedSeed(42), simulator.DefaultSettings())
+	trajectories := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}}
+
+	if m.keys.Trajectories.Enabled() {
+		t.Fatalf("bankroll charts key should be disabled before the results are shown")
+	}
+
+	m.stateUI = stateRunningSimulation
+	m.numSimulations = 10
+	stats, _ := simulator.RunMultipleSimulations(context.Background(), simulator.BetOnBanco, simulator.DefaultSettings(), 10, false, 42, nil)
+	updated, _ := m.Update(simulationCompleteMsg{stats: stats})
+	updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'h'}})
+	updated, _ = updated.Update(trajectories)
+	actualModel := updated.(model)
+
+	if actualModel.resultsView != viewTrajectories {
+		t.Fatalf("bankroll charts should replace the distributions")
+	}
+	if !strings.Contains(actualModel.View(), "Bankroll of all games by round") {
+		t.Errorf("results should show the bankroll charts")
+	}
+
+	updated, _ = actualModel.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	actualModel = updated.(model)
+	if actualModel.resultsView != viewStatistics || actualModel.keys.Trajectories.Enabled() {
+		t.Errorf("returning to the strategy selection should reset the results view and disable the key")
+	}
+}
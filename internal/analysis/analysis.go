@@ -0,0 +1,277 @@
+package analysis
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/adequatica/punto-banco-golango/internal/deck"
+	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
+	"github.com/adequatica/punto-banco-golango/internal/rules"
+)
+
+// Number of card values, tens and face cards count as 0
+const numberOfValues = 10
+
+// Sequences of a coup are counted in 64-bit integers, which holds for shoes up to 8 decks
+const maxCards = 8 * 52
+
+// Numbers of cards of each value in a shoe, the index is the value of the card
+type Composition [numberOfValues]int
+
+// Composition of a fresh shoe of a number of decks
+func NewComposition(numberOfDecks int) Composition {
+	var c Composition
+	for _, card := range deck.Cards {
+		c[card.Value] += len(deck.Suits) * numberOfDecks
+	}
+	return c
+}
+
+// Composition of the cards left in a shoe
+func CompositionOf(cards []deck.Card) Composition {
+	var c Composition
+	for _, card := range cards {
+		c[card.Value]++
+	}
+	return c
+}
+
+func (c Composition) Total() int {
+	total := 0
+	for _, count := range c {
+		total += count
+	}
+	return total
+}
+
+func (c Composition) Validate() error {
+	for value, count := range c {
+		if count < 0 {
+			return fmt.Errorf("number of cards of value %d should not be negative, got %d", value, count)
+		}
+	}
+	if total := c.Total(); total < deck.MaxCardsPerCoup || total > maxCards {
+		return fmt.Errorf("number of cards should be between %d and %d, got %d", deck.MaxCardsPerCoup, maxCards, total)
+	}
+	return nil
+}
+
+// Exact odds of a coup dealt from a shoe. Every ordered sequence of six cards is counted once,
+// coups of four or five cards are counted with every way to deal the cards left over, so the numbers of
+// sequences of the results add up to all sequences, and probabilities are their exact ratios.
+type Analysis struct {
+	Cards     int   `json:"cards"`
+	Sequences int64 `json:"sequences"`
+	PuntoWins int64 `json:"puntoWins"`
+	BancoWins int64 `json:"bancoWins"`
+	Ties      int64 `json:"ties"`
+
+	PuntoProbability float64 `json:"puntoProbability"`
+	BancoProbability float64 `json:"bancoProbability"`
+	TieProbability   float64 `json:"tieProbability"`
+
+	// Expected loss of the gambler per amount wagered in percent, Égalité is a push for Punto and Banco bets
+	PuntoHouseEdge float64 `json:"puntoHouseEdge"`
+	BancoHouseEdge float64 `json:"bancoHouseEdge"`
+	TieHouseEdge   float64 `json:"tieHouseEdge"`
+}
+
+// Enumerate all coups that can be dealt from the composition by the drawing rules of the game
+func Analyze(composition Composition, tableRules rules.TableRules) (Analysis, error) {
+	if err := composition.Validate(); err != nil {
+		return Analysis{}, fmt.Errorf("Invalid composition of the shoe: %w", err)
+	}
+
+	return enumerate(composition).withHouseEdges(tableRules), nil
+}
+
+// Analyses of fresh shoes by number of decks, they do not depend on other table rules
+var freshShoes sync.Map
+
+// Exact odds of a coup dealt from a fresh shoe of the table, the enumeration is done once per number of decks
+func AnalyzeShoe(tableRules rules.TableRules) (Analysis, error) {
+	if cached, ok := freshShoes.Load(tableRules.NumberOfDecks); ok {
+		return cached.(Analysis).withHouseEdges(tableRules), nil
+	}
+
+	composition := NewComposition(tableRules.NumberOfDecks)
+	if err := composition.Validate(); err != nil {
+		return Analysis{}, fmt.Errorf("Invalid composition of the shoe: %w", err)
+	}
+
+	analysis := enumerate(composition)
+	freshShoes.Store(tableRules.NumberOfDecks, analysis)
+
+	return analysis.withHouseEdges(tableRules), nil
+}
+
+// Exact odds of the next coup of a game and the probabilities of the side bets on it
+type NextCoupOdds struct {
+	// Cards left in the shoe, zero when the shoe is changed before the next coup
+	CardsLeft            int
+	Odds                 Analysis
+	SideBetProbabilities map[puntobanco.SideBetType]float64
+}
+
+// The shoe is changed before the next coup once it needs a change, so its cards do not matter then
+func AnalyzeNextCoup(shoe deck.Shoe, tableRules rules.TableRules) (NextCoupOdds, error) {
+	next := NextCoupOdds{SideBetProbabilities: make(map[puntobanco.SideBetType]float64)}
+	sideBetProbability := func(sideBet puntobanco.SideBetType) (float64, error) {
+		return SideBetProbability(sideBet, shoe.Cards)
+	}
+
+	var err error
+	if shoe.NeedsChange() {
+		next.Odds, err = AnalyzeShoe(tableRules)
+		sideBetProbability = func(sideBet puntobanco.SideBetType) (float64, error) {
+			return FreshShoeSideBetProbability(sideBet, tableRules.NumberOfDecks)
+		}
+	} else {
+		next.CardsLeft = len(shoe.Cards)
+		next.Odds, err = Analyze(CompositionOf(shoe.Cards), tableRules)
+	}
+	if err != nil {
+		return next, err
+	}
+
+	for _, option := range puntobanco.GetSideBettingOptions() {
+		sideBet := puntobanco.SideBetType(option)
+		probability, err := sideBetProbability(sideBet)
+		if err != nil {
+			return next, err
+		}
+		next.SideBetProbabilities[sideBet] = probability
+	}
+
+	return next, nil
+}
+
+// Expected loss of the gambler per amount wagered on the bet in percent
+func (a Analysis) HouseEdge(betType puntobanco.BetType, tableRules rules.TableRules) float64 {
+	switch betType {
+	case puntobanco.PuntoPlayer:
+		return (a.BancoProbability - a.PuntoProbability) * 100
+	case puntobanco.BancoBanker:
+		return (a.PuntoProbability - a.BancoProbability*(1-tableRules.BancoCommission)) * 100
+	case puntobanco.EgaliteTie:
+		return ((1 - a.TieProbability) - a.TieProbability*tableRules.TiePayout) * 100
+	default:
+		return 0
+	}
+}
+
+func (a Analysis) withHouseEdges(tableRules rules.TableRules) Analysis {
+	a.PuntoHouseEdge = a.HouseEdge(puntobanco.PuntoPlayer, tableRules)
+	a.BancoHouseEdge = a.HouseEdge(puntobanco.BancoBanker, tableRules)
+	a.TieHouseEdge = a.HouseEdge(puntobanco.EgaliteTie, tableRules)
+	return a
+}
+
+// Count the sequences of each result, the composition should be valid
+func enumerate(composition Composition) Analysis {
+	n := int64(composition.Total())
+	analysis := Analysis{
+		Cards:     int(n),
+		Sequences: n * (n - 1) * (n - 2) * (n - 3) * (n - 4) * (n - 5),
+	}
+
+	record := func(puntoPoints int, bancoPoints int, sequences int64) {
+		switch puntobanco.DetermineResult(puntoPoints, bancoPoints) {
+		case puntobanco.PuntoPlayer:
+			analysis.PuntoWins += sequences
+		case puntobanco.BancoBanker:
+			analysis.BancoWins += sequences
+		default:
+			analysis.Ties += sequences
+		}
+	}
+
+	counts := composition
+	// Take a card of the value out of the shoe, the number of ways to do it is the number of such cards
+	take := func(value int) int64 {
+		ways := int64(counts[value])
+		counts[value]--
+		return ways
+	}
+	card := func(value int) deck.Card {
+		return deck.Card{Value: value}
+	}
+
+	// Punto gets the 1st and 3rd cards, Banco gets the 2nd and 4th cards
+	for p1 := range numberOfValues {
+		if counts[p1] == 0 {
+			continue
+		}
+		w1 := take(p1)
+		for b1 := range numberOfValues {
+			if counts[b1] == 0 {
+				continue
+			}
+			w2 := w1 * take(b1)
+			for p2 := range numberOfValues {
+				if counts[p2] == 0 {
+					continue
+				}
+				w3 := w2 * take(p2)
+				for b2 := range numberOfValues {
+					if counts[b2] == 0 {
+						continue
+					}
+					w4 := w3 * take(b2)
+
+					puntoPoints := puntobanco.CountInitialDeal(card(p1), card(p2))
+					bancoPoints := puntobanco.CountInitialDeal(card(b1), card(b2))
+
+					switch {
+					case puntobanco.IsNatural(puntoPoints, bancoPoints):
+						record(puntoPoints, bancoPoints, w4*(n-4)*(n-5))
+
+					case puntoPoints <= 5:
+						for p3 := range numberOfValues {
+							if counts[p3] == 0 {
+								continue
+							}
+							w5 := w4 * take(p3)
+							puntoThirdCard := card(p3)
+							puntoTotal := puntobanco.CountThirdCard(puntoPoints, puntoThirdCard)
+
+							if puntobanco.DrawThirdCardBanco(bancoPoints, &puntoThirdCard) {
+								for b3 := range numberOfValues {
+									w6 := w5 * int64(counts[b3])
+									if w6 > 0 {
+										record(puntoTotal, puntobanco.CountThirdCard(bancoPoints, card(b3)), w6)
+									}
+								}
+							} else {
+								record(puntoTotal, bancoPoints, w5*(n-5))
+							}
+							counts[p3]++
+						}
+
+					case puntobanco.DrawThirdCardBanco(bancoPoints, nil):
+						for b3 := range numberOfValues {
+							w5 := w4 * int64(counts[b3])
+							if w5 > 0 {
+								record(puntoPoints, puntobanco.CountThirdCard(bancoPoints, card(b3)), w5*(n-5))
+							}
+						}
+
+					default:
+						record(puntoPoints, bancoPoints, w4*(n-4)*(n-5))
+					}
+					counts[b2]++
+				}
+				counts[p2]++
+			}
+			counts[b1]++
+		}
+		counts[p1]++
+	}
+
+	sequences := float64(analysis.Sequences)
+	analysis.PuntoProbability = float64(analysis.PuntoWins) / sequences
+	analysis.BancoProbability = float64(analysis.BancoWins) / sequences
+	analysis.TieProbability = float64(analysis.Ties) / sequences
+
+	return analysis
+}
@@ -0,0 +1,253 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/adequatica/punto-banco-golango/internal/deck"
+	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
+	"github.com/adequatica/punto-banco-golango/internal/rules"
+)
+
+func TestNewComposition(t *testing.T) {
+	composition := NewComposition(6)
+
+	if composition[0] != 96 {
+		t.Errorf("6 decks should have 96 cards of value 0, got %d", composition[0])
+	}
+	for value := 1; value < numberOfValues; value++ {
+		if composition[value] != 24 {
+			t.Errorf("6 decks should have 24 cards of value %d, got %d", value, composition[value])
+		}
+	}
+	if composition.Total() != 312 {
+		t.Errorf("Total() = %d should be 312", composition.Total())
+	}
+}
+
+func TestCompositionOf(t *testing.T) {
+	cards := []deck.Card{
+		{Card: "K", Value: 0, Suit: "♠"},
+		{Card: "10", Value: 0, Suit: "♥"},
+		{Card: "A", Value: 1, Suit: "♦"},
+		{Card: "9", Value: 9, Suit: "♣"},
+	}
+
+	want := Composition{2, 1, 0, 0, 0, 0, 0, 0, 0, 1}
+	if got := CompositionOf(cards); got != want {
+		t.Errorf("CompositionOf() = %v should be %v", got, want)
+	}
+}
+
+func TestCompositionValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		composition Composition
+		wantErr     bool
+	}{
+		{name: "fresh shoe of 8 decks", composition: NewComposition(8)},
+		{name: "six cards", composition: Composition{1, 1, 1, 1, 1, 1}},
+		{name: "five cards", composition: Composition{5}, wantErr: true},
+		{name: "negative number of cards", composition: Composition{10, -1}, wantErr: true},
+		{name: "more than 8 decks", composition: NewComposition(9), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.composition.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAnalyze_FreshShoes(t *testing.T) {
+	tests := []struct {
+		numberOfDecks int
+		wantBanco     float64
+		wantPunto     float64
+		wantTie       float64
+	}{
+		{numberOfDecks: 1, wantBanco: 0.459624, wantPunto: 0.446760, wantTie: 0.093615},
+		{numberOfDecks: 6, wantBanco: 0.458653, wantPunto: 0.446279, wantTie: 0.095069},
+		{numberOfDecks: 8, wantBanco: 0.458597, wantPunto: 0.446247, wantTie: 0.095156},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d decks", tt.numberOfDecks), func(t *testing.T) {
+			analysis, err := Analyze(NewComposition(tt.numberOfDecks), rules.DefaultTableRules())
+			if err != nil {
+				t.Fatalf("Analyze() error = %v", err)
+			}
+
+			if analysis.PuntoWins+analysis.BancoWins+analysis.Ties != analysis.Sequences {
+				t.Errorf("sequences of the results should add up to %d", analysis.Sequences)
+			}
+			for _, probability := range []struct {
+				name string
+				got  float64
+				want float64
+			}{
+				{"Banco", analysis.BancoProbability, tt.wantBanco},
+				{"Punto", analysis.PuntoProbability, tt.wantPunto},
+				{"Égalité", analysis.TieProbability, tt.wantTie},
+			} {
+				if math.Abs(probability.got-probability.want) > 1e-6 {
+					t.Errorf("probability of %s = %.6f should be %.6f", probability.name, probability.got, probability.want)
+				}
+			}
+		})
+	}
+}
+
+func TestAnalyze_ExactCounts(t *testing.T) {
+	analysis, err := Analyze(NewComposition(8), rules.DefaultTableRules())
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+
+	// Published numbers of combinations of 8 decks
+	if analysis.BancoWins != 2292252566437888 {
+		t.Errorf("BancoWins = %d should be 2292252566437888", analysis.BancoWins)
+	}
+	if analysis.PuntoWins != 2230518282592256 {
+		t.Errorf("PuntoWins = %d should be 2230518282592256", analysis.PuntoWins)
+	}
+	if analysis.Ties != 475627426473216 {
+		t.Errorf("Ties = %d should be 475627426473216", analysis.Ties)
+	}
+	if analysis.Sequences != 4998398275503360 {
+		t.Errorf("Sequences = %d should be 4998398275503360", analysis.Sequences)
+	}
+}
+
+func TestAnalyze_HouseEdges(t *testing.T) {
+	noCommission := rules.DefaultTableRules()
+	noCommission.BancoCommission = 0
+	tieNineToOne := rules.DefaultTableRules()
+	tieNineToOne.TiePayout = 9
+
+	tests := []struct {
+		name       string
+		tableRules rules.TableRules
+		wantPunto  float64
+		wantBanco  float64
+		wantTie    float64
+	}{
+		{name: "default rules", tableRules: rules.DefaultTableRules(), wantPunto: 1.24, wantBanco: 1.06, wantTie: 14.44},
+		{name: "no commission", tableRules: noCommission, wantPunto: 1.24, wantBanco: -1.24, wantTie: 14.44},
+		{name: "tie pays 9 to 1", tableRules: tieNineToOne, wantPunto: 1.24, wantBanco: 1.06, wantTie: 4.93},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analysis, err := Analyze(NewComposition(6), tt.tableRules)
+			if err != nil {
+				t.Fatalf("Analyze() error = %v", err)
+			}
+
+			if math.Abs(analysis.PuntoHouseEdge-tt.wantPunto) > 0.01 {
+				t.Errorf("PuntoHouseEdge = %.4f should be %.2f", analysis.PuntoHouseEdge, tt.wantPunto)
+			}
+			if math.Abs(analysis.BancoHouseEdge-tt.wantBanco) > 0.01 {
+				t.Errorf("BancoHouseEdge = %.4f should be %.2f", analysis.BancoHouseEdge, tt.wantBanco)
+			}
+			if math.Abs(analysis.TieHouseEdge-tt.wantTie) > 0.01 {
+				t.Errorf("TieHouseEdge = %.4f should be %.2f", analysis.TieHouseEdge, tt.wantTie)
+			}
+		})
+	}
+}
+
+func TestAnalyze_RemainingComposition(t *testing.T) {
+	// Only tens are left, both hands are 0 and no third card changes them
+	tens := Composition{6}
+	analysis, err := Analyze(tens, rules.DefaultTableRules())
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	if analysis.Ties != analysis.Sequences || analysis.TieProbability != 1 {
+		t.Errorf("every coup should be a tie, got %+v", analysis)
+	}
+
+	// Four cards are not enough for a coup
+	nines := Composition{0, 0, 0, 0, 0, 0, 0, 0, 0, 4}
+	if _, err := Analyze(nines, rules.DefaultTableRules()); err == nil {
+		t.Errorf("Analyze() of 4 cards should fail")
+	}
+
+	// Dealt fours change the odds of the rest of the shoe
+	shoe := NewComposition(6)
+	shoe[4] -= 20
+	depleted, err := Analyze(shoe, rules.DefaultTableRules())
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	fresh, _ := Analyze(NewComposition(6), rules.DefaultTableRules())
+	if depleted.BancoHouseEdge == fresh.BancoHouseEdge {
+		t.Errorf("house edge of a depleted shoe should differ from a fresh shoe")
+	}
+}
+
+func TestAnalyzeShoe(t *testing.T) {
+	tableRules := rules.DefaultTableRules()
+
+	shoe, err := AnalyzeShoe(tableRules)
+	if err != nil {
+		t.Fatalf("AnalyzeShoe() error = %v", err)
+	}
+	fresh, _ := Analyze(NewComposition(tableRules.NumberOfDecks), tableRules)
+	if shoe != fresh {
+		t.Errorf("AnalyzeShoe() = %+v should be %+v", shoe, fresh)
+	}
+
+	// Cached enumeration takes the commission of the table
+	tableRules.BancoCommission = 0
+	cached, _ := AnalyzeShoe(tableRules)
+	if cached.BancoHouseEdge != cached.HouseEdge(puntobanco.BancoBanker, tableRules) || cached.BancoHouseEdge >= 0 {
+		t.Errorf("BancoHouseEdge without commission = %.4f should be negative", cached.BancoHouseEdge)
+	}
+
+	tableRules.NumberOfDecks = 0
+	if _, err := AnalyzeShoe(tableRules); err == nil {
+		t.Errorf("AnalyzeShoe() of an empty shoe should fail")
+	}
+}
+
+func TestAnalyzeNextCoup(t *testing.T) {
+	tableRules := rules.DefaultTableRules()
+	fresh, err := AnalyzeShoe(tableRules)
+	if err != nil {
+		t.Fatalf("should not have error analyzing a fresh shoe: %v", err)
+	}
+
+	shoe := deck.Shoe{Cards: deck.MultiplyDeck(deck.MakeNewDeck(deck.Cards, deck.Suits), 1), CutCard: 14}
+	tests := []struct {
+		name          string
+		shoe          deck.Shoe
+		wantCardsLeft int
+	}{
+		{"cards left in the shoe", shoe, 52},
+		{"shoe changed before the coup", deck.Shoe{Cards: shoe.Cards, IsFinished: true}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, err := AnalyzeNextCoup(tt.shoe, tableRules)
+			if err != nil {
+				t.Fatalf("should not have error: %v", err)
+			}
+			if next.CardsLeft != tt.wantCardsLeft {
+				t.Errorf("CardsLeft = %d should be %d", next.CardsLeft, tt.wantCardsLeft)
+			}
+			if (next.Odds == fresh) != (tt.wantCardsLeft == 0) {
+				t.Errorf("odds of a fresh shoe should be used only when the shoe is changed")
+			}
+			if len(next.SideBetProbabilities) != len(puntobanco.GetSideBettingOptions()) {
+				t.Errorf("probabilities of %d side bets should be %d", len(next.SideBetProbabilities), len(puntobanco.GetSideBettingOptions()))
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package analysis
+
+import (
+	"fmt"
+
+	"github.com/adequatica/punto-banco-golango/internal/deck"
+	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
+)
+
+// Exact probability of the side bet winning on a coup dealt from the cards. Side bets are settled
+// on the first four cards of the coup, so each hand is a random pair of distinct cards of the shoe.
+func SideBetProbability(sideBet puntobanco.SideBetType, cards []deck.Card) (float64, error) {
+	if len(cards) < deck.MaxCardsPerCoup {
+		return 0, fmt.Errorf("number of cards should be at least %d, got %d", deck.MaxCardsPerCoup, len(cards))
+	}
+
+	byRank := func(card deck.Card) string { return card.Card }
+	byRankAndSuit := func(card deck.Card) deck.Card { return card }
+
+	switch sideBet {
+	case puntobanco.PuntoPair, puntobanco.BancoPair:
+		pair, _ := pairProbabilities(cards, byRank)
+		return pair, nil
+	case puntobanco.EitherPair:
+		pair, bothPairs := pairProbabilities(cards, byRank)
+		return 2*pair - bothPairs, nil
+	case puntobanco.PerfectPair:
+		pair, bothPairs := pairProbabilities(cards, byRankAndSuit)
+		return 2*pair - bothPairs, nil
+	default:
+		return 0, fmt.Errorf("unknown side bet: %s", sideBet)
+	}
+}
+
+// Exact probability of the side bet winning on a coup dealt from a fresh shoe of a number of decks
+func FreshShoeSideBetProbability(sideBet puntobanco.SideBetType, numberOfDecks int) (float64, error) {
+	return SideBetProbability(sideBet, deck.MultiplyDeck(deck.MakeNewDeck(deck.Cards, deck.Suits), numberOfDecks))
+}
+
+// Expected loss of the gambler per amount wagered on the side bet in percent
+func SideBetHouseEdge(sideBet puntobanco.SideBetType, probability float64) float64 {
+	return ((1 - probability) - probability*sideBet.Payout()) * 100
+}
+
+// Probabilities of a pair in one hand and of pairs in both hands, the cards of a pair have the same key.
+// Ordered draws are counted exactly in integers: a hand of k cards of a key makes k(k-1) pairs,
+// and two hands of the same key take four of its cards.
+func pairProbabilities[K comparable](cards []deck.Card, key func(deck.Card) K) (float64, float64) {
+	counts := make(map[K]int64)
+	for _, card := range cards {
+		counts[key(card)]++
+	}
+
+	var pairs, sameKeyPairs, squaredPairs int64
+	for _, k := range counts {
+		pairs += k * (k - 1)
+		sameKeyPairs += k * (k - 1) * (k - 2) * (k - 3)
+		squaredPairs += k * (k - 1) * k * (k - 1)
+	}
+	// Pairs of two different keys are all products of pairs without the products of a key with itself
+	bothPairs := sameKeyPairs + pairs*pairs - squaredPairs
+
+	n := int64(len(cards))
+	return float64(pairs) / float64(n*(n-1)), float64(bothPairs) / float64(n*(n-1)*(n-2)*(n-3))
+}
@@ -0,0 +1,105 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+
+	"github.com/adequatica/punto-banco-golango/internal/deck"
+	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
+)
+
+func TestFreshShoeSideBetProbability(t *testing.T) {
+	tests := []struct {
+		name          string
+		sideBet       puntobanco.SideBetType
+		numberOfDecks int
+		wantEdge      float64
+	}{
+		{name: "Punto pair of 8 decks", sideBet: puntobanco.PuntoPair, numberOfDecks: 8, wantEdge: 10.36},
+		{name: "Banco pair of 8 decks", sideBet: puntobanco.BancoPair, numberOfDecks: 8, wantEdge: 10.36},
+		{name: "Punto pair of 6 decks", sideBet: puntobanco.PuntoPair, numberOfDecks: 6, wantEdge: 11.25},
+		{name: "Either pair of 8 decks", sideBet: puntobanco.EitherPair, numberOfDecks: 8, wantEdge: 13.71},
+		{name: "Perfect pair of 8 decks", sideBet: puntobanco.PerfectPair, numberOfDecks: 8, wantEdge: 13.03},
+		// A single deck has no two cards of the same rank and suit
+		{name: "Perfect pair of a single deck", sideBet: puntobanco.PerfectPair, numberOfDecks: 1, wantEdge: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			probability, err := FreshShoeSideBetProbability(tt.sideBet, tt.numberOfDecks)
+			if err != nil {
+				t.Fatalf("FreshShoeSideBetProbability() error = %v", err)
+			}
+
+			if edge := SideBetHouseEdge(tt.sideBet, probability); math.Abs(edge-tt.wantEdge) > 0.01 {
+				t.Errorf("house edge = %.4f should be %.2f", edge, tt.wantEdge)
+			}
+		})
+	}
+
+	// A pair takes one of the 4d-1 cards of the rank of the first card out of the 52d-1 cards left
+	probability, _ := FreshShoeSideBetProbability(puntobanco.PuntoPair, 8)
+	if want := 31.0 / 415.0; math.Abs(probability-want) > 1e-12 {
+		t.Errorf("probability of a pair of 8 decks = %v should be %v", probability, want)
+	}
+}
+
+func TestSideBetProbability_Enumeration(t *testing.T) {
+	cards := []deck.Card{
+		{Card: "A", Value: 1, Suit: "Spades"},
+		{Card: "A", Value: 1, Suit: "Spades"},
+		{Card: "A", Value: 1, Suit: "Hearts"},
+		{Card: "K", Value: 0, Suit: "Clubs"},
+		{Card: "K", Value: 0, Suit: "Clubs"},
+		{Card: "Q", Value: 0, Suit: "Clubs"},
+		{Card: "7", Value: 7, Suit: "Diamonds"},
+		{Card: "7", Value: 7, Suit: "Hearts"},
+	}
+
+	// Every ordered deal of the first four cards, Punto gets the 1st and the 3rd cards
+	wins := make(map[puntobanco.SideBetType]int)
+	deals := 0
+	for a := range cards {
+		for b := range cards {
+			for c := range cards {
+				for d := range cards {
+					if a == b || a == c || a == d || b == c || b == d || c == d {
+						continue
+					}
+					deals++
+					gameState := puntobanco.GameResultState{
+						PuntoState: &puntobanco.PlayerState{FirstCard: &cards[a], SecondCard: &cards[c]},
+						BancoState: &puntobanco.PlayerState{FirstCard: &cards[b], SecondCard: &cards[d]},
+					}
+					for _, option := range puntobanco.GetSideBettingOptions() {
+						if gameState.IsSideBetWon(puntobanco.SideBetType(option)) {
+							wins[puntobanco.SideBetType(option)]++
+						}
+					}
+				}
+			}
+		}
+	}
+
+	for _, option := range puntobanco.GetSideBettingOptions() {
+		sideBet := puntobanco.SideBetType(option)
+		t.Run(option, func(t *testing.T) {
+			probability, err := SideBetProbability(sideBet, cards)
+			if err != nil {
+				t.Fatalf("SideBetProbability() error = %v", err)
+			}
+			if want := float64(wins[sideBet]) / float64(deals); math.Abs(probability-want) > 1e-12 {
+				t.Errorf("SideBetProbability() = %v should be %v", probability, want)
+			}
+		})
+	}
+}
+
+func TestSideBetProbability_Invalid(t *testing.T) {
+	if _, err := SideBetProbability(puntobanco.PuntoPair, make([]deck.Card, deck.MaxCardsPerCoup-1)); err == nil {
+		t.Error("SideBetProbability() of too few cards should return an error")
+	}
+	if _, err := FreshShoeSideBetProbability(puntobanco.SideBetType("Dragon bonus"), 8); err == nil {
+		t.Error("SideBetProbability() of an unknown side bet should return an error")
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"math"
 	"math/rand"
 	"time"
+
+	"github.com/adequatica/punto-banco-golango/internal/rules"
 )
 
 type BlankCard struct {
@@ -36,10 +38,48 @@ var Cards = []BlankCard{
 
 var Suits = []string{"Spades", "Clubs", "Hearts", "Diamonds"}
 
-var NumberOfDecks = 6
+// A coup never takes more than six cards (two hands of three cards)
+const MaxCardsPerCoup = 6
+
+// The cut card must leave enough cards to complete the coup in progress and deal one more
+const MinCutCardPosition = MaxCardsPerCoup * 2
+
+type Shoe struct {
+	Cards []Card `json:"cards"`
+	// Number of cards behind the cut card
+	CutCard int `json:"cutCard"`
+	// The last coup after the cut card has been dealt, and the shoe has to be changed
+	IsFinished bool `json:"isFinished"`
+}
+
+// The cut card comes out once fewer cards than its position are left,
+// then the round in progress is completed, and one more coup is dealt
+func (s Shoe) IsCutCardOut() bool {
+	return len(s.Cards) < s.CutCard
+}
+
+// The shoe is changed after the coup that follows the cut card,
+// or when it cannot cover a whole coup anymore
+func (s Shoe) NeedsChange() bool {
+	return s.IsFinished || len(s.Cards) < MaxCardsPerCoup
+}
+
+// Source of randomness for shuffling and cutting the shoe.
+// *rand.Rand satisfies it, so a seeded generator makes every shoe reproducible.
+type Shuffler interface {
+	Intn(n int) int
+	Int63() int64
+	Shuffle(n int, swap func(i, j int))
+}
 
-// It takes seven shuffles to randomize a deck of card
-var NumberOfShuffles = 7
+func NewShuffler(seed int64) Shuffler {
+	return rand.New(rand.NewSource(seed))
+}
+
+// Seed for a session that is not meant to be replayed
+func NewRandomSeed() int64 {
+	return time.Now().UnixNano()
+}
 
 func MakeNewDeck(cards []BlankCard, suits []string) []Card {
 	total := len(cards) * len(suits)
@@ -68,25 +108,21 @@ func MultiplyDeck(deck []Card, multiplier int) []Card {
 	return severalDecks
 }
 
-func ShuffleDeck(deck []Card) []Card {
-	var random = rand.New(rand.NewSource(time.Now().UnixNano()))
-
-	for i := 0; i < NumberOfShuffles; i++ {
-		random.Shuffle(len(deck), func(i, j int) {
+func ShuffleDeck(deck []Card, numberOfShuffles int, shuffler Shuffler) []Card {
+	for i := 0; i < numberOfShuffles; i++ {
+		shuffler.Shuffle(len(deck), func(i, j int) {
 			deck[i], deck[j] = deck[j], deck[i]
 		})
 	}
 	return deck
 }
 
-func CutDeck(deck []Card) []Card {
+func CutDeck(deck []Card, shuffler Shuffler) []Card {
 	if len(deck) <= 1 {
 		return deck
 	}
 
-	var random = rand.New(rand.NewSource(time.Now().UnixNano()))
-
-	cutPoint := random.Intn(len(deck)-1) + 1
+	cutPoint := shuffler.Intn(len(deck)-1) + 1
 	cutDeck := make([]Card, len(deck))
 
 	copy(cutDeck, deck[cutPoint:])
@@ -120,14 +156,33 @@ func BurnCards(deck []Card) []Card {
 	return remainingDeck
 }
 
-func MakeNewShoe() []Card {
+func GetCutCardPosition(shoeLength int, tableRules rules.TableRules) int {
+	position := tableRules.CutCardPosition
+	if tableRules.Penetration > 0 && tableRules.Penetration < 1 {
+		position = int(math.Round(float64(shoeLength) * (1 - tableRules.Penetration)))
+	}
+
+	if position < MinCutCardPosition {
+		position = MinCutCardPosition
+	}
+
+	return position
+}
+
+func MakeNewShoe(tableRules rules.TableRules, shuffler Shuffler) Shoe {
 	deck := MakeNewDeck(Cards, Suits)
-	shoe := MultiplyDeck(deck, NumberOfDecks)
-	ShuffleDeck(shoe)
-	shoe = CutDeck(shoe)
-	shoe = BurnCards(shoe)
+	cards := MultiplyDeck(deck, tableRules.NumberOfDecks)
+	ShuffleDeck(cards, tableRules.NumberOfShuffles, shuffler)
+	cards = CutDeck(cards, shuffler)
+	if tableRules.BurnPolicy != rules.BurnNone {
+		cards = BurnCards(cards)
+	}
 
-	return shoe
+	return Shoe{
+		Cards:      cards,
+		CutCard:    GetCutCardPosition(len(cards), tableRules),
+		IsFinished: false,
+	}
 }
 
 func GetRemainingRounds(deck []Card) string {
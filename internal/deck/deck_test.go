@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+
+	"github.com/adequatica/punto-banco-golango/internal/rules"
 )
 
 var DefaultNumberOfCards = 52
@@ -19,8 +21,8 @@ func TestMakeNewDeck(t *testing.T) {
 
 func TestMultiplyDeck(t *testing.T) {
 	originalDeck := MakeNewDeck(Cards, Suits)
-	multipleDecks := MultiplyDeck(originalDeck, NumberOfDecks)
-	want := DefaultNumberOfCards * NumberOfDecks
+	multipleDecks := MultiplyDeck(originalDeck, rules.DefaultNumberOfDecks)
+	want := DefaultNumberOfCards * rules.DefaultNumberOfDecks
 
 	if len(multipleDecks) != want {
 		t.Errorf("multiplied deck length of %d should have %d cards", len(multipleDecks), want)
@@ -31,7 +33,7 @@ func TestShuffleDeck(t *testing.T) {
 	originalDeck := MakeNewDeck(Cards, Suits)
 	originalLength := len(originalDeck)
 
-	shuffledDeck := ShuffleDeck(MakeNewDeck(Cards, Suits))
+	shuffledDeck := ShuffleDeck(MakeNewDeck(Cards, Suits), rules.DefaultNumberOfShuffles, NewShuffler(NewRandomSeed()))
 	shuffledLength := len(shuffledDeck)
 
 	if shuffledLength != originalLength {
@@ -57,7 +59,7 @@ func TestShuffleDeck(t *testing.T) {
 
 func TestCutDeck(t *testing.T) {
 	originalDeck := MakeNewDeck(Cards, Suits)
-	cuttedDecks := CutDeck(originalDeck)
+	cuttedDecks := CutDeck(originalDeck, NewShuffler(NewRandomSeed()))
 
 	if len(cuttedDecks) != len(originalDeck) {
 		t.Errorf("cutted deck length of %d should have %d cards as original deck", len(cuttedDecks), len(originalDeck))
@@ -100,29 +102,208 @@ func TestBurnCards(t *testing.T) {
 }
 
 func TestMakeNewShow(t *testing.T) {
-	get := MakeNewShoe()
-	want := DefaultNumberOfCards * NumberOfDecks
+	get := MakeNewShoe(rules.DefaultTableRules(), NewShuffler(NewRandomSeed()))
+	want := DefaultNumberOfCards * rules.DefaultNumberOfDecks
 
 	wantMinLength := want - 11
 	wantMaxLength := want - 1
 
-	if len(get) < wantMinLength || len(get) > wantMaxLength {
-		t.Errorf("shoe length of %d should be between %d and %d cards after burning", len(get), wantMinLength, wantMaxLength)
+	if len(get.Cards) < wantMinLength || len(get.Cards) > wantMaxLength {
+		t.Errorf("shoe length of %d should be between %d and %d cards after burning", len(get.Cards), wantMinLength, wantMaxLength)
 	}
 
 	originalDeck := MakeNewDeck(Cards, Suits)
-	multipleDecks := MultiplyDeck(originalDeck, NumberOfDecks)
-	cardsRemoved := len(multipleDecks) - len(get)
+	multipleDecks := MultiplyDeck(originalDeck, rules.DefaultNumberOfDecks)
+	cardsRemoved := len(multipleDecks) - len(get.Cards)
 	pseudoMultipleDecks := multipleDecks[cardsRemoved:]
 
-	if reflect.DeepEqual(get, pseudoMultipleDecks) {
+	if reflect.DeepEqual(get.Cards, pseudoMultipleDecks) {
 		t.Errorf("shoe should not be equal to unshuffled decks")
 	}
+
+	if get.CutCard != rules.DefaultCutCardPosition {
+		t.Errorf("cut card position of %d should be %d", get.CutCard, rules.DefaultCutCardPosition)
+	}
+
+	if get.IsCutCardOut() || get.IsFinished {
+		t.Errorf("new shoe should not have the cut card out")
+	}
+}
+
+func TestGetCutCardPosition(t *testing.T) {
+	tests := []struct {
+		name            string
+		cutCardPosition int
+		penetration     float64
+		shoeLength      int
+		want            int
+	}{
+		{
+			name:            "cards from the end without penetration",
+			cutCardPosition: 14,
+			penetration:     0,
+			shoeLength:      300,
+			want:            14,
+		},
+		{
+			name:            "penetration takes precedence",
+			cutCardPosition: 14,
+			penetration:     0.75,
+			shoeLength:      300,
+			want:            75,
+		},
+		{
+			name:            "penetration of 90%",
+			cutCardPosition: 14,
+			penetration:     0.9,
+			shoeLength:      300,
+			want:            30,
+		},
+		{
+			name:            "invalid penetration is ignored",
+			cutCardPosition: 16,
+			penetration:     1.5,
+			shoeLength:      300,
+			want:            16,
+		},
+		{
+			name:            "cut card is not placed too close to the end",
+			cutCardPosition: 7,
+			penetration:     0,
+			shoeLength:      300,
+			want:            MinCutCardPosition,
+		},
+		{
+			name:            "deep penetration is limited too",
+			cutCardPosition: 14,
+			penetration:     0.99,
+			shoeLength:      300,
+			want:            MinCutCardPosition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tableRules := rules.DefaultTableRules()
+			tableRules.CutCardPosition = tt.cutCardPosition
+			tableRules.Penetration = tt.penetration
+
+			result := GetCutCardPosition(tt.shoeLength, tableRules)
+			if result != tt.want {
+				t.Errorf("GetCutCardPosition() = %v should be %v", result, tt.want)
+			}
+		})
+	}
+}
+
+func TestShoeIsCutCardOut(t *testing.T) {
+	tests := []struct {
+		name string
+		shoe Shoe
+		want bool
+	}{
+		{
+			name: "cards in front of the cut card",
+			shoe: Shoe{Cards: make([]Card, 20), CutCard: 14},
+			want: false,
+		},
+		{
+			name: "cut card is the next card",
+			shoe: Shoe{Cards: make([]Card, 14), CutCard: 14},
+			want: false,
+		},
+		{
+			name: "cut card has come out",
+			shoe: Shoe{Cards: make([]Card, 13), CutCard: 14},
+			want: true,
+		},
+		{
+			name: "shoe without a cut card",
+			shoe: Shoe{Cards: make([]Card, 3)},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.shoe.IsCutCardOut()
+			if result != tt.want {
+				t.Errorf("IsCutCardOut() = %v should be %v", result, tt.want)
+			}
+		})
+	}
+}
+
+func TestShoeNeedsChange(t *testing.T) {
+	tests := []struct {
+		name string
+		shoe Shoe
+		want bool
+	}{
+		{
+			name: "cards left for a coup",
+			shoe: Shoe{Cards: make([]Card, 20), CutCard: 14},
+			want: false,
+		},
+		{
+			name: "last coup has been dealt",
+			shoe: Shoe{Cards: make([]Card, 20), CutCard: 14, IsFinished: true},
+			want: true,
+		},
+		{
+			name: "fewer cards than a coup needs",
+			shoe: Shoe{Cards: make([]Card, MaxCardsPerCoup-1)},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.shoe.NeedsChange(); result != tt.want {
+				t.Errorf("NeedsChange() = %v should be %v", result, tt.want)
+			}
+		})
+	}
+}
+
+func TestMakeNewShoe_TableRules(t *testing.T) {
+	tableRules := rules.DefaultTableRules()
+	tableRules.NumberOfDecks = 8
+	tableRules.Penetration = 0.75
+	tableRules.BurnPolicy = rules.BurnNone
+
+	get := MakeNewShoe(tableRules, NewShuffler(NewRandomSeed()))
+	want := DefaultNumberOfCards * 8
+
+	if len(get.Cards) != want {
+		t.Errorf("shoe length of %d should be %d cards without burning", len(get.Cards), want)
+	}
+
+	if get.CutCard != want/4 {
+		t.Errorf("cut card position of %d should be %d", get.CutCard, want/4)
+	}
+}
+
+func TestMakeNewShoe_Seed(t *testing.T) {
+	var seed int64 = 42
+
+	first := MakeNewShoe(rules.DefaultTableRules(), NewShuffler(seed))
+	second := MakeNewShoe(rules.DefaultTableRules(), NewShuffler(seed))
+
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("shoes made with the same seed should be equal")
+	}
+
+	other := MakeNewShoe(rules.DefaultTableRules(), NewShuffler(seed+1))
+
+	if reflect.DeepEqual(first, other) {
+		t.Errorf("shoes made with different seeds should not be equal")
+	}
 }
 
 func TestGetRemainingRounds(t *testing.T) {
 	originalDeck := MakeNewDeck(Cards, Suits)
-	multipleDecks := MultiplyDeck(originalDeck, NumberOfDecks)
+	multipleDecks := MultiplyDeck(originalDeck, rules.DefaultNumberOfDecks)
 
 	minIdealRounds := len(multipleDecks) / 6
 	maxIdealRounds := len(multipleDecks) / 4
@@ -168,3 +349,16 @@ func TestGetRemainingRounds(t *testing.T) {
 		})
 	}
 }
+
+func TestMakeNewShoe_NumberOfShuffles(t *testing.T) {
+	var seed int64 = 42
+	tableRules := rules.DefaultTableRules()
+
+	sevenShuffles := MakeNewShoe(tableRules, NewShuffler(seed))
+	tableRules.NumberOfShuffles = 1
+	oneShuffle := MakeNewShoe(tableRules, NewShuffler(seed))
+
+	if reflect.DeepEqual(sevenShuffles, oneShuffle) {
+		t.Errorf("shoes shuffled a different number of times should not be equal")
+	}
+}
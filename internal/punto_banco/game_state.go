@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/adequatica/punto-banco-golango/internal/deck"
+	"github.com/adequatica/punto-banco-golango/internal/rules"
 )
 
 type PlayerState struct {
@@ -18,18 +19,38 @@ type GameResultState struct {
 	Result        *BetType
 	PuntoState    *PlayerState
 	BancoState    *PlayerState
-	RemainingShoe []deck.Card
+	RemainingShoe deck.Shoe
+	// The coup is the first one dealt from a new shoe
+	IsNewShoe bool
+	// The coup is the last one of the shoe, it will be changed before the next coup
+	IsLastCoup bool
 }
 
-func GetNewGameResultState() GameResultState {
+func GetNewGameResultState(tableRules rules.TableRules, shuffler deck.Shuffler) GameResultState {
 	return GameResultState{
 		Result:        nil,
 		PuntoState:    nil,
 		BancoState:    nil,
-		RemainingShoe: deck.MakeNewShoe(),
+		RemainingShoe: deck.MakeNewShoe(tableRules, shuffler),
 	}
 }
 
+// Cards of the coup, Punto's cards first
+func (g *GameResultState) DealtCards() []deck.Card {
+	var cards []deck.Card
+	for _, state := range []*PlayerState{g.PuntoState, g.BancoState} {
+		if state == nil {
+			continue
+		}
+		for _, card := range []*deck.Card{state.FirstCard, state.SecondCard, state.ThirdCard} {
+			if card != nil {
+				cards = append(cards, *card)
+			}
+		}
+	}
+	return cards
+}
+
 func (g *GameResultState) GetResult() *BetType {
 	return g.Result
 }
@@ -38,12 +59,12 @@ func (g *GameResultState) SetResult(result *BetType) {
 	g.Result = result
 }
 
-func (g *GameResultState) GetShoe() []deck.Card {
+func (g *GameResultState) GetShoe() deck.Shoe {
 	return g.RemainingShoe
 }
 
-func (g *GameResultState) SetShoe(shoe []deck.Card) error {
-	if len(shoe) == 0 {
+func (g *GameResultState) SetShoe(shoe deck.Shoe) error {
+	if len(shoe.Cards) == 0 {
 		return fmt.Errorf("empty shoe is not allowed for the next round")
 	}
 
@@ -4,12 +4,13 @@ import (
 	"testing"
 
 	"github.com/adequatica/punto-banco-golango/internal/deck"
+	"github.com/adequatica/punto-banco-golango/internal/rules"
 )
 
 func TestGetNewGameResultState(t *testing.T) {
-	newGameState := GetNewGameResultState()
+	newGameState := GetNewGameResultState(rules.DefaultTableRules(), deck.NewShuffler(deck.NewRandomSeed()))
 
-	if newGameState.GetShoe() == nil {
+	if newGameState.GetShoe().Cards == nil {
 		t.Error("nextShoe should not be nil")
 	}
 
@@ -19,7 +20,7 @@ func TestGetNewGameResultState(t *testing.T) {
 }
 
 func TestGameState_GetResult(t *testing.T) {
-	gameState := GetNewGameResultState()
+	gameState := GetNewGameResultState(rules.DefaultTableRules(), deck.NewShuffler(deck.NewRandomSeed()))
 
 	if gameState.GetResult() != nil {
 		t.Error("GetResult should return nil by default")
@@ -38,7 +39,7 @@ func TestGameState_GetResult(t *testing.T) {
 }
 
 func TestGameState_SetResult(t *testing.T) {
-	gameState := GetNewGameResultState()
+	gameState := GetNewGameResultState(rules.DefaultTableRules(), deck.NewShuffler(deck.NewRandomSeed()))
 
 	result := BancoBanker
 	gameState.SetResult(&result)
@@ -59,41 +60,69 @@ func TestGameState_SetResult(t *testing.T) {
 }
 
 func TestGameState_GetShoe(t *testing.T) {
-	gameState := GetNewGameResultState()
+	gameState := GetNewGameResultState(rules.DefaultTableRules(), deck.NewShuffler(deck.NewRandomSeed()))
 
-	if gameState.GetShoe() == nil {
+	if gameState.GetShoe().Cards == nil {
 		t.Error("GetShoe should not return nil by default")
 	}
 
 	shoe := gameState.GetShoe()
-	if len(shoe) == 0 {
+	if len(shoe.Cards) == 0 {
 		t.Error("GetShoe should return a non-empty slice")
 	}
 }
 
 func TestGameState_SetShoe(t *testing.T) {
 	t.Run("set valid shoe", func(t *testing.T) {
-		gameState := GetNewGameResultState()
+		gameState := GetNewGameResultState(rules.DefaultTableRules(), deck.NewShuffler(deck.NewRandomSeed()))
 
-		validShoe := deck.MakeNewShoe()
+		validShoe := deck.MakeNewShoe(rules.DefaultTableRules(), deck.NewShuffler(deck.NewRandomSeed()))
 		err := gameState.SetShoe(validShoe)
 		if err != nil {
 			t.Errorf("SetShoe should not return error for valid shoe")
 		}
 
 		setShoe := gameState.GetShoe()
-		if len(setShoe) != len(validShoe) {
-			t.Errorf("Set shoe length %d should match input shoe length %d", len(setShoe), len(validShoe))
+		if len(setShoe.Cards) != len(validShoe.Cards) {
+			t.Errorf("Set shoe length %d should match input shoe length %d", len(setShoe.Cards), len(validShoe.Cards))
 		}
 	})
 
 	t.Run("set invalid shoe", func(t *testing.T) {
-		gameState := GetNewGameResultState()
+		gameState := GetNewGameResultState(rules.DefaultTableRules(), deck.NewShuffler(deck.NewRandomSeed()))
 
-		emptyShoe := []deck.Card{}
+		emptyShoe := deck.Shoe{Cards: []deck.Card{}}
 		err := gameState.SetShoe(emptyShoe)
 		if err == nil {
 			t.Error("SetShoe should return error for empty shoe")
 		}
 	})
 }
+
+func TestGameState_DealtCards(t *testing.T) {
+	gameState := GetNewGameResultState(rules.DefaultTableRules(), deck.NewShuffler(deck.NewRandomSeed()))
+	if cards := gameState.DealtCards(); len(cards) != 0 {
+		t.Errorf("DealtCards() before the first coup should be empty, got %v", cards)
+	}
+
+	gameState.PuntoState = &PlayerState{
+		FirstCard:  &deck.Card{Card: "2", Value: 2, Suit: "Spades"},
+		SecondCard: &deck.Card{Card: "3", Value: 3, Suit: "Hearts"},
+		ThirdCard:  &deck.Card{Card: "K", Value: 0, Suit: "Clubs"},
+	}
+	gameState.BancoState = &PlayerState{
+		FirstCard:  &deck.Card{Card: "9", Value: 9, Suit: "Diamonds"},
+		SecondCard: &deck.Card{Card: "A", Value: 1, Suit: "Spades"},
+	}
+
+	cards := gameState.DealtCards()
+	want := []int{2, 3, 0, 9, 1}
+	if len(cards) != len(want) {
+		t.Fatalf("DealtCards() = %v should have %d cards", cards, len(want))
+	}
+	for i, value := range want {
+		if cards[i].Value != value {
+			t.Errorf("card %d has value %d, should be %d", i, cards[i].Value, value)
+		}
+	}
+}
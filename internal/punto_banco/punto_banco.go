@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/adequatica/punto-banco-golango/internal/deck"
+	"github.com/adequatica/punto-banco-golango/internal/rules"
 )
 
 type BetType string
@@ -43,15 +44,19 @@ func IsNatural(puntoPoints int, bancoPoints int) bool {
 	return puntoPoints >= 8 || bancoPoints >= 8
 }
 
-func PlayPuntoBanco(shoe []deck.Card) (GameResultState, error) {
-	// A cut-card is usully placed in front of the seventh from last card to indicate the last round of the shoe
-	if len(shoe) < 8 {
-		shoe = deck.MakeNewShoe()
+func PlayPuntoBanco(shoe deck.Shoe, tableRules rules.TableRules, shuffler deck.Shuffler) (GameResultState, error) {
+	isNewShoe := false
+	if shoe.NeedsChange() {
+		shoe = deck.MakeNewShoe(tableRules, shuffler)
+		isNewShoe = true
 	}
 
+	// The cut card came out during the previous coup, so this coup is the last one of the shoe
+	isLastCoup := shoe.IsCutCardOut()
+
 	// Create a copy of the shoe to avoid modifying the original
-	gameShoe := make([]deck.Card, len(shoe))
-	copy(gameShoe, shoe)
+	gameShoe := make([]deck.Card, len(shoe.Cards))
+	copy(gameShoe, shoe.Cards)
 
 	puntoState := PlayerState{}
 	bancoState := PlayerState{}
@@ -70,37 +75,45 @@ func PlayPuntoBanco(shoe []deck.Card) (GameResultState, error) {
 	puntoState.Points = CountInitialDeal(*puntoState.FirstCard, *puntoState.SecondCard)
 	bancoState.Points = CountInitialDeal(*bancoState.FirstCard, *bancoState.SecondCard)
 
-	// Check for 'natural' (8 or 9)
-	if IsNatural(puntoState.Points, bancoState.Points) {
-		return DetermineGameResultState(puntoState, bancoState, gameShoe), nil
-	}
-
-	// Player's rule for third card
-	if puntoState.Points <= 5 {
-		if len(gameShoe) == 0 {
-			return GameResultState{}, fmt.Errorf("insufficient cards to draw a third card for Punto (player)")
+	// Check for 'natural' (8 or 9), no more cards are drawn in this case
+	if !IsNatural(puntoState.Points, bancoState.Points) {
+		// Player's rule for third card
+		if puntoState.Points <= 5 {
+			if len(gameShoe) == 0 {
+				return GameResultState{}, fmt.Errorf("insufficient cards to draw a third card for Punto (player)")
+			}
+
+			puntoState.ThirdCard = &gameShoe[0]
+			puntoState.Points = CountThirdCard(puntoState.Points, *puntoState.ThirdCard)
+			// Remove drawn card from shoe
+			gameShoe = gameShoe[1:]
 		}
 
-		puntoState.ThirdCard = &gameShoe[0]
-		puntoState.Points = CountThirdCard(puntoState.Points, *puntoState.ThirdCard)
-		// Remove drawn card from shoe
-		gameShoe = gameShoe[1:]
-	}
-
-	// Banker's rule for third card
-	shouldBancoDraw := DrawThirdCardBanco(bancoState.Points, puntoState.ThirdCard)
-	if shouldBancoDraw {
-		if len(gameShoe) == 0 {
-			return GameResultState{}, fmt.Errorf("insufficient cards to draw a third card for Banco (banker)")
+		// Banker's rule for third card
+		shouldBancoDraw := DrawThirdCardBanco(bancoState.Points, puntoState.ThirdCard)
+		if shouldBancoDraw {
+			if len(gameShoe) == 0 {
+				return GameResultState{}, fmt.Errorf("insufficient cards to draw a third card for Banco (banker)")
+			}
+
+			bancoState.ThirdCard = &gameShoe[0]
+			bancoState.Points = CountThirdCard(bancoState.Points, *bancoState.ThirdCard)
+			// Remove drawn card from shoe
+			gameShoe = gameShoe[1:]
 		}
+	}
 
-		bancoState.ThirdCard = &gameShoe[0]
-		bancoState.Points = CountThirdCard(bancoState.Points, *bancoState.ThirdCard)
-		// Remove drawn card from shoe
-		gameShoe = gameShoe[1:]
+	remainingShoe := deck.Shoe{
+		Cards:      gameShoe,
+		CutCard:    shoe.CutCard,
+		IsFinished: isLastCoup,
 	}
 
-	return DetermineGameResultState(puntoState, bancoState, gameShoe), nil
+	gameResult := DetermineGameResultState(puntoState, bancoState, remainingShoe)
+	gameResult.IsNewShoe = isNewShoe
+	gameResult.IsLastCoup = isLastCoup
+
+	return gameResult, nil
 }
 
 func DrawThirdCardBanco(bancoPoints int, puntoThirdCard *deck.Card) bool {
@@ -154,7 +167,7 @@ func DetermineResult(puntoPoints int, bancoPoints int) BetType {
 	}
 }
 
-func DetermineGameResultState(puntoState PlayerState, bancoState PlayerState, remainingShoe []deck.Card) GameResultState {
+func DetermineGameResultState(puntoState PlayerState, bancoState PlayerState, remainingShoe deck.Shoe) GameResultState {
 	winner := DetermineResult(puntoState.Points, bancoState.Points)
 
 	return GameResultState{
@@ -1,9 +1,11 @@
 package puntobanco
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/adequatica/punto-banco-golango/internal/deck"
+	"github.com/adequatica/punto-banco-golango/internal/rules"
 )
 
 func TestGetBettingOptions(t *testing.T) {
@@ -200,9 +202,9 @@ func TestIsNatural(t *testing.T) {
 
 func TestPlayPuntoBanco(t *testing.T) {
 	t.Run("new shoe", func(t *testing.T) {
-		initialShoe := deck.MakeNewShoe()
+		initialShoe := deck.MakeNewShoe(rules.DefaultTableRules(), deck.NewShuffler(deck.NewRandomSeed()))
 
-		got, err := PlayPuntoBanco(initialShoe)
+		got, err := PlayPuntoBanco(initialShoe, rules.DefaultTableRules(), deck.NewShuffler(deck.NewRandomSeed()))
 
 		if err != nil {
 			t.Errorf("should not have error playing the game: %v\n", err)
@@ -221,13 +223,17 @@ func TestPlayPuntoBanco(t *testing.T) {
 			t.Errorf("BancoState should not be nil")
 		}
 
-		if len(got.RemainingShoe) >= len(initialShoe) {
-			t.Errorf("remaining shoe length of %d should be less that initial %d", len(got.RemainingShoe), len(initialShoe))
+		if len(got.RemainingShoe.Cards) >= len(initialShoe.Cards) {
+			t.Errorf("remaining shoe length of %d should be less that initial %d", len(got.RemainingShoe.Cards), len(initialShoe.Cards))
+		}
+
+		if got.IsNewShoe || got.IsLastCoup {
+			t.Errorf("coup in the middle of the shoe should not change the shoe")
 		}
 	})
 
 	t.Run("end of shoe", func(t *testing.T) {
-		initialShoe := []deck.Card{
+		initialCards := []deck.Card{
 			{Card: "A", Value: 1, Suit: "Spades"},
 			{Card: "K", Value: 0, Suit: "Hearts"},
 			{Card: "Q", Value: 0, Suit: "Diamonds"},
@@ -236,8 +242,9 @@ func TestPlayPuntoBanco(t *testing.T) {
 			{Card: "9", Value: 9, Suit: "Hearts"},
 			{Card: "8", Value: 8, Suit: "Diamonds"},
 		}
+		initialShoe := deck.Shoe{Cards: initialCards, CutCard: 14, IsFinished: true}
 
-		got, err := PlayPuntoBanco(initialShoe)
+		got, err := PlayPuntoBanco(initialShoe, rules.DefaultTableRules(), deck.NewShuffler(deck.NewRandomSeed()))
 
 		if err != nil {
 			t.Errorf("should not have error playing the game: %v\n", err)
@@ -256,19 +263,96 @@ func TestPlayPuntoBanco(t *testing.T) {
 			t.Errorf("BancoState should not be nil")
 		}
 
-		if len(got.RemainingShoe) <= len(initialShoe) {
-			// A new shoe (game) should start if initial shoe is less than 8 cards
-			t.Errorf("remaining shoe length of %d should be more that initial %d", len(got.RemainingShoe), len(initialShoe))
+		if len(got.RemainingShoe.Cards) <= len(initialShoe.Cards) {
+			// A new shoe (game) should start after the last coup of the shoe
+			t.Errorf("remaining shoe length of %d should be more that initial %d", len(got.RemainingShoe.Cards), len(initialShoe.Cards))
+		}
+
+		if !got.IsNewShoe {
+			t.Errorf("coup should be dealt from a new shoe")
+		}
+	})
+
+	t.Run("too few cards for a coup", func(t *testing.T) {
+		initialShoe := deck.Shoe{
+			Cards: []deck.Card{
+				{Card: "A", Value: 1, Suit: "Spades"},
+				{Card: "K", Value: 0, Suit: "Hearts"},
+				{Card: "Q", Value: 0, Suit: "Diamonds"},
+				{Card: "J", Value: 0, Suit: "Clubs"},
+				{Card: "10", Value: 0, Suit: "Spades"},
+			},
+		}
+
+		got, err := PlayPuntoBanco(initialShoe, rules.DefaultTableRules(), deck.NewShuffler(deck.NewRandomSeed()))
+
+		if err != nil {
+			t.Errorf("should not have error playing the game: %v\n", err)
+		}
+
+		if !got.IsNewShoe {
+			t.Errorf("coup should be dealt from a new shoe")
+		}
+	})
+
+	t.Run("cut card", func(t *testing.T) {
+		// Natural of 9 for Punto: exactly four cards are dealt
+		cards := []deck.Card{
+			{Card: "9", Value: 9, Suit: "Spades"},
+			{Card: "2", Value: 2, Suit: "Hearts"},
+			{Card: "K", Value: 0, Suit: "Diamonds"},
+			{Card: "3", Value: 3, Suit: "Clubs"},
+		}
+		for i := 0; i < 3; i++ {
+			cards = append(cards, cards[:4]...)
+		}
+		shoe := deck.Shoe{Cards: cards, CutCard: 14}
+		shuffler := deck.NewShuffler(deck.NewRandomSeed())
+
+		// 16 cards -> 12 cards: the cut card comes out during this coup
+		got, err := PlayPuntoBanco(shoe, rules.DefaultTableRules(), shuffler)
+		if err != nil {
+			t.Fatalf("should not have error playing the game: %v\n", err)
+		}
+		if got.IsLastCoup || got.RemainingShoe.IsFinished {
+			t.Errorf("coup during which the cut card comes out should not be the last one")
+		}
+		if !got.RemainingShoe.IsCutCardOut() {
+			t.Errorf("cut card should be out after the coup")
+		}
+
+		// One more coup is dealt after the cut card
+		got, err = PlayPuntoBanco(got.RemainingShoe, rules.DefaultTableRules(), shuffler)
+		if err != nil {
+			t.Fatalf("should not have error playing the game: %v\n", err)
+		}
+		if !got.IsLastCoup || !got.RemainingShoe.IsFinished {
+			t.Errorf("coup after the cut card should be the last one")
+		}
+		if got.IsNewShoe {
+			t.Errorf("last coup should be dealt from the same shoe")
+		}
+		if len(got.RemainingShoe.Cards) != 8 {
+			t.Errorf("remaining shoe length of %d should be 8", len(got.RemainingShoe.Cards))
+		}
+
+		// Then the shoe is changed
+		got, err = PlayPuntoBanco(got.RemainingShoe, rules.DefaultTableRules(), shuffler)
+		if err != nil {
+			t.Fatalf("should not have error playing the game: %v\n", err)
+		}
+		if !got.IsNewShoe || got.IsLastCoup {
+			t.Errorf("coup after the last one should be dealt from a new shoe")
 		}
 	})
 
 	t.Run("multiple shoes game", func(t *testing.T) {
-		currentShoe := deck.MakeNewShoe()
+		currentShoe := deck.MakeNewShoe(rules.DefaultTableRules(), deck.NewShuffler(deck.NewRandomSeed()))
 		// Magic number for test iteration: 312 cards is a full shoe, it should be at least 4 games with full shoe usage
 		inerations := 312
 
 		for i := 0; i < inerations; i++ {
-			got, err := PlayPuntoBanco(currentShoe)
+			got, err := PlayPuntoBanco(currentShoe, rules.DefaultTableRules(), deck.NewShuffler(deck.NewRandomSeed()))
 
 			if err != nil {
 				t.Errorf("should not have error playing the game %d: %v\n", i+1, err)
@@ -289,14 +373,45 @@ func TestPlayPuntoBanco(t *testing.T) {
 				return
 			}
 
-			if len(got.RemainingShoe) == 0 {
+			if len(got.RemainingShoe.Cards) == 0 {
 				t.Errorf("game %d: remaining shoe should not be empty", i+1)
 				return
 			}
+
+			currentShoe = got.RemainingShoe
 		}
 	})
 }
 
+func TestPlayPuntoBanco_Seed(t *testing.T) {
+	var seed int64 = 42
+	// Magic number for test iteration: enough rounds to go through more than one shoe
+	iterations := 200
+
+	firstShuffler := deck.NewShuffler(seed)
+	secondShuffler := deck.NewShuffler(seed)
+	firstShoe := deck.MakeNewShoe(rules.DefaultTableRules(), firstShuffler)
+	secondShoe := deck.MakeNewShoe(rules.DefaultTableRules(), secondShuffler)
+
+	for i := 0; i < iterations; i++ {
+		first, err := PlayPuntoBanco(firstShoe, rules.DefaultTableRules(), firstShuffler)
+		if err != nil {
+			t.Fatalf("should not have error playing the game %d: %v\n", i+1, err)
+		}
+		second, err := PlayPuntoBanco(secondShoe, rules.DefaultTableRules(), secondShuffler)
+		if err != nil {
+			t.Fatalf("should not have error playing the game %d: %v\n", i+1, err)
+		}
+
+		if !reflect.DeepEqual(first, second) {
+			t.Fatalf("game %d: games played with the same seed should be equal", i+1)
+		}
+
+		firstShoe = first.RemainingShoe
+		secondShoe = second.RemainingShoe
+	}
+}
+
 func TestDrawThirdCardBanco(t *testing.T) {
 	// Tableau for punto banco (Banker's total / Player's third card value):
 	// |     | 0 | 1 | 2 | 3 | 4 | 5 | 6 | 7 | 8 | 9 |
@@ -530,13 +645,13 @@ func TestDetermineResult(t *testing.T) {
 }
 
 func TestDetermineGameResultState(t *testing.T) {
-	shoe := deck.MakeNewShoe()
+	shoe := deck.MakeNewShoe(rules.DefaultTableRules(), deck.NewShuffler(deck.NewRandomSeed()))
 
 	tests := []struct {
 		name          string
 		puntoState    PlayerState
 		bancoState    PlayerState
-		remainingShoe []deck.Card
+		remainingShoe deck.Shoe
 		wantResult    BetType
 		wantShoeLen   int
 	}{
@@ -554,9 +669,9 @@ func TestDetermineGameResultState(t *testing.T) {
 				ThirdCard:  nil,
 				Points:     6,
 			},
-			remainingShoe: []deck.Card{
+			remainingShoe: deck.Shoe{Cards: []deck.Card{
 				{Card: "A", Value: 1, Suit: "Spades"},
-			},
+			}},
 			wantResult:  PuntoPlayer,
 			wantShoeLen: 1,
 		},
@@ -574,10 +689,10 @@ func TestDetermineGameResultState(t *testing.T) {
 				ThirdCard:  nil,
 				Points:     8,
 			},
-			remainingShoe: []deck.Card{
+			remainingShoe: deck.Shoe{Cards: []deck.Card{
 				{Card: "A", Value: 1, Suit: "Spades"},
 				{Card: "1", Value: 1, Suit: "Hearts"},
-			},
+			}},
 			wantResult:  BancoBanker,
 			wantShoeLen: 2,
 		},
@@ -595,7 +710,7 @@ func TestDetermineGameResultState(t *testing.T) {
 				ThirdCard:  nil,
 				Points:     7,
 			},
-			remainingShoe: []deck.Card{},
+			remainingShoe: deck.Shoe{Cards: []deck.Card{}},
 			wantResult:    EgaliteTie,
 			wantShoeLen:   0,
 		},
@@ -615,7 +730,7 @@ func TestDetermineGameResultState(t *testing.T) {
 			},
 			remainingShoe: shoe,
 			wantResult:    BancoBanker,
-			wantShoeLen:   len(shoe),
+			wantShoeLen:   len(shoe.Cards),
 		},
 	}
 
@@ -639,8 +754,8 @@ func TestDetermineGameResultState(t *testing.T) {
 				t.Errorf("BancoState should not be nil")
 			}
 
-			if len(got.RemainingShoe) != tt.wantShoeLen {
-				t.Errorf("remaining shoe length of %d should be %d", len(got.RemainingShoe), tt.wantShoeLen)
+			if len(got.RemainingShoe.Cards) != tt.wantShoeLen {
+				t.Errorf("remaining shoe length of %d should be %d", len(got.RemainingShoe.Cards), tt.wantShoeLen)
 			}
 		})
 	}
@@ -0,0 +1,84 @@
+package puntobanco
+
+import "fmt"
+
+// Side bets are placed alongside a bet on Punto, Banco or Égalité and are settled on the first two cards of the hands
+type SideBetType string
+
+const (
+	PuntoPair   SideBetType = "Punto pair (player pair)"
+	BancoPair   SideBetType = "Banco pair (banker pair)"
+	EitherPair  SideBetType = "Either pair"
+	PerfectPair SideBetType = "Perfect pair"
+)
+
+// Standard paytables of pair side bets
+const (
+	// Pair of the same rank in the first two cards of the hand pays 11 to 1
+	PairPayout = 11.0
+	// Pair in either hand pays 5 to 1
+	EitherPairPayout = 5.0
+	// Pair of the same rank and suit in either hand pays 25 to 1
+	PerfectPairPayout = 25.0
+)
+
+func GetSideBettingOptions() []string {
+	return []string{
+		string(PuntoPair),
+		string(BancoPair),
+		string(EitherPair),
+		string(PerfectPair),
+	}
+}
+
+func ParseSideBetType(s string) (SideBetType, error) {
+	for _, option := range GetSideBettingOptions() {
+		if s == option {
+			return SideBetType(option), nil
+		}
+	}
+	return "", fmt.Errorf("unknown side bet: %s", s)
+}
+
+// Winnings per amount wagered on the side bet, the bet itself is returned on top of them
+func (s SideBetType) Payout() float64 {
+	switch s {
+	case PuntoPair, BancoPair:
+		return PairPayout
+	case EitherPair:
+		return EitherPairPayout
+	case PerfectPair:
+		return PerfectPairPayout
+	default:
+		return 0.0
+	}
+}
+
+// First two cards of the hand are of the same rank, tens and face cards do not make a pair with each other
+func (p *PlayerState) IsPair() bool {
+	if p == nil || p.FirstCard == nil || p.SecondCard == nil {
+		return false
+	}
+	return p.FirstCard.Card == p.SecondCard.Card
+}
+
+// First two cards of the hand are of the same rank and suit, which is possible in a shoe of several decks
+func (p *PlayerState) IsPerfectPair() bool {
+	return p.IsPair() && p.FirstCard.Suit == p.SecondCard.Suit
+}
+
+// Side bet wins on the first two cards of the hands, third cards do not matter
+func (g *GameResultState) IsSideBetWon(sideBet SideBetType) bool {
+	switch sideBet {
+	case PuntoPair:
+		return g.PuntoState.IsPair()
+	case BancoPair:
+		return g.BancoState.IsPair()
+	case EitherPair:
+		return g.PuntoState.IsPair() || g.BancoState.IsPair()
+	case PerfectPair:
+		return g.PuntoState.IsPerfectPair() || g.BancoState.IsPerfectPair()
+	default:
+		return false
+	}
+}
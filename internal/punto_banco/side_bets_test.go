@@ -0,0 +1,182 @@
+package puntobanco
+
+import (
+	"testing"
+
+	"github.com/adequatica/punto-banco-golango/internal/deck"
+)
+
+func hand(cards ...deck.Card) *PlayerState {
+	state := &PlayerState{}
+	for i := range cards {
+		switch i {
+		case 0:
+			state.FirstCard = &cards[i]
+		case 1:
+			state.SecondCard = &cards[i]
+		case 2:
+			state.ThirdCard = &cards[i]
+		}
+	}
+	return state
+}
+
+func TestGetSideBettingOptions(t *testing.T) {
+	options := GetSideBettingOptions()
+
+	if len(options) != 4 {
+		t.Fatalf("Side betting options of length %d should be 4", len(options))
+	}
+
+	for _, option := range options {
+		sideBet, err := ParseSideBetType(option)
+		if err != nil || string(sideBet) != option {
+			t.Errorf("ParseSideBetType(%q) = %q, %v", option, sideBet, err)
+		}
+		if sideBet.Payout() <= 0 {
+			t.Errorf("%s should have a payout", sideBet)
+		}
+	}
+
+	if _, err := ParseSideBetType(string(PuntoPlayer)); err == nil {
+		t.Error("ParseSideBetType() of a main bet should return an error")
+	}
+}
+
+func TestSideBetTypePayout(t *testing.T) {
+	tests := []struct {
+		sideBet SideBetType
+		want    float64
+	}{
+		{PuntoPair, 11.0},
+		{BancoPair, 11.0},
+		{EitherPair, 5.0},
+		{PerfectPair, 25.0},
+		{SideBetType("Dragon bonus"), 0.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.sideBet), func(t *testing.T) {
+			if result := tt.sideBet.Payout(); result != tt.want {
+				t.Errorf("Payout() = %.0f should be %.0f", result, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlayerStateIsPair(t *testing.T) {
+	tests := []struct {
+		name          string
+		state         *PlayerState
+		isPair        bool
+		isPerfectPair bool
+	}{
+		{
+			name:  "no hand",
+			state: nil,
+		},
+		{
+			name:  "single card",
+			state: hand(deck.Card{Card: "7", Value: 7, Suit: "Spades"}),
+		},
+		{
+			name:  "different ranks",
+			state: hand(deck.Card{Card: "7", Value: 7, Suit: "Spades"}, deck.Card{Card: "8", Value: 8, Suit: "Spades"}),
+		},
+		{
+			name:  "ten and king are not a pair",
+			state: hand(deck.Card{Card: "10", Value: 0, Suit: "Spades"}, deck.Card{Card: "K", Value: 0, Suit: "Spades"}),
+		},
+		{
+			name:  "third card does not make a pair",
+			state: hand(deck.Card{Card: "2", Value: 2, Suit: "Spades"}, deck.Card{Card: "3", Value: 3, Suit: "Clubs"}, deck.Card{Card: "2", Value: 2, Suit: "Spades"}),
+		},
+		{
+			name:   "pair of different suits",
+			state:  hand(deck.Card{Card: "Q", Value: 0, Suit: "Hearts"}, deck.Card{Card: "Q", Value: 0, Suit: "Clubs"}),
+			isPair: true,
+		},
+		{
+			name:          "perfect pair",
+			state:         hand(deck.Card{Card: "A", Value: 1, Suit: "Diamonds"}, deck.Card{Card: "A", Value: 1, Suit: "Diamonds"}),
+			isPair:        true,
+			isPerfectPair: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.state.IsPair(); result != tt.isPair {
+				t.Errorf("IsPair() = %v should be %v", result, tt.isPair)
+			}
+			if result := tt.state.IsPerfectPair(); result != tt.isPerfectPair {
+				t.Errorf("IsPerfectPair() = %v should be %v", result, tt.isPerfectPair)
+			}
+		})
+	}
+}
+
+func TestGameResultStateIsSideBetWon(t *testing.T) {
+	noPair := hand(deck.Card{Card: "7", Value: 7, Suit: "Spades"}, deck.Card{Card: "8", Value: 8, Suit: "Spades"})
+	pair := hand(deck.Card{Card: "5", Value: 5, Suit: "Hearts"}, deck.Card{Card: "5", Value: 5, Suit: "Clubs"})
+	perfectPair := hand(deck.Card{Card: "J", Value: 0, Suit: "Clubs"}, deck.Card{Card: "J", Value: 0, Suit: "Clubs"})
+
+	tests := []struct {
+		name  string
+		punto *PlayerState
+		banco *PlayerState
+		won   []SideBetType
+	}{
+		{
+			name:  "no pairs",
+			punto: noPair,
+			banco: noPair,
+		},
+		{
+			name:  "pair of Punto",
+			punto: pair,
+			banco: noPair,
+			won:   []SideBetType{PuntoPair, EitherPair},
+		},
+		{
+			name:  "pair of Banco",
+			punto: noPair,
+			banco: pair,
+			won:   []SideBetType{BancoPair, EitherPair},
+		},
+		{
+			name:  "perfect pair of Banco",
+			punto: noPair,
+			banco: perfectPair,
+			won:   []SideBetType{BancoPair, EitherPair, PerfectPair},
+		},
+		{
+			name:  "pairs of both hands",
+			punto: pair,
+			banco: pair,
+			won:   []SideBetType{PuntoPair, BancoPair, EitherPair},
+		},
+		{
+			name: "no cards dealt",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gameState := GameResultState{PuntoState: tt.punto, BancoState: tt.banco}
+
+			for _, option := range GetSideBettingOptions() {
+				sideBet := SideBetType(option)
+				want := false
+				for _, won := range tt.won {
+					if won == sideBet {
+						want = true
+					}
+				}
+				if result := gameState.IsSideBetWon(sideBet); result != want {
+					t.Errorf("IsSideBetWon(%s) = %v should be %v", sideBet, result, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package rendering
+
+import (
+	"fmt"
+
+	"github.com/adequatica/punto-banco-golango/internal/analysis"
+	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Probabilities differ in the fourth decimal place between shoes, so they need more precision than other percentages
+func FormatProbability(value float64) string {
+	return fmt.Sprintf("%.4f%%", value*100)
+}
+
+// Expected win of the gambler per amount wagered with an explicit sign, and a mark when it favours the gambler
+func FormatExpectedValue(value float64) string {
+	if value > 0 {
+		return fmt.Sprintf("+%.2f%% ▲", value)
+	}
+	return FormatPercentage(value)
+}
+
+// Exact probability and house edge of each bet
+func RenderAnalysisTable(a analysis.Analysis) string {
+	columns := []table.Column{
+		{Title: "Bet", Width: 16},
+		{Title: "Probability", Width: 12},
+		{Title: "House edge", Width: 12},
+	}
+
+	rows := []table.Row{
+		{string(puntobanco.PuntoPlayer), FormatProbability(a.PuntoProbability), FormatPercentage(a.PuntoHouseEdge)},
+		{string(puntobanco.BancoBanker), FormatProbability(a.BancoProbability), FormatPercentage(a.BancoHouseEdge)},
+		{string(puntobanco.EgaliteTie), FormatProbability(a.TieProbability), FormatPercentage(a.TieHouseEdge)},
+	}
+
+	return renderAnalysisRows(columns, rows)
+}
+
+// Exact odds of the next coup computed by the game, and the expected value of each bet and side bet
+func RenderNextCoupOdds(next *analysis.NextCoupOdds, err error) string {
+	if err != nil {
+		return fmt.Sprintf("Odds of the next coup are not available: %v\n", err)
+	}
+	if next == nil {
+		return ""
+	}
+
+	header := "Odds of the next coup from a new shoe\n"
+	if next.CardsLeft > 0 {
+		header = fmt.Sprintf("Odds of the next coup from %d cards left in the shoe\n", next.CardsLeft)
+	}
+
+	columns := []table.Column{
+		{Title: "Bet", Width: 24},
+		{Title: "Probability", Width: 12},
+		{Title: "Expected value", Width: 16},
+	}
+
+	odds := next.Odds
+	rows := []table.Row{
+		{string(puntobanco.PuntoPlayer), FormatProbability(odds.PuntoProbability), FormatExpectedValue(-odds.PuntoHouseEdge)},
+		{string(puntobanco.BancoBanker), FormatProbability(odds.BancoProbability), FormatExpectedValue(-odds.BancoHouseEdge)},
+		{string(puntobanco.EgaliteTie), FormatProbability(odds.TieProbability), FormatExpectedValue(-odds.TieHouseEdge)},
+	}
+
+	for _, option := range puntobanco.GetSideBettingOptions() {
+		sideBet := puntobanco.SideBetType(option)
+		probability := next.SideBetProbabilities[sideBet]
+		rows = append(rows, table.Row{option, FormatProbability(probability), FormatExpectedValue(-analysis.SideBetHouseEdge(sideBet, probability))})
+	}
+
+	return header + renderAnalysisRows(columns, rows)
+}
+
+func renderAnalysisRows(columns []table.Column, rows []table.Row) string {
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithRows(rows),
+		table.WithFocused(false),
+		table.WithHeight(len(rows)+1),
+	)
+
+	styles := table.DefaultStyles()
+	styles.Header = styles.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		Bold(true)
+	styles.Selected = styles.Selected.
+		// Reset default selected cell styles
+		UnsetForeground().
+		Bold(false)
+	t.SetStyles(styles)
+
+	return noBorderStyle.Render(t.View())
+}
@@ -0,0 +1,100 @@
+package rendering
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/adequatica/punto-banco-golango/internal/analysis"
+	"github.com/adequatica/punto-banco-golango/internal/deck"
+	"github.com/adequatica/punto-banco-golango/internal/rules"
+)
+
+func TestFormatProbability(t *testing.T) {
+	if got := FormatProbability(0.458653); got != "45.8653%" {
+		t.Errorf("FormatProbability() = %q should be %q", got, "45.8653%")
+	}
+}
+
+func TestRenderAnalysisTable(t *testing.T) {
+	a := analysis.Analysis{
+		PuntoProbability: 0.446279,
+		BancoProbability: 0.458653,
+		TieProbability:   0.095069,
+		PuntoHouseEdge:   1.2374,
+		BancoHouseEdge:   1.0558,
+		TieHouseEdge:     14.4382,
+	}
+
+	got := RenderAnalysisTable(a)
+	for _, want := range []string{"Punto (player)", "Banco (banker)", "Égalité (tie)", "44.6279%", "45.8653%", "9.5069%", "1.24%", "1.06%", "14.44%"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("RenderAnalysisTable() should contain %q", want)
+		}
+	}
+}
+
+func TestFormatExpectedValue(t *testing.T) {
+	tests := []struct {
+		value float64
+		want  string
+	}{
+		{value: -1.0579, want: "-1.06%"},
+		{value: 0.1234, want: "+0.12% ▲"},
+		{value: 0, want: "0.00%"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatExpectedValue(tt.value); got != tt.want {
+			t.Errorf("FormatExpectedValue(%f) = %q should be %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestRenderNextCoupOdds(t *testing.T) {
+	if got := RenderNextCoupOdds(nil, nil); got != "" {
+		t.Errorf("RenderNextCoupOdds() of no odds = %q should be empty", got)
+	}
+	if got := RenderNextCoupOdds(nil, errors.New("empty shoe")); !strings.Contains(got, "not available: empty shoe") {
+		t.Errorf("RenderNextCoupOdds() of an error = %q should mention it", got)
+	}
+
+	tens := make([]deck.Card, 10)
+	for i := range tens {
+		tens[i] = deck.Card{Card: "K", Value: 0, Suit: "Spades"}
+	}
+
+	tests := []struct {
+		name  string
+		shoe  deck.Shoe
+		wants []string
+	}{
+		{
+			name:  "cards left in the shoe",
+			shoe:  deck.Shoe{Cards: tens, CutCard: 2},
+			wants: []string{"from 10 cards left in the shoe", "100.0000%", "+800.00% ▲", "0.0000%", "Perfect pair", "+2500.00% ▲"},
+		},
+		{
+			name:  "last coup of the shoe",
+			shoe:  deck.Shoe{Cards: tens, CutCard: 14, IsFinished: true},
+			wants: []string{"from a new shoe", "45.8653%", "-1.06%", "Either pair", "-14.54%"},
+		},
+		{
+			name:  "too few cards for a coup",
+			shoe:  deck.Shoe{Cards: tens[:5]},
+			wants: []string{"from a new shoe", "44.6279%", "-1.24%"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, err := analysis.AnalyzeNextCoup(tt.shoe, rules.DefaultTableRules())
+			got := RenderNextCoupOdds(&next, err)
+			for _, want := range tt.wants {
+				if !strings.Contains(got, want) {
+					t.Errorf("RenderNextCoupOdds() should contain %q, got %q", want, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package rendering
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	chartWidth  = 60
+	chartHeight = 15
+)
+
+// Grid of cells with the values of the points scaled to rows and the points spread over columns
+type chart struct {
+	cells    [][]string
+	rounds   []int
+	min, max float64
+	format   func(value float64) string
+}
+
+// Values of the chart are scaled to the range of all given values
+func newChart(rounds []int, format func(value float64) string, values ...[]float64) *chart {
+	c := &chart{rounds: rounds, min: math.Inf(1), max: math.Inf(-1), format: format}
+	for _, series := range values {
+		for _, value := range series {
+			c.min = math.Min(c.min, value)
+			c.max = math.Max(c.max, value)
+		}
+	}
+	if c.min == c.max {
+		c.min--
+		c.max++
+	}
+
+	c.cells = make([][]string, chartHeight)
+	for row := range c.cells {
+		c.cells[row] = make([]string, c.width())
+		for column := range c.cells[row] {
+			c.cells[row][column] = " "
+		}
+	}
+
+	return c
+}
+
+func (c *chart) width() int {
+	return min(chartWidth, len(c.rounds))
+}
+
+// Index of the point shown in the column
+func (c *chart) point(column int) int {
+	if c.width() < 2 {
+		return 0
+	}
+	return column * (len(c.rounds) - 1) / (c.width() - 1)
+}
+
+// Rows go from the maximum at the top to the minimum at the bottom
+func (c *chart) row(value float64) int {
+	return int(math.Round((c.max - value) / (c.max - c.min) * float64(chartHeight-1)))
+}
+
+// Cells between two values of each column, the inner band is drawn over the outer one
+func (c *chart) band(low []float64, high []float64, symbol string, style lipgloss.Style) {
+	for column := range c.width() {
+		i := c.point(column)
+		for row := c.row(high[i]); row <= c.row(low[i]); row++ {
+			c.cells[row][column] = style.Render(symbol)
+		}
+	}
+}
+
+// Horizontal line at a value
+func (c *chart) level(value float64, style lipgloss.Style) {
+	if value < c.min || value > c.max {
+		return
+	}
+	row := c.row(value)
+	for column := range c.width() {
+		c.cells[row][column] = style.Render("┄")
+	}
+}
+
+// Points of the series joined by vertical strokes where the value jumps between columns
+func (c *chart) line(values []float64, symbol string, style lipgloss.Style) {
+	previous := -1
+	for column := range c.width() {
+		row := c.row(values[c.point(column)])
+		if previous >= 0 {
+			for r := min(previous, row) + 1; r < max(previous, row); r++ {
+				c.cells[r][column] = style.Render("│")
+			}
+		}
+		c.cells[row][column] = style.Render(symbol)
+		previous = row
+	}
+}
+
+// Values on the left of the top, middle and bottom rows, rounds below the last row
+func (c *chart) render() string {
+	labels := map[int]string{
+		0:               c.format(c.max),
+		chartHeight / 2: c.format((c.max + c.min) / 2),
+		chartHeight - 1: c.format(c.min),
+	}
+	labelWidth := 0
+	for _, label := range labels {
+		labelWidth = max(labelWidth, lipgloss.Width(label))
+	}
+
+	var s strings.Builder
+	for row, cells := range c.cells {
+		fmt.Fprintf(&s, "%*s ┤%s\n", labelWidth, labels[row], strings.Join(cells, ""))
+	}
+
+	firstRound := fmt.Sprintf("%d", c.rounds[0])
+	lastRound := fmt.Sprintf("round %d", c.rounds[len(c.rounds)-1])
+	fmt.Fprintf(&s, "%*s └%s\n", labelWidth, "", strings.Repeat("─", c.width()))
+	padding := max(1, c.width()-len(firstRound)-len(lastRound))
+	fmt.Fprintf(&s, "%*s  %s%s%s\n", labelWidth, "", firstRound, strings.Repeat(" ", padding), lastRound)
+
+	return s.String()
+}
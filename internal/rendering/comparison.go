@@ -0,0 +1,243 @@
+package rendering
+
+import (
+	"fmt"
+
+	"github.com/adequatica/punto-banco-golango/internal/simulator"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+)
+
+var (
+	comparisonCellStyle   = lipgloss.NewStyle().Padding(0, 1)
+	comparisonHeaderStyle = comparisonCellStyle.Bold(true)
+	bestValueStyle        = comparisonCellStyle.Bold(true).Foreground(lipgloss.Color("2")) // Green
+)
+
+// Which value of a row is the best one
+type betterValue int
+
+const (
+	// Neither value is better, e.g. pushes
+	noBetterValue betterValue = iota
+	higherIsBetter
+	lowerIsBetter
+)
+
+type comparisonRow struct {
+	title  string
+	value  func(stats *simulator.MultipleSimulationsStats) float64
+	format func(value float64) string
+	better betterValue
+}
+
+func formatCount(value float64) string {
+	return fmt.Sprintf("%.0f", value)
+}
+
+// Rows follow the rows of the table of a single strategy, the empty title separates the groups
+var comparisonRows = []comparisonRow{
+	// Games played statistics
+	{"Mean rounds per game", func(s *simulator.MultipleSimulationsStats) float64 { return s.AvgRoundsPerGame }, FormatFloat, higherIsBetter},
+	{"Minimum played rounds per game", func(s *simulator.MultipleSimulationsStats) float64 { return float64(s.MinRoundsPlayed) }, formatCount, higherIsBetter},
+	{"Maximum played rounds per game", func(s *simulator.MultipleSimulationsStats) float64 { return float64(s.MaxRoundsPlayed) }, formatCount, higherIsBetter},
+	{"Mean observed rounds per game", func(s *simulator.MultipleSimulationsStats) float64 { return s.AvgRoundsObservedPerGame }, FormatFloat, noBetterValue},
+	{"Rounds with a bet", func(s *simulator.MultipleSimulationsStats) float64 { return s.BetRate }, FormatPercentage, noBetterValue},
+	{},
+	// Wins statistics
+	{"Mean wins per game", func(s *simulator.MultipleSimulationsStats) float64 { return s.AvgWinsPerGames }, FormatFloat, higherIsBetter},
+	{"Minimum wins per game", func(s *simulator.MultipleSimulationsStats) float64 { return float64(s.MinWins) }, formatCount, higherIsBetter},
+	{"Maximum wins per game", func(s *simulator.MultipleSimulationsStats) float64 { return float64(s.MaxWins) }, formatCount, higherIsBetter},
+	// Win rate statistics
+	{"Win rate", func(s *simulator.MultipleSimulationsStats) float64 { return s.WinRate }, FormatPercentage, higherIsBetter},
+	{"Rate of zero-wins games", func(s *simulator.MultipleSimulationsStats) float64 { return s.ZeroWinsRate }, FormatPercentage, lowerIsBetter},
+	// Pushes statistics
+	{"Mean pushes per game", func(s *simulator.MultipleSimulationsStats) float64 { return s.AvgPushesPerGame }, FormatFloat, noBetterValue},
+	{"Push rate", func(s *simulator.MultipleSimulationsStats) float64 { return s.PushRate }, FormatPercentage, noBetterValue},
+	{},
+	// Streaks statistics
+	{"Mean winning streak", func(s *simulator.MultipleSimulationsStats) float64 { return s.AvgMaxWinsStreak }, FormatFloat, higherIsBetter},
+	{"Maximum winning streak", func(s *simulator.MultipleSimulationsStats) float64 { return float64(s.MaxWinsStreak) }, formatCount, higherIsBetter},
+	{"Mean losing streak", func(s *simulator.MultipleSimulationsStats) float64 { return s.AvgMaxLossStreak }, FormatFloat, lowerIsBetter},
+	{"Maximum losing streak", func(s *simulator.MultipleSimulationsStats) float64 { return float64(s.MaxLossStreak) }, formatCount, lowerIsBetter},
+	{},
+	// Bankroll statistics
+	{"Mean peak bankroll per game", func(s *simulator.MultipleSimulationsStats) float64 { return s.AvgMaxBankrollReached }, FormatCurrency, higherIsBetter},
+	{"Maximum recorded bankroll", func(s *simulator.MultipleSimulationsStats) float64 { return s.MaxBankrollReacorded }, FormatCurrency, higherIsBetter},
+	{"Profitable games", func(s *simulator.MultipleSimulationsStats) float64 { return s.ProfitableBankrollRate }, FormatPercentage, higherIsBetter},
+	{"Profitably ended games", func(s *simulator.MultipleSimulationsStats) float64 { return s.ProfitableEndGamesRate }, FormatPercentage, higherIsBetter},
+	// Table maximum statistics
+	{"Games hitting table maximum", func(s *simulator.MultipleSimulationsStats) float64 { return s.TableMaximumHitRate }, FormatPercentage, lowerIsBetter},
+	{"Mean table maximum hits per game", func(s *simulator.MultipleSimulationsStats) float64 { return s.AvgTableMaximumHitsPerGame }, FormatFloat, lowerIsBetter},
+	{},
+	// Exit reasons statistics
+	{"Busted games", func(s *simulator.MultipleSimulationsStats) float64 { return s.BustedRate }, FormatPercentage, lowerIsBetter},
+	{"Games ended at stop-win", func(s *simulator.MultipleSimulationsStats) float64 { return s.GoalHitRate }, FormatPercentage, higherIsBetter},
+	{"Games ended at stop-loss", func(s *simulator.MultipleSimulationsStats) float64 { return s.LossLimitRate }, FormatPercentage, lowerIsBetter},
+	{"Games ended at round cap", func(s *simulator.MultipleSimulationsStats) float64 { return s.RoundCapRate }, FormatPercentage, noBetterValue},
+	{"Games ended by bet over bankroll", func(s *simulator.MultipleSimulationsStats) float64 { return s.BetExceedsBankrollRate }, FormatPercentage, lowerIsBetter},
+	{"Games ended at table maximum", func(s *simulator.MultipleSimulationsStats) float64 { return s.TableMaximumExitRate }, FormatPercentage, lowerIsBetter},
+	{"Games ended at shoe cap", func(s *simulator.MultipleSimulationsStats) float64 { return s.ShoeCapRate }, FormatPercentage, noBetterValue},
+	{"Games ended at sit-out cap", func(s *simulator.MultipleSimulationsStats) float64 { return s.SitOutCapRate }, FormatPercentage, noBetterValue},
+	{},
+	// Money flow statistics
+	{"Mean wagered per game", func(s *simulator.MultipleSimulationsStats) float64 { return s.AvgWageredPerGame }, FormatCurrency, noBetterValue},
+	{"Mean net result per game", func(s *simulator.MultipleSimulationsStats) float64 { return s.AvgNetResultPerGame }, FormatCurrency, higherIsBetter},
+	{"Realised house edge", func(s *simulator.MultipleSimulationsStats) float64 { return s.RealisedHouseEdge }, FormatPercentage, lowerIsBetter},
+	{"Theoretical house edge", func(s *simulator.MultipleSimulationsStats) float64 { return s.TheoreticalHouseEdge }, FormatPercentage, lowerIsBetter},
+	{"Mean side bets wagered per game", func(s *simulator.MultipleSimulationsStats) float64 { return s.AvgSideBetsWageredPerGame }, FormatCurrency, noBetterValue},
+	{"Side bet win rate", func(s *simulator.MultipleSimulationsStats) float64 { return s.SideBetWinRate }, FormatPercentage, noBetterValue},
+	{},
+	// Distributions statistics
+	{"Median rounds per game", func(s *simulator.MultipleSimulationsStats) float64 { return s.RoundsPlayedDistribution.Median }, formatCount, higherIsBetter},
+	{"Median final bankroll", func(s *simulator.MultipleSimulationsStats) float64 { return s.FinalBankrollDistribution.Median }, FormatCurrency, higherIsBetter},
+	{"5th percentile of final bankroll", func(s *simulator.MultipleSimulationsStats) float64 { return s.FinalBankrollDistribution.P5 }, FormatCurrency, higherIsBetter},
+	{"95th percentile of final bankroll", func(s *simulator.MultipleSimulationsStats) float64 { return s.FinalBankrollDistribution.P95 }, FormatCurrency, higherIsBetter},
+	{"Standard deviation of final bankroll", func(s *simulator.MultipleSimulationsStats) float64 { return s.FinalBankrollDistribution.StdDev }, FormatCurrency, noBetterValue},
+}
+
+// Columns of the best value of a row, values are compared as they are shown.
+// Nothing is the best when all values are the same.
+func bestColumns(row comparisonRow, results []simulator.StrategyStats) map[int]bool {
+	best := make(map[int]bool)
+	if row.better == noBetterValue || len(results) < 2 {
+		return best
+	}
+
+	bestValue := row.value(&results[0].Stats)
+	for i := range results {
+		value := row.value(&results[i].Stats)
+		if (row.better == higherIsBetter && value > bestValue) || (row.better == lowerIsBetter && value < bestValue) {
+			bestValue = value
+		}
+	}
+
+	for i := range results {
+		if row.format(row.value(&results[i].Stats)) == row.format(bestValue) {
+			best[i] = true
+		}
+	}
+	if len(best) == len(results) {
+		return map[int]bool{}
+	}
+
+	return best
+}
+
+// Side-by-side stats of strategies played on the same shoes, the best value of each row is highlighted
+func RenderComparisonTable(results []simulator.StrategyStats) string {
+	if len(results) == 0 {
+		return noSimulationsYet
+	}
+
+	headers := []string{"Statistics category"}
+	for _, result := range results {
+		headers = append(headers, string(result.Strategy))
+	}
+
+	rows := make([][]string, 0, len(comparisonRows))
+	bestCells := make(map[int]map[int]bool)
+	for rowIndex, row := range comparisonRows {
+		cells := []string{row.title}
+		for i := range results {
+			if row.title == "" {
+				cells = append(cells, "")
+				continue
+			}
+			cells = append(cells, row.format(row.value(&results[i].Stats)))
+		}
+		rows = append(rows, cells)
+
+		if row.title != "" {
+			bestCells[rowIndex] = bestColumns(row, results)
+		}
+	}
+
+	t := table.New().
+		Border(lipgloss.NormalBorder()).
+		BorderTop(false).
+		BorderBottom(false).
+		BorderLeft(false).
+		BorderRight(false).
+		BorderColumn(false).
+		Headers(headers...).
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return comparisonHeaderStyle
+			}
+			// First column has the titles of the rows
+			if col > 0 && bestCells[row][col-1] {
+				return bestValueStyle
+			}
+			return comparisonCellStyle
+		})
+
+	return noBorderStyle.Render(t.Render())
+}
+
+func formatDifference(metric simulator.PairedMetric, value float64) string {
+	switch metric {
+	case simulator.MetricFinalBankroll, simulator.MetricPeakBankroll:
+		return FormatCurrency(value)
+	case simulator.MetricProfitableEnd:
+		return FormatPercentage(value)
+	default:
+		return fmt.Sprintf("%.2f", value)
+	}
+}
+
+// Differences of the strategies from the baseline game by game, significant differences are highlighted
+func RenderPairedDifferences(differences []simulator.PairedDifference) string {
+	if len(differences) == 0 {
+		return ""
+	}
+
+	headers := []string{"Strategy", "Metric", "Mean difference", "95% confidence interval", "Standard error", "On independent shoes"}
+	rows := make([][]string, 0, len(differences))
+	for _, d := range differences {
+		rows = append(rows, []string{
+			string(d.Strategy),
+			string(d.Metric),
+			formatDifference(d.Metric, d.MeanDifference),
+			fmt.Sprintf("%s to %s", formatDifference(d.Metric, d.ConfidenceLow), formatDifference(d.Metric, d.ConfidenceHigh)),
+			formatDifference(d.Metric, d.StdError),
+			formatDifference(d.Metric, d.UnpairedStdError),
+		})
+	}
+
+	t := table.New().
+		Border(lipgloss.NormalBorder()).
+		BorderTop(false).
+		BorderBottom(false).
+		BorderLeft(false).
+		BorderRight(false).
+		BorderColumn(false).
+		Headers(headers...).
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return comparisonHeaderStyle
+			}
+			// Mean difference column
+			if col == 2 && differences[row].IsSignificant() {
+				return bestValueStyle
+			}
+			return comparisonCellStyle
+		})
+
+	header := fmt.Sprintf("Paired differences from %s over the same games\n", differences[0].Baseline)
+	return header + noBorderStyle.Render(t.Render())
+}
+
+// Stats of every strategy side by side and their differences from the first strategy
+func RenderComparisonStatistics(comparison *simulator.StrategyComparison, numSimulations int, duration float64) string {
+	if comparison == nil || len(comparison.Strategies) == 0 || comparison.Strategies[0].Stats.TotalSimulations == 0 || numSimulations == 0 {
+		return noSimulationsYet
+	}
+
+	header := fmt.Sprintf("Comparison of %d strategies (%d simulations each on the same shoes)\n", len(comparison.Strategies), numSimulations)
+	header += fmt.Sprintf("Simulation completed in: %s\n", FormatDuration(duration))
+
+	return header + RenderComparisonTable(comparison.Strategies) + "\n" + RenderPairedDifferences(comparison.Differences)
+}
@@ -0,0 +1,152 @@
+package rendering
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/adequatica/punto-banco-golango/internal/simulator"
+)
+
+func comparisonResults(winRates ...float64) []simulator.StrategyStats {
+	strategies := simulator.GetStrategyOptions()
+	results := make([]simulator.StrategyStats, 0, len(winRates))
+	for i, winRate := range winRates {
+		results = append(results, simulator.StrategyStats{
+			Strategy: simulator.StrategyType(strategies[i]),
+			Stats:    simulator.MultipleSimulationsStats{TotalSimulations: 10, WinRate: winRate, BustedRate: 100 - winRate},
+		})
+	}
+	return results
+}
+
+func TestBestColumns(t *testing.T) {
+	winRate := comparisonRow{title: "Win rate", value: func(s *simulator.MultipleSimulationsStats) float64 { return s.WinRate }, format: FormatPercentage, better: higherIsBetter}
+	busted := comparisonRow{title: "Busted games", value: func(s *simulator.MultipleSimulationsStats) float64 { return s.BustedRate }, format: FormatPercentage, better: lowerIsBetter}
+	pushes := comparisonRow{title: "Push rate", value: func(s *simulator.MultipleSimulationsStats) float64 { return s.PushRate }, format: FormatPercentage, better: noBetterValue}
+
+	tests := []struct {
+		name    string
+		row     comparisonRow
+		results []simulator.StrategyStats
+		want    map[int]bool
+	}{
+		{
+			name:    "higher is better",
+			row:     winRate,
+			results: comparisonResults(45, 48, 46),
+			want:    map[int]bool{1: true},
+		},
+		{
+			name:    "lower is better",
+			row:     busted,
+			results: comparisonResults(45, 48, 46),
+			want:    map[int]bool{1: true},
+		},
+		{
+			name:    "ties are all the best",
+			row:     winRate,
+			results: comparisonResults(48, 45, 48),
+			want:    map[int]bool{0: true, 2: true},
+		},
+		{
+			name:    "values shown the same are ties",
+			row:     winRate,
+			results: comparisonResults(48.001, 45, 48.002),
+			want:    map[int]bool{0: true, 2: true},
+		},
+		{
+			name:    "nothing is the best when all values are the same",
+			row:     winRate,
+			results: comparisonResults(46, 46),
+			want:    map[int]bool{},
+		},
+		{
+			name:    "row without the better value",
+			row:     pushes,
+			results: comparisonResults(45, 48),
+			want:    map[int]bool{},
+		},
+		{
+			name:    "single strategy",
+			row:     winRate,
+			results: comparisonResults(45),
+			want:    map[int]bool{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := bestColumns(tt.row, tt.results)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("bestColumns() = %v should be %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderComparisonTable(t *testing.T) {
+	if got := RenderComparisonTable(nil); got != noSimulationsYet {
+		t.Errorf("RenderComparisonTable() = %q should be %q", got, noSimulationsYet)
+	}
+
+	results := comparisonResults(45, 48)
+	got := RenderComparisonTable(results)
+
+	wantContains := []string{
+		"Statistics category",
+		string(results[0].Strategy),
+		string(results[1].Strategy),
+		"Win rate",
+		"45.00%",
+		"48.00%",
+		"Games ended at table maximum",
+	}
+	for _, want := range wantContains {
+		if !strings.Contains(got, want) {
+			t.Errorf("RenderComparisonTable() should contain %q", want)
+		}
+	}
+}
+
+func TestRenderPairedDifferences(t *testing.T) {
+	if got := RenderPairedDifferences(nil); got != "" {
+		t.Errorf("RenderPairedDifferences() = %q should be empty", got)
+	}
+
+	differences := []simulator.PairedDifference{
+		{
+			Strategy:         simulator.ParoliOnBanco,
+			Baseline:         simulator.MartingaleOnBanco,
+			Metric:           simulator.MetricFinalBankroll,
+			MeanDifference:   -12.5,
+			StdError:         2,
+			ConfidenceLow:    -16.42,
+			ConfidenceHigh:   -8.58,
+			UnpairedStdError: 9,
+		},
+		{
+			Strategy:       simulator.ParoliOnBanco,
+			Baseline:       simulator.MartingaleOnBanco,
+			Metric:         simulator.MetricRoundsPlayed,
+			MeanDifference: 0.5,
+			ConfidenceLow:  -1.25,
+			ConfidenceHigh: 2.25,
+		},
+	}
+	got := RenderPairedDifferences(differences)
+
+	wantContains := []string{
+		"Paired differences from " + string(simulator.MartingaleOnBanco),
+		"95% confidence interval",
+		"$-12.50",
+		"$-16.42 to $-8.58",
+		"$9.00",
+		"-1.25 to 2.25",
+	}
+	for _, want := range wantContains {
+		if !strings.Contains(got, want) {
+			t.Errorf("RenderPairedDifferences() should contain %q", want)
+		}
+	}
+}
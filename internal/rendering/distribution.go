@@ -0,0 +1,136 @@
+package rendering
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/adequatica/punto-banco-golango/internal/simulator"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const histogramBarWidth = 30
+
+// Values of games are formatted as they are, e.g. whole rounds, mean and standard deviation are formatted as averages
+type distributionRow struct {
+	title        string
+	distribution simulator.Distribution
+	format       func(value float64) string
+	formatMean   func(value float64) string
+}
+
+func distributionRows(stats *simulator.MultipleSimulationsStats) []distributionRow {
+	return []distributionRow{
+		{"Rounds played", stats.RoundsPlayedDistribution, formatCount, FormatFloat},
+		{"Final bankroll", stats.FinalBankrollDistribution, FormatCurrency, FormatCurrency},
+		{"Peak bankroll", stats.PeakBankrollDistribution, FormatCurrency, FormatCurrency},
+		{"Longest winning streak", stats.WinsStreakDistribution, formatCount, FormatFloat},
+		{"Longest losing streak", stats.LossStreakDistribution, formatCount, FormatFloat},
+	}
+}
+
+// Mean, standard deviation and percentiles of the values of games
+func RenderDistributionTable(stats *simulator.MultipleSimulationsStats) string {
+	if stats == nil || stats.TotalSimulations == 0 {
+		return noSimulationsYet
+	}
+
+	columns := []table.Column{
+		{Title: "Distribution", Width: 22},
+		{Title: "Mean", Width: 10},
+		{Title: "Std dev", Width: 10},
+		{Title: "P5", Width: 10},
+		{Title: "P25", Width: 10},
+		{Title: "Median", Width: 10},
+		{Title: "P75", Width: 10},
+		{Title: "P95", Width: 10},
+		{Title: "P99", Width: 10},
+	}
+
+	var rows []table.Row
+	for _, row := range distributionRows(stats) {
+		d := row.distribution
+		rows = append(rows, table.Row{
+			row.title,
+			row.formatMean(d.Mean),
+			row.formatMean(d.StdDev),
+			row.format(d.P5),
+			row.format(d.P25),
+			row.format(d.Median),
+			row.format(d.P75),
+			row.format(d.P95),
+			row.format(d.P99),
+		})
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithRows(rows),
+		table.WithFocused(false),
+		table.WithHeight(len(rows)+1),
+	)
+
+	styles := table.DefaultStyles()
+	styles.Header = styles.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		Bold(true)
+	styles.Selected = styles.Selected.
+		// Reset default selected cell styles
+		UnsetForeground().
+		Bold(false)
+	t.SetStyles(styles)
+
+	return noBorderStyle.Render(t.View())
+}
+
+// Horizontal bars of the bins scaled to the largest bin
+func RenderHistogram(title string, distribution simulator.Distribution, format func(value float64) string) string {
+	if len(distribution.Histogram) == 0 {
+		return ""
+	}
+
+	maxCount := 0
+	ranges := make([]string, 0, len(distribution.Histogram))
+	rangeWidth := 0
+	for _, bin := range distribution.Histogram {
+		maxCount = max(maxCount, bin.Count)
+		r := fmt.Sprintf("%s – %s", format(bin.From), format(bin.To))
+		if format(bin.From) == format(bin.To) {
+			r = format(bin.From)
+		}
+		ranges = append(ranges, r)
+		rangeWidth = max(rangeWidth, lipgloss.Width(r))
+	}
+
+	var s strings.Builder
+	s.WriteString(title + "\n")
+	for i, bin := range distribution.Histogram {
+		bar := 0
+		if maxCount > 0 {
+			bar = bin.Count * histogramBarWidth / maxCount
+		}
+		// Bins with a few games are still visible
+		if bin.Count > 0 && bar == 0 {
+			bar = 1
+		}
+		padding := strings.Repeat(" ", rangeWidth-lipgloss.Width(ranges[i]))
+		fmt.Fprintf(&s, "  %s%s │%s %d\n", ranges[i], padding, strings.Repeat("█", bar), bin.Count)
+	}
+
+	return s.String()
+}
+
+// Table of the distributions with a histogram of each one
+func RenderSimulatorDistributions(stats *simulator.MultipleSimulationsStats) string {
+	if stats == nil || stats.TotalSimulations == 0 {
+		return noSimulationsYet
+	}
+
+	s := RenderDistributionTable(stats) + "\n"
+	for _, row := range distributionRows(stats) {
+		s += "\n" + RenderHistogram(row.title, row.distribution, row.format)
+	}
+
+	return s
+}
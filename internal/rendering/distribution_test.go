@@ -0,0 +1,61 @@
+package rendering
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/adequatica/punto-banco-golango/internal/simulator"
+)
+
+func TestRenderDistributionTable(t *testing.T) {
+	if got := RenderDistributionTable(nil); got != noSimulationsYet {
+		t.Errorf("RenderDistributionTable() = %q should be %q", got, noSimulationsYet)
+	}
+
+	stats := &simulator.MultipleSimulationsStats{
+		TotalSimulations:          100,
+		RoundsPlayedDistribution:  simulator.Distribution{Mean: 52.5, StdDev: 12.25, P5: 31, Median: 50, P99: 97},
+		FinalBankrollDistribution: simulator.Distribution{Mean: 640, P5: 5, Median: 600.5, P95: 1450},
+	}
+	got := RenderDistributionTable(stats)
+
+	for _, want := range []string{"Distribution", "Median", "P99", "Rounds played", "52.5", "12.2", "97", "Final bankroll", "$600.50", "$1450.00", "Longest losing streak"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("RenderDistributionTable() should contain %q", want)
+		}
+	}
+}
+
+func TestRenderHistogram(t *testing.T) {
+	t.Run("no histogram", func(t *testing.T) {
+		if got := RenderHistogram("Rounds played", simulator.Distribution{}, formatCount); got != "" {
+			t.Errorf("RenderHistogram() = %q should be empty", got)
+		}
+	})
+
+	t.Run("bars are scaled to the largest bin", func(t *testing.T) {
+		distribution := simulator.Distribution{
+			Histogram: []simulator.HistogramBin{
+				{From: 0, To: 9, Count: 60},
+				{From: 10, To: 19, Count: 30},
+				{From: 20, To: 20, Count: 1},
+				{From: 21, To: 29, Count: 0},
+			},
+		}
+		got := RenderHistogram("Rounds played", distribution, formatCount)
+		lines := strings.Split(strings.TrimRight(got, "\n"), "\n")
+
+		if len(lines) != 5 || lines[0] != "Rounds played" {
+			t.Fatalf("histogram should have a title and a line for every bin, got %q", got)
+		}
+		wantBars := []int{histogramBarWidth, histogramBarWidth / 2, 1, 0}
+		for i, want := range wantBars {
+			if bars := strings.Count(lines[i+1], "█"); bars != want {
+				t.Errorf("bin %d should have %d bars, got %d: %q", i, want, bars, lines[i+1])
+			}
+		}
+		if !strings.Contains(lines[1], "0 – 9") || !strings.Contains(lines[3], "  20 ") {
+			t.Errorf("bins should show their ranges, a bin of one number shows the number: %q", got)
+		}
+	})
+}
@@ -79,6 +79,24 @@ func RenderBetResult(gameResult *puntobanco.BetType, betString string) string {
 	}
 }
 
+// Results of the side bets placed alongside the bet, they are settled on the first two cards of the hands
+func RenderSideBetResults(gameState *puntobanco.GameResultState, sideBets []puntobanco.SideBetType) string {
+	if gameState == nil || gameState.Result == nil || len(sideBets) == 0 {
+		return ""
+	}
+
+	var result string
+	for _, sideBet := range sideBets {
+		if gameState.IsSideBetWon(sideBet) {
+			result += fmt.Sprintf("%s: you %s %.0f to 1\n", sideBet, greenStyle.Bold(true).Render("won"), sideBet.Payout())
+		} else {
+			result += fmt.Sprintf("%s: you %s\n", sideBet, redStyle.Bold(true).Render("lost"))
+		}
+	}
+
+	return result + "\n"
+}
+
 func RenderDrawnCards(state *puntobanco.PlayerState) string {
 	if state == nil {
 		return "no cards"
@@ -138,3 +156,23 @@ func RenderGameResultState(gameState *puntobanco.GameResultState, betString stri
 
 	return result
 }
+
+func RenderShoeState(gameState *puntobanco.GameResultState) string {
+	if gameState == nil {
+		return ""
+	}
+
+	if gameState.IsLastCoup {
+		return fmt.Sprintf("%s, the shoe will be changed\n\n", blackStyle.Render("It was the last coup of the shoe"))
+	}
+
+	if gameState.RemainingShoe.IsCutCardOut() {
+		return fmt.Sprintf("%s, one more coup will be dealt\n\n", blackStyle.Render("The cut card has come out"))
+	}
+
+	if gameState.IsNewShoe {
+		return fmt.Sprintf("%s\n\n", blackStyle.Render("The coup was dealt from a new shoe"))
+	}
+
+	return ""
+}
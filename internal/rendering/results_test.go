@@ -408,7 +408,7 @@ func TestRenderGameResultState(t *testing.T) {
 				ThirdCard: nil,
 				Points:    0,
 			},
-			RemainingShoe: []deck.Card{},
+			RemainingShoe: deck.Shoe{Cards: []deck.Card{}},
 		}
 
 		result := RenderGameResultState(gameState, string(puntobanco.PuntoPlayer))
@@ -439,7 +439,7 @@ func TestRenderGameResultState(t *testing.T) {
 				ThirdCard:  nil,
 				Points:     1,
 			},
-			RemainingShoe: []deck.Card{},
+			RemainingShoe: deck.Shoe{Cards: []deck.Card{}},
 		}
 
 		result := RenderGameResultState(gameState, string(puntobanco.BancoBanker))
@@ -470,7 +470,7 @@ func TestRenderGameResultState(t *testing.T) {
 				Points:     1,
 			},
 			BancoState:    nil,
-			RemainingShoe: []deck.Card{},
+			RemainingShoe: deck.Shoe{Cards: []deck.Card{}},
 		}
 
 		result := RenderGameResultState(gameState, string(puntobanco.EgaliteTie))
@@ -509,7 +509,7 @@ func TestRenderGameResultState(t *testing.T) {
 				ThirdCard:  nil,
 				Points:     0,
 			},
-			RemainingShoe: []deck.Card{},
+			RemainingShoe: deck.Shoe{Cards: []deck.Card{}},
 		}
 
 		result := RenderGameResultState(gameState, string(puntobanco.PuntoPlayer))
@@ -551,7 +551,7 @@ func TestRenderGameResultState(t *testing.T) {
 				ThirdCard:  nil,
 				Points:     0,
 			},
-			RemainingShoe: []deck.Card{},
+			RemainingShoe: deck.Shoe{Cards: []deck.Card{}},
 		}
 
 		result := RenderGameResultState(gameState, "invalid")
@@ -561,3 +561,94 @@ func TestRenderGameResultState(t *testing.T) {
 		}
 	})
 }
+
+func TestRenderSideBetResults(t *testing.T) {
+	result := puntobanco.BancoBanker
+	gameState := &puntobanco.GameResultState{
+		Result: &result,
+		PuntoState: &puntobanco.PlayerState{
+			FirstCard:  &deck.Card{Card: "6", Value: 6, Suit: "Hearts"},
+			SecondCard: &deck.Card{Card: "6", Value: 6, Suit: "Spades"},
+		},
+		BancoState: &puntobanco.PlayerState{
+			FirstCard:  &deck.Card{Card: "4", Value: 4, Suit: "Clubs"},
+			SecondCard: &deck.Card{Card: "5", Value: 5, Suit: "Clubs"},
+		},
+	}
+
+	if got := RenderSideBetResults(gameState, nil); got != "" {
+		t.Errorf("RenderSideBetResults() without side bets should be empty, got: %s", got)
+	}
+	if got := RenderSideBetResults(&puntobanco.GameResultState{}, []puntobanco.SideBetType{puntobanco.PuntoPair}); got != "" {
+		t.Errorf("RenderSideBetResults() before the coup should be empty, got: %s", got)
+	}
+
+	got := RenderSideBetResults(gameState, []puntobanco.SideBetType{puntobanco.PuntoPair, puntobanco.BancoPair})
+	lines := strings.Split(strings.TrimSpace(got), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("RenderSideBetResults() should have a line per side bet, got: %s", got)
+	}
+	if !strings.Contains(lines[0], string(puntobanco.PuntoPair)) || !strings.Contains(lines[0], "won") || !strings.Contains(lines[0], "11 to 1") {
+		t.Errorf("Punto pair should be won, got: %s", lines[0])
+	}
+	if !strings.Contains(lines[1], string(puntobanco.BancoPair)) || !strings.Contains(lines[1], "lost") {
+		t.Errorf("Banco pair should be lost, got: %s", lines[1])
+	}
+}
+
+func TestRenderShoeState(t *testing.T) {
+	tests := []struct {
+		name      string
+		gameState *puntobanco.GameResultState
+		want      string
+	}{
+		{
+			name:      "nil game state",
+			gameState: nil,
+			want:      "",
+		},
+		{
+			name: "coup in the middle of the shoe",
+			gameState: &puntobanco.GameResultState{
+				RemainingShoe: deck.Shoe{Cards: make([]deck.Card, 100), CutCard: 14},
+			},
+			want: "",
+		},
+		{
+			name: "coup from a new shoe",
+			gameState: &puntobanco.GameResultState{
+				RemainingShoe: deck.Shoe{Cards: make([]deck.Card, 300), CutCard: 14},
+				IsNewShoe:     true,
+			},
+			want: "new shoe",
+		},
+		{
+			name: "cut card has come out",
+			gameState: &puntobanco.GameResultState{
+				RemainingShoe: deck.Shoe{Cards: make([]deck.Card, 10), CutCard: 14},
+			},
+			want: "cut card",
+		},
+		{
+			name: "last coup of the shoe",
+			gameState: &puntobanco.GameResultState{
+				RemainingShoe: deck.Shoe{Cards: make([]deck.Card, 6), CutCard: 14, IsFinished: true},
+				IsLastCoup:    true,
+			},
+			want: "last coup",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := RenderShoeState(tt.gameState)
+
+			if tt.want == "" && result != "" {
+				t.Errorf("RenderShoeState() should be empty, got: %s", result)
+			}
+			if !strings.Contains(result, tt.want) {
+				t.Errorf("RenderShoeState() should contain '%s', got: %s", tt.want, result)
+			}
+		})
+	}
+}
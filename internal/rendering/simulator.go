@@ -35,6 +35,11 @@ func FormatDuration(seconds float64) string {
 	return fmt.Sprintf("%d minutes %.2f seconds", minutes, remainingSeconds)
 }
 
+// Bounds of a confidence interval, e.g. "49.12% – 51.30%"
+func FormatInterval(interval simulator.ConfidenceInterval, format func(value float64) string) string {
+	return fmt.Sprintf("%s – %s", format(interval.Low), format(interval.High))
+}
+
 const noSimulationsYet = "No simulations run yet"
 
 func RenderSimulatorStatistics(stats *simulator.MultipleSimulationsStats, strategy simulator.StrategyType, numSimulations int, duration float64) string {
@@ -50,6 +55,30 @@ func RenderSimulatorStatistics(stats *simulator.MultipleSimulationsStats, strate
 	return header + table
 }
 
+// Counters, speed, estimated time left, and running averages of a simulation in progress
+func RenderSimulatorProgress(progress *simulator.Progress, elapsed float64) string {
+	if progress == nil || progress.TotalSimulations == 0 {
+		return ""
+	}
+
+	s := fmt.Sprintf("Completed: %d of %d games", progress.CompletedSimulations, progress.TotalSimulations)
+	if progress.CompletedSimulations == 0 || elapsed <= 0 {
+		return s + "\n"
+	}
+
+	gamesPerSecond := float64(progress.CompletedSimulations) / elapsed
+	timeLeft := float64(progress.TotalSimulations-progress.CompletedSimulations) / gamesPerSecond
+
+	s += fmt.Sprintf(" (%.0f games per second)\n", gamesPerSecond)
+	s += fmt.Sprintf("Time left: ~%s\n\n", FormatDuration(timeLeft))
+
+	s += fmt.Sprintf("Mean rounds per game: %s\n", FormatFloat(progress.Stats.AvgRoundsPerGame))
+	s += fmt.Sprintf("Win rate: %s\n", FormatPercentage(progress.Stats.WinRate))
+	s += fmt.Sprintf("Mean peak bankroll per game: %s\n", FormatCurrency(progress.Stats.AvgMaxBankrollReached))
+
+	return s
+}
+
 func RenderSimulatorTable(stats *simulator.MultipleSimulationsStats) string {
 	if stats == nil || stats.TotalSimulations == 0 {
 		return noSimulationsYet
@@ -58,33 +87,62 @@ func RenderSimulatorTable(stats *simulator.MultipleSimulationsStats) string {
 	columns := []table.Column{
 		{Title: "Statistics category", Width: 36},
 		{Title: "Value", Width: 10},
+		{Title: "95% confidence interval", Width: 24},
 	}
 
 	rows := []table.Row{
 		// Games played statistics
-		{"Mean rounds per game", FormatFloat(stats.AvgRoundsPerGame)},
-		{"Minimum played rounds per game", fmt.Sprintf("%d", stats.MinRoundsPlayed)},
-		{"Maximum played rounds per game", fmt.Sprintf("%d", stats.MaxRoundsPlayed)},
-		{"", ""},
+		{"Mean rounds per game", FormatFloat(stats.AvgRoundsPerGame), FormatInterval(stats.AvgRoundsPerGameInterval, FormatFloat)},
+		{"Minimum played rounds per game", fmt.Sprintf("%d", stats.MinRoundsPlayed), ""},
+		{"Maximum played rounds per game", fmt.Sprintf("%d", stats.MaxRoundsPlayed), ""},
+		{"Mean observed rounds per game", FormatFloat(stats.AvgRoundsObservedPerGame), ""},
+		{"Rounds with a bet", FormatPercentage(stats.BetRate), ""},
+		{"", "", ""},
 		// Wins statistics
-		{"Mean wins per game", FormatFloat(stats.AvgWinsPerGames)},
-		{"Minimum wins per game", fmt.Sprintf("%d", stats.MinWins)},
-		{"Maximum wins per game", fmt.Sprintf("%d", stats.MaxWins)},
+		{"Mean wins per game", FormatFloat(stats.AvgWinsPerGames), ""},
+		{"Minimum wins per game", fmt.Sprintf("%d", stats.MinWins), ""},
+		{"Maximum wins per game", fmt.Sprintf("%d", stats.MaxWins), ""},
 		// Win rate statistics
-		{"Win rate", FormatPercentage(stats.WinRate)},
-		{"Rate of zero-wins games", FormatPercentage(stats.ZeroWinsRate)},
-		{"", ""},
+		{"Win rate", FormatPercentage(stats.WinRate), FormatInterval(stats.WinRateInterval, FormatPercentage)},
+		{"Rate of zero-wins games", FormatPercentage(stats.ZeroWinsRate), FormatInterval(stats.ZeroWinsRateInterval, FormatPercentage)},
+		// Pushes statistics
+		{"Mean pushes per game", FormatFloat(stats.AvgPushesPerGame), ""},
+		{"Push rate", FormatPercentage(stats.PushRate), ""},
+		{"", "", ""},
 		// Streaks statistics
-		{"Mean winning streak", FormatFloat(stats.AvgMaxWinsStreak)},
-		{"Maximum winning streak", fmt.Sprintf("%d", stats.MaxWinsStreak)},
-		{"Mean losing streak", FormatFloat(stats.AvgMaxLossStreak)},
-		{"Maximum losing streak", fmt.Sprintf("%d", stats.MaxLossStreak)},
-		{"", ""},
+		{"Mean winning streak", FormatFloat(stats.AvgMaxWinsStreak), ""},
+		{"Maximum winning streak", fmt.Sprintf("%d", stats.MaxWinsStreak), ""},
+		{"Mean losing streak", FormatFloat(stats.AvgMaxLossStreak), ""},
+		{"Maximum losing streak", fmt.Sprintf("%d", stats.MaxLossStreak), ""},
+		{"", "", ""},
 		// Bankroll statistics
-		{"Mean peak bankroll per game", FormatCurrency(stats.AvgMaxBankrollReached)},
-		{"Maximum recorded bankroll", FormatCurrency(stats.MaxBankrollReacorded)},
-		{"Profitable games", FormatPercentage(stats.ProfitableBankrollRate)},
-		{"Profitably ended games", FormatPercentage(stats.ProfitableEndGamesRate)},
+		{"Mean peak bankroll per game", FormatCurrency(stats.AvgMaxBankrollReached), FormatInterval(stats.AvgMaxBankrollReachedInterval, FormatCurrency)},
+		{"Maximum recorded bankroll", FormatCurrency(stats.MaxBankrollReacorded), ""},
+		{"Profitable games", FormatPercentage(stats.ProfitableBankrollRate), FormatInterval(stats.ProfitableBankrollRateInterval, FormatPercentage)},
+		{"Profitably ended games", FormatPercentage(stats.ProfitableEndGamesRate), FormatInterval(stats.ProfitableEndGamesRateInterval, FormatPercentage)},
+		// Table maximum statistics
+		{"Games hitting table maximum", FormatPercentage(stats.TableMaximumHitRate), ""},
+		{"Mean table maximum hits per game", FormatFloat(stats.AvgTableMaximumHitsPerGame), ""},
+		{"", "", ""},
+		// Money flow statistics
+		{"Mean wagered per game", FormatCurrency(stats.AvgWageredPerGame), ""},
+		{"Mean paid out per game", FormatCurrency(stats.AvgPaidOutPerGame), ""},
+		{"Mean commission per game", FormatCurrency(stats.AvgCommissionPerGame), ""},
+		{"Mean net result per game", FormatCurrency(stats.AvgNetResultPerGame), FormatInterval(stats.AvgNetResultPerGameInterval, FormatCurrency)},
+		{"Realised house edge", FormatPercentage(stats.RealisedHouseEdge), ""},
+		{"Theoretical house edge", FormatPercentage(stats.TheoreticalHouseEdge), ""},
+		{"Mean side bets wagered per game", FormatCurrency(stats.AvgSideBetsWageredPerGame), ""},
+		{"Side bet win rate", FormatPercentage(stats.SideBetWinRate), ""},
+		{"", "", ""},
+		// Exit reasons statistics
+		{"Busted games", FormatPercentage(stats.BustedRate), ""},
+		{"Games ended at stop-win", FormatPercentage(stats.GoalHitRate), ""},
+		{"Games ended at stop-loss", FormatPercentage(stats.LossLimitRate), ""},
+		{"Games ended at round cap", FormatPercentage(stats.RoundCapRate), ""},
+		{"Games ended by bet over bankroll", FormatPercentage(stats.BetExceedsBankrollRate), ""},
+		{"Games ended at table maximum", FormatPercentage(stats.TableMaximumExitRate), ""},
+		{"Games ended at shoe cap", FormatPercentage(stats.ShoeCapRate), ""},
+		{"Games ended at sit-out cap", FormatPercentage(stats.SitOutCapRate), ""},
 	}
 
 	t := table.New(
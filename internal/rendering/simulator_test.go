@@ -227,6 +227,78 @@ func TestRenderSimulatorStatistics(t *testing.T) {
 	}
 }
 
+func TestRenderSimulatorProgress(t *testing.T) {
+	tests := []struct {
+		name           string
+		progress       *simulator.Progress
+		elapsed        float64
+		wantContains   []string
+		wantNotContain []string
+	}{
+		{
+			name: "in progress",
+			progress: &simulator.Progress{
+				CompletedSimulations: 250,
+				TotalSimulations:     1000,
+				Stats: simulator.MultipleSimulationsStats{
+					TotalSimulations:      250,
+					AvgRoundsPerGame:      123.45,
+					WinRate:               44.5,
+					AvgMaxBankrollReached: 1050.0,
+				},
+			},
+			elapsed: 5.0,
+			wantContains: []string{
+				"Completed: 250 of 1000 games (50 games per second)",
+				"Time left: ~15.00 seconds",
+				"Mean rounds per game: 123.5",
+				"Win rate: 44.50%",
+				"Mean peak bankroll per game: $1050.00",
+			},
+		},
+		{
+			name: "no games completed yet",
+			progress: &simulator.Progress{
+				CompletedSimulations: 0,
+				TotalSimulations:     1000,
+			},
+			elapsed: 0.1,
+			wantContains: []string{
+				"Completed: 0 of 1000 games",
+			},
+			wantNotContain: []string{
+				"Time left",
+				"Win rate",
+			},
+		},
+		{
+			name:     "nil progress",
+			progress: nil,
+			elapsed:  1.0,
+			wantNotContain: []string{
+				"Completed",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := RenderSimulatorProgress(tt.progress, tt.elapsed)
+
+			for _, expected := range tt.wantContains {
+				if !strings.Contains(result, expected) {
+					t.Errorf("RenderSimulatorProgress() result should contain: %s", expected)
+				}
+			}
+			for _, unexpected := range tt.wantNotContain {
+				if strings.Contains(result, unexpected) {
+					t.Errorf("RenderSimulatorProgress() result should not contain: %s", unexpected)
+				}
+			}
+		})
+	}
+}
+
 func TestRenderSimulatorTable(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -247,6 +319,9 @@ func TestRenderSimulatorTable(t *testing.T) {
 				WinRate:                     50.3,
 				GamesWithZeroWins:           1,
 				ZeroWinsRate:                1.0,
+				AvgPushesPerGame:            4.8,
+				MaxPushes:                   9,
+				PushRate:                    9.5,
 				AvgMaxWinsStreak:            3.2,
 				MaxWinsStreak:               4,
 				AvgMaxLossStreak:            2.1,
@@ -257,9 +332,24 @@ func TestRenderSimulatorTable(t *testing.T) {
 				ProfitableBankrollRate:      1.0,
 				GamesWithProfitableEnd:      1,
 				ProfitableEndGamesRate:      1.0,
+				GamesWithGoalHit:            12,
+				GoalHitRate:                 12.0,
+				WinRateInterval:             simulator.ConfidenceInterval{Low: 49.12, High: 51.48},
+				RealisedHouseEdge:           1.37,
+				TheoreticalHouseEdge:        1.06,
 			},
 			wantContains: []string{
 				"Statistics category",
+				"Push rate",
+				"9.50%",
+				"95% confidence interval",
+				"49.12% – 51.48%",
+				"Realised house edge",
+				"1.37%",
+				"Theoretical house edge",
+				"1.06%",
+				"Games ended at stop-win",
+				"12.00%",
 			},
 		},
 		{
@@ -78,6 +78,15 @@ func RenderStatisticsTable(s *statistics.SessionStatistics) string {
 		},
 	}
 
+	// Side bets are shown once the user has placed them
+	if s.SideBets > 0 {
+		rows = append(rows, table.Row{
+			"Your side bet wins",
+			fmt.Sprintf("%d", s.SideBetWins),
+			fmt.Sprintf("%s%%", FormatFloat(s.GetSideBetWinsPercentage())),
+		})
+	}
+
 	t := table.New(
 		table.WithColumns(columns),
 		table.WithRows(rows),
@@ -159,5 +159,13 @@ func TestRenderStatisticsTable(t *testing.T) {
 		if result == noGamesPlayedYet {
 			t.Errorf("RenderStatisticsTable() should not return no games played yet")
 		}
+		if strings.Contains(result, "Your side bet wins") {
+			t.Errorf("RenderStatisticsTable() should not show side bets before they are placed")
+		}
+
+		stats.UpdateSideBetStatistics(true)
+		if result := RenderStatisticsTable(stats); !strings.Contains(result, "Your side bet wins") {
+			t.Errorf("RenderStatisticsTable() should show side bets once they are placed")
+		}
 	})
 }
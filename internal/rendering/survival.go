@@ -0,0 +1,109 @@
+package rendering
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/adequatica/punto-banco-golango/internal/simulator"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Rows of the table of the survival curve
+const numberOfSurvivalRows = 10
+
+var survivalStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("2")) // Green
+
+// Ruined games, median rounds to ruin and chance to double the bankroll
+func RenderSurvivalSummary(stats *simulator.MultipleSimulationsStats) string {
+	survival := stats.Survival
+	ruinedRate := float64(survival.GamesRuined) / float64(stats.TotalSimulations) * 100
+
+	s := fmt.Sprintf("Ruined games: %d of %d (%s)\n", survival.GamesRuined, stats.TotalSimulations, FormatPercentage(ruinedRate))
+	if survival.MedianRoundsToRuin > 0 {
+		s += fmt.Sprintf("Median rounds to ruin: %d\n", survival.MedianRoundsToRuin)
+	} else {
+		s += "Median rounds to ruin: not reached, more than half of games are not ruined\n"
+	}
+	s += fmt.Sprintf("Doubled the bankroll before going broke: %s (95%% confidence interval %s)\n",
+		FormatPercentage(survival.DoublingRate), FormatInterval(survival.DoublingRateInterval, FormatPercentage))
+
+	return s
+}
+
+// Kaplan–Meier curve of the share of games alive by round
+func RenderSurvivalCurve(survival simulator.SurvivalAnalysis) string {
+	if len(survival.Curve) == 0 {
+		return ""
+	}
+
+	rounds := make([]int, len(survival.Curve))
+	alive := make([]float64, len(survival.Curve))
+	for i, point := range survival.Curve {
+		rounds[i] = point.Round
+		alive[i] = point.Survival
+	}
+
+	c := newChart(rounds, FormatPercentage, alive, []float64{0, 100})
+	c.line(alive, "•", survivalStyle)
+
+	return "Games alive by round\n" + c.render()
+}
+
+// Survival at rounds spread evenly over the curve
+func RenderSurvivalTable(survival simulator.SurvivalAnalysis) string {
+	if len(survival.Curve) == 0 {
+		return ""
+	}
+
+	columns := []table.Column{
+		{Title: "Round", Width: 10},
+		{Title: "Games alive", Width: 12},
+		{Title: "Games at risk", Width: 14},
+	}
+
+	step := max(1, (len(survival.Curve)-1)/(numberOfSurvivalRows-1))
+	var rows []table.Row
+	for i := 0; i < len(survival.Curve); i += step {
+		// The last row is the end of the longest game
+		if len(survival.Curve)-1-i < step {
+			i = len(survival.Curve) - 1
+		}
+		point := survival.Curve[i]
+		rows = append(rows, table.Row{fmt.Sprintf("%d", point.Round), FormatPercentage(point.Survival), fmt.Sprintf("%d", point.AtRisk)})
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithRows(rows),
+		table.WithFocused(false),
+		table.WithHeight(len(rows)+1),
+	)
+
+	styles := table.DefaultStyles()
+	styles.Header = styles.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		Bold(true)
+	styles.Selected = styles.Selected.
+		// Reset default selected cell styles
+		UnsetForeground().
+		Bold(false)
+	t.SetStyles(styles)
+
+	return noBorderStyle.Render(t.View())
+}
+
+// Summary, curve and table of the risk of ruin
+func RenderSurvivalAnalysis(stats *simulator.MultipleSimulationsStats) string {
+	if stats == nil || stats.TotalSimulations == 0 {
+		return noSimulationsYet
+	}
+
+	var s strings.Builder
+	s.WriteString(RenderSurvivalSummary(stats) + "\n")
+	s.WriteString(RenderSurvivalCurve(stats.Survival) + "\n")
+	s.WriteString(RenderSurvivalTable(stats.Survival) + "\n")
+
+	return s.String()
+}
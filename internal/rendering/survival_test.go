@@ -0,0 +1,72 @@
+package rendering
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/adequatica/punto-banco-golango/internal/simulator"
+)
+
+func testSurvivalStats() *simulator.MultipleSimulationsStats {
+	return &simulator.MultipleSimulationsStats{
+		TotalSimulations: 200,
+		Survival: simulator.SurvivalAnalysis{
+			Curve: []simulator.SurvivalPoint{
+				{Round: 0, Survival: 100, AtRisk: 200},
+				{Round: 50, Survival: 75, AtRisk: 150},
+				{Round: 100, Survival: 40, AtRisk: 80},
+				{Round: 150, Survival: 40, AtRisk: 10},
+			},
+			MedianRoundsToRuin:   87,
+			GamesRuined:          120,
+			GamesDoubled:         30,
+			DoublingRate:         15,
+			DoublingRateInterval: simulator.ConfidenceInterval{Low: 10.73, High: 20.58},
+		},
+	}
+}
+
+func TestRenderSurvivalSummary(t *testing.T) {
+	got := RenderSurvivalSummary(testSurvivalStats())
+	for _, want := range []string{"Ruined games: 120 of 200 (60.00%)", "Median rounds to ruin: 87", "15.00%", "10.73% – 20.58%"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("RenderSurvivalSummary() should contain %q, got %q", want, got)
+		}
+	}
+
+	stats := testSurvivalStats()
+	stats.Survival.MedianRoundsToRuin = 0
+	if got := RenderSurvivalSummary(stats); !strings.Contains(got, "not reached") {
+		t.Errorf("RenderSurvivalSummary() should tell when the median is not reached, got %q", got)
+	}
+}
+
+func TestRenderSurvivalAnalysis(t *testing.T) {
+	if got := RenderSurvivalAnalysis(nil); got != noSimulationsYet {
+		t.Errorf("RenderSurvivalAnalysis() = %q should be %q", got, noSimulationsYet)
+	}
+
+	got := RenderSurvivalAnalysis(testSurvivalStats())
+	for _, want := range []string{"Games alive by round", "100.00%", "0.00%", "round 150", "Games at risk", "75.00%", "150"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("RenderSurvivalAnalysis() should contain %q", want)
+		}
+	}
+}
+
+func TestRenderSurvivalTable(t *testing.T) {
+	curve := make([]simulator.SurvivalPoint, 100)
+	for i := range curve {
+		curve[i] = simulator.SurvivalPoint{Round: i * 3, Survival: 100 - float64(i), AtRisk: 100 - i}
+	}
+
+	got := RenderSurvivalTable(simulator.SurvivalAnalysis{Curve: curve})
+	lines := strings.Split(strings.TrimSpace(got), "\n")
+	// Header, its border and the rows
+	if rows := len(lines) - 2; rows > numberOfSurvivalRows+1 {
+		t.Errorf("table should have about %d rows, got %d: %q", numberOfSurvivalRows, rows, got)
+	}
+	if !strings.Contains(lines[len(lines)-1], "297") {
+		t.Errorf("last row should be the end of the longest game, got %q", lines[len(lines)-1])
+	}
+}
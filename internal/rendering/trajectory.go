@@ -0,0 +1,95 @@
+package rendering
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/adequatica/punto-banco-golango/internal/simulator"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	bandStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("8")) // Gray
+	meanStyle     = lipgloss.NewStyle().Bold(true)
+	bankrollStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("3")) // Yellow for the starting bankroll
+	sampleStyles  = []lipgloss.Style{
+		lipgloss.NewStyle().Foreground(lipgloss.Color("4")), // Blue
+		lipgloss.NewStyle().Foreground(lipgloss.Color("2")), // Green
+		lipgloss.NewStyle().Foreground(lipgloss.Color("5")), // Magenta
+		lipgloss.NewStyle().Foreground(lipgloss.Color("6")), // Cyan
+		lipgloss.NewStyle().Foreground(lipgloss.Color("1")), // Red
+	}
+)
+
+func trajectoryRounds(trajectories simulator.BankrollTrajectories) []int {
+	rounds := make([]int, len(trajectories.Points))
+	for i, point := range trajectories.Points {
+		rounds[i] = point.Round
+	}
+	return rounds
+}
+
+// Bankrolls of the sample games by round, each game in its own colour
+func RenderSampleTrajectories(trajectories simulator.BankrollTrajectories) string {
+	if len(trajectories.Points) == 0 || len(trajectories.SampleGames) == 0 {
+		return ""
+	}
+
+	// Every game starts with the same bankroll
+	bankroll := trajectories.Points[0].Mean
+	values := append([][]float64{{bankroll}}, trajectories.SampleGames...)
+	c := newChart(trajectoryRounds(trajectories), FormatCurrency, values...)
+	c.level(bankroll, bankrollStyle)
+	var legend []string
+	for i, game := range trajectories.SampleGames {
+		style := sampleStyles[i%len(sampleStyles)]
+		c.line(game, "•", style)
+		legend = append(legend, style.Render("•")+fmt.Sprintf(" Game %d", i+1))
+	}
+	legend = append(legend, bankrollStyle.Render("┄")+" Starting bankroll")
+
+	return fmt.Sprintf("Bankroll of the first %d games\n", len(trajectories.SampleGames)) + c.render() + strings.Join(legend, "  ") + "\n"
+}
+
+// Mean bankroll of all games by round over the bands of the 5th–95th and 25th–75th percentiles
+func RenderTrajectoryBands(trajectories simulator.BankrollTrajectories) string {
+	if len(trajectories.Points) == 0 {
+		return ""
+	}
+
+	bankroll := trajectories.Points[0].Mean
+
+	var mean, p5, p25, p75, p95 []float64
+	for _, point := range trajectories.Points {
+		mean = append(mean, point.Mean)
+		p5 = append(p5, point.P5)
+		p25 = append(p25, point.P25)
+		p75 = append(p75, point.P75)
+		p95 = append(p95, point.P95)
+	}
+
+	c := newChart(trajectoryRounds(trajectories), FormatCurrency, mean, p5, p95, []float64{bankroll})
+	c.band(p5, p95, "░", bandStyle)
+	c.band(p25, p75, "▒", bandStyle)
+	c.level(bankroll, bankrollStyle)
+	c.line(mean, "•", meanStyle)
+
+	legend := []string{
+		meanStyle.Render("•") + " Mean",
+		bandStyle.Render("▒") + " 25th–75th percentile",
+		bandStyle.Render("░") + " 5th–95th percentile",
+		bankrollStyle.Render("┄") + " Starting bankroll",
+	}
+
+	return "Bankroll of all games by round\n" + c.render() + strings.Join(legend, "  ") + "\n"
+}
+
+// Charts of the sample games and the bands of all games
+func RenderBankrollTrajectories(stats *simulator.MultipleSimulationsStats) string {
+	if stats == nil || stats.TotalSimulations == 0 {
+		return noSimulationsYet
+	}
+
+	trajectories := stats.BankrollTrajectories
+	return RenderSampleTrajectories(trajectories) + "\n" + RenderTrajectoryBands(trajectories)
+}
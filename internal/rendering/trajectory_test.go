@@ -0,0 +1,76 @@
+package rendering
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/adequatica/punto-banco-golango/internal/simulator"
+)
+
+func testTrajectories() simulator.BankrollTrajectories {
+	return simulator.BankrollTrajectories{
+		Points: []simulator.TrajectoryPoint{
+			{Round: 0, Mean: 1000, P5: 1000, P25: 1000, Median: 1000, P75: 1000, P95: 1000},
+			{Round: 10, Mean: 950, P5: 700, P25: 900, Median: 960, P75: 1010, P95: 1200},
+			{Round: 20, Mean: 900, P5: 400, P25: 800, Median: 920, P75: 1020, P95: 1400},
+		},
+		SampleGames: [][]float64{{1000, 1100, 1400}, {1000, 800, 400}},
+	}
+}
+
+func TestRenderSampleTrajectories(t *testing.T) {
+	got := RenderSampleTrajectories(testTrajectories())
+	lines := strings.Split(strings.TrimRight(got, "\n"), "\n")
+
+	// Title, rows of the chart, the axis with the rounds and the legend
+	if len(lines) != chartHeight+4 {
+		t.Fatalf("chart should have %d lines, got %d: %q", chartHeight+4, len(lines), got)
+	}
+	for _, want := range []string{"Bankroll of the first 2 games", "$1400.00", "$400.00", "round 20", "Game 1", "Game 2", "Starting bankroll"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("RenderSampleTrajectories() should contain %q", want)
+		}
+	}
+	// The first game ends at the top, the second one at the bottom
+	if !strings.HasSuffix(lines[1], "•") || !strings.HasSuffix(lines[chartHeight], "•") {
+		t.Errorf("games should end at the maximum and the minimum of the chart: %q", got)
+	}
+
+	if got := RenderSampleTrajectories(simulator.BankrollTrajectories{}); got != "" {
+		t.Errorf("RenderSampleTrajectories() of no games = %q should be empty", got)
+	}
+}
+
+func TestRenderTrajectoryBands(t *testing.T) {
+	got := RenderTrajectoryBands(testTrajectories())
+
+	for _, want := range []string{"Bankroll of all games by round", "Mean", "5th–95th percentile", "25th–75th percentile", "░", "▒", "┄"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("RenderTrajectoryBands() should contain %q", want)
+		}
+	}
+}
+
+func TestRenderBankrollTrajectories(t *testing.T) {
+	if got := RenderBankrollTrajectories(nil); got != noSimulationsYet {
+		t.Errorf("RenderBankrollTrajectories() = %q should be %q", got, noSimulationsYet)
+	}
+
+	stats := &simulator.MultipleSimulationsStats{TotalSimulations: 2, BankrollTrajectories: testTrajectories()}
+	got := RenderBankrollTrajectories(stats)
+	if !strings.Contains(got, "Bankroll of the first 2 games") || !strings.Contains(got, "Bankroll of all games by round") {
+		t.Errorf("RenderBankrollTrajectories() should have both charts, got %q", got)
+	}
+}
+
+func TestChart_SinglePoint(t *testing.T) {
+	trajectories := simulator.BankrollTrajectories{
+		Points:      []simulator.TrajectoryPoint{{Round: 0, Mean: 1000, P5: 1000, P25: 1000, Median: 1000, P75: 1000, P95: 1000}},
+		SampleGames: [][]float64{{1000}},
+	}
+
+	got := RenderSampleTrajectories(trajectories)
+	if !strings.Contains(got, "round 0") {
+		t.Errorf("chart of a single point should be rendered, got %q", got)
+	}
+}
@@ -0,0 +1,211 @@
+package rules
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+type BurnPolicy string
+
+const (
+	// The first card is turned over, and as many cards as its value are burned (10 for 10 and face cards)
+	BurnByFirstCard BurnPolicy = "first-card"
+	// Cards are dealt right after the cut
+	BurnNone BurnPolicy = "none"
+)
+
+const (
+	DefaultNumberOfDecks = 6
+	// The cut card is placed in front of the 14th card from the end of the shoe
+	DefaultCutCardPosition = 14
+	// Winning bets on Banco pay 19 to 20
+	DefaultBancoCommission = 0.05
+	// Standard payout for tie bet is 8-to-1
+	DefaultTiePayout = 8.0
+	// The minimum bet for baccarat in Las Vegas
+	DefaultMinimumBet = 10.0
+	// It takes seven shuffles to randomize a deck of cards
+	DefaultNumberOfShuffles = 7
+)
+
+// Rules of the table passed explicitly into the game and the simulator,
+// so that several rule sets can be played side by side
+type TableRules struct {
+	NumberOfDecks int `json:"numberOfDecks"`
+	// Number of cards behind the cut card
+	CutCardPosition int `json:"cutCardPosition"`
+	// Share of the shoe dealt before the cut card comes out (e.g. 0.75 for 75%).
+	// When it is set, it takes precedence over CutCardPosition.
+	Penetration     float64 `json:"penetration"`
+	BancoCommission float64 `json:"bancoCommission"`
+	TiePayout       float64 `json:"tiePayout"`
+	MinimumBet      float64 `json:"minimumBet"`
+	// Zero means no table maximum
+	MaximumBet float64    `json:"maximumBet"`
+	BurnPolicy BurnPolicy `json:"burnPolicy"`
+	// Number of times the shoe is shuffled before the cut
+	NumberOfShuffles int `json:"numberOfShuffles"`
+}
+
+func DefaultTableRules() TableRules {
+	return TableRules{
+		NumberOfDecks:    DefaultNumberOfDecks,
+		CutCardPosition:  DefaultCutCardPosition,
+		Penetration:      0.0,
+		BancoCommission:  DefaultBancoCommission,
+		TiePayout:        DefaultTiePayout,
+		MinimumBet:       DefaultMinimumBet,
+		MaximumBet:       0.0,
+		BurnPolicy:       BurnByFirstCard,
+		NumberOfShuffles: DefaultNumberOfShuffles,
+	}
+}
+
+func (r TableRules) Validate() error {
+	if r.NumberOfDecks < 1 || r.NumberOfDecks > 8 {
+		return fmt.Errorf("number of decks should be between 1 and 8, got %d", r.NumberOfDecks)
+	}
+	if r.CutCardPosition < 0 {
+		return fmt.Errorf("cut card position should not be negative, got %d", r.CutCardPosition)
+	}
+	if r.Penetration < 0 || r.Penetration >= 1 {
+		return fmt.Errorf("penetration should be between 0 and 1, got %.2f", r.Penetration)
+	}
+	if r.BancoCommission < 0 || r.BancoCommission >= 1 {
+		return fmt.Errorf("Banco commission should be between 0 and 1, got %.2f", r.BancoCommission)
+	}
+	if r.TiePayout <= 0 {
+		return fmt.Errorf("tie payout should be positive, got %.2f", r.TiePayout)
+	}
+	if r.MinimumBet <= 0 {
+		return fmt.Errorf("minimum bet should be positive, got %.2f", r.MinimumBet)
+	}
+	if r.MaximumBet != 0 && r.MaximumBet < r.MinimumBet {
+		return fmt.Errorf("maximum bet of %.2f should not be less than minimum bet of %.2f", r.MaximumBet, r.MinimumBet)
+	}
+	if r.BurnPolicy != BurnByFirstCard && r.BurnPolicy != BurnNone {
+		return fmt.Errorf("unknown burn policy: %s", r.BurnPolicy)
+	}
+	if r.NumberOfShuffles < 1 {
+		return fmt.Errorf("number of shuffles should be positive, got %d", r.NumberOfShuffles)
+	}
+
+	return nil
+}
+
+// Load table rules from a JSON file, or a TOML file by the .toml extension.
+// The rules missing in the file keep their default values.
+func LoadTableRules(path string) (TableRules, error) {
+	tableRules := DefaultTableRules()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return tableRules, fmt.Errorf("Failed to open table rules file: %w", err)
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		content, err = tomlToJSON(content)
+		if err != nil {
+			return tableRules, fmt.Errorf("Failed to parse table rules file: %w", err)
+		}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&tableRules); err != nil {
+		return tableRules, fmt.Errorf("Failed to parse table rules file: %w", err)
+	}
+
+	if err := tableRules.Validate(); err != nil {
+		return tableRules, fmt.Errorf("Invalid table rules: %w", err)
+	}
+
+	return tableRules, nil
+}
+
+// Table rules are flat, so only the key/value pairs of TOML are supported: strings, numbers and booleans.
+// They are converted to JSON to be decoded the same way as the JSON file.
+func tomlToJSON(content []byte) ([]byte, error) {
+	values := make(map[string]any)
+
+	for i, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(stripTOMLComment(line))
+		if line == "" {
+			continue
+		}
+
+		key, rawValue, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value, got %q", i+1, line)
+		}
+		key = strings.TrimSpace(key)
+		if unquoted, err := strconv.Unquote(key); err == nil {
+			key = unquoted
+		}
+		if key == "" {
+			return nil, fmt.Errorf("line %d: empty key", i+1)
+		}
+		if _, ok := values[key]; ok {
+			return nil, fmt.Errorf("line %d: duplicate key %s", i+1, key)
+		}
+
+		value, err := parseTOMLValue(strings.TrimSpace(rawValue))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		values[key] = value
+	}
+
+	return json.Marshal(values)
+}
+
+// The comment starts at the first # outside of a string
+func stripTOMLComment(line string) string {
+	inString := false
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			if inString {
+				i++
+			}
+		case '"':
+			inString = !inString
+		case '#':
+			if !inString {
+				return line[:i]
+			}
+		}
+	}
+	return line
+}
+
+func parseTOMLValue(value string) (any, error) {
+	switch {
+	case value == "":
+		return nil, fmt.Errorf("missing value")
+	case value == "true" || value == "false":
+		return value == "true", nil
+	case strings.HasPrefix(value, "\""):
+		return strconv.Unquote(value)
+	case strings.HasPrefix(value, "'"):
+		if len(value) < 2 || !strings.HasSuffix(value, "'") {
+			return nil, fmt.Errorf("unterminated string %s", value)
+		}
+		return value[1 : len(value)-1], nil
+	}
+
+	number := strings.ReplaceAll(value, "_", "")
+	if integer, err := strconv.ParseInt(number, 10, 64); err == nil {
+		return integer, nil
+	}
+	if float, err := strconv.ParseFloat(number, 64); err == nil {
+		return float, nil
+	}
+
+	return nil, fmt.Errorf("unsupported value %s", value)
+}
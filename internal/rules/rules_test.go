@@ -0,0 +1,243 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDefaultTableRules(t *testing.T) {
+	tableRules := DefaultTableRules()
+
+	if err := tableRules.Validate(); err != nil {
+		t.Errorf("default table rules should be valid: %v", err)
+	}
+
+	if tableRules.NumberOfDecks != 6 {
+		t.Errorf("NumberOfDecks = %d should be 6", tableRules.NumberOfDecks)
+	}
+	if tableRules.MinimumBet != 10.0 {
+		t.Errorf("MinimumBet = %.2f should be 10.00", tableRules.MinimumBet)
+	}
+	if tableRules.BurnPolicy != BurnByFirstCard {
+		t.Errorf("BurnPolicy = %s should be %s", tableRules.BurnPolicy, BurnByFirstCard)
+	}
+}
+
+func TestTableRulesValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(r *TableRules)
+		wantErr bool
+	}{
+		{
+			name:    "default rules",
+			modify:  func(r *TableRules) {},
+			wantErr: false,
+		},
+		{
+			name:    "eight decks with penetration",
+			modify:  func(r *TableRules) { r.NumberOfDecks = 8; r.Penetration = 0.8 },
+			wantErr: false,
+		},
+		{
+			name:    "no decks",
+			modify:  func(r *TableRules) { r.NumberOfDecks = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "too many decks",
+			modify:  func(r *TableRules) { r.NumberOfDecks = 9 },
+			wantErr: true,
+		},
+		{
+			name:    "negative cut card position",
+			modify:  func(r *TableRules) { r.CutCardPosition = -1 },
+			wantErr: true,
+		},
+		{
+			name:    "full penetration",
+			modify:  func(r *TableRules) { r.Penetration = 1 },
+			wantErr: true,
+		},
+		{
+			name:    "commission-free Banco",
+			modify:  func(r *TableRules) { r.BancoCommission = 0 },
+			wantErr: false,
+		},
+		{
+			name:    "negative commission",
+			modify:  func(r *TableRules) { r.BancoCommission = -0.05 },
+			wantErr: true,
+		},
+		{
+			name:    "tie payout of 9-to-1",
+			modify:  func(r *TableRules) { r.TiePayout = 9 },
+			wantErr: false,
+		},
+		{
+			name:    "zero tie payout",
+			modify:  func(r *TableRules) { r.TiePayout = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "zero minimum bet",
+			modify:  func(r *TableRules) { r.MinimumBet = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "maximum bet above minimum",
+			modify:  func(r *TableRules) { r.MaximumBet = 5000 },
+			wantErr: false,
+		},
+		{
+			name:    "maximum bet below minimum",
+			modify:  func(r *TableRules) { r.MaximumBet = 5 },
+			wantErr: true,
+		},
+		{
+			name:    "no burning",
+			modify:  func(r *TableRules) { r.BurnPolicy = BurnNone },
+			wantErr: false,
+		},
+		{
+			name:    "unknown burn policy",
+			modify:  func(r *TableRules) { r.BurnPolicy = "all" },
+			wantErr: true,
+		},
+		{
+			name:    "single shuffle",
+			modify:  func(r *TableRules) { r.NumberOfShuffles = 1 },
+			wantErr: false,
+		},
+		{
+			name:    "no shuffles",
+			modify:  func(r *TableRules) { r.NumberOfShuffles = 0 },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tableRules := DefaultTableRules()
+			tt.modify(&tableRules)
+
+			err := tableRules.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadTableRules(t *testing.T) {
+	writeFile := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "rules.json")
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write rules file: %v", err)
+		}
+		return path
+	}
+
+	t.Run("partial rules keep defaults", func(t *testing.T) {
+		path := writeFile(t, `{"numberOfDecks": 8, "tiePayout": 9, "maximumBet": 5000}`)
+
+		got, err := LoadTableRules(path)
+		if err != nil {
+			t.Fatalf("should not have error loading rules: %v", err)
+		}
+
+		want := DefaultTableRules()
+		want.NumberOfDecks = 8
+		want.TiePayout = 9
+		want.MaximumBet = 5000
+
+		if got != want {
+			t.Errorf("LoadTableRules() = %+v should be %+v", got, want)
+		}
+	})
+
+	t.Run("invalid rules", func(t *testing.T) {
+		path := writeFile(t, `{"numberOfDecks": 0}`)
+
+		_, err := LoadTableRules(path)
+		if err == nil || !strings.Contains(err.Error(), "Invalid table rules") {
+			t.Errorf("LoadTableRules() should return validation error, got %v", err)
+		}
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		path := writeFile(t, `{"decks": 8}`)
+
+		_, err := LoadTableRules(path)
+		if err == nil {
+			t.Errorf("LoadTableRules() should return error for unknown field")
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := LoadTableRules(filepath.Join(t.TempDir(), "missing.json"))
+		if err == nil {
+			t.Errorf("LoadTableRules() should return error for missing file")
+		}
+	})
+}
+
+func TestLoadTableRules_TOML(t *testing.T) {
+	writeFile := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "rules.toml")
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write rules file: %v", err)
+		}
+		return path
+	}
+
+	t.Run("partial rules keep defaults", func(t *testing.T) {
+		path := writeFile(t, `# Macau table
+numberOfDecks = 8
+tiePayout = 9.0
+maximumBet = 5_000 # no more
+burnPolicy = "none"
+numberOfShuffles = 3
+`)
+
+		got, err := LoadTableRules(path)
+		if err != nil {
+			t.Fatalf("should not have error loading rules: %v", err)
+		}
+
+		want := DefaultTableRules()
+		want.NumberOfDecks = 8
+		want.TiePayout = 9
+		want.MaximumBet = 5000
+		want.BurnPolicy = BurnNone
+		want.NumberOfShuffles = 3
+
+		if got != want {
+			t.Errorf("LoadTableRules() = %+v should be %+v", got, want)
+		}
+	})
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"invalid rules", "numberOfDecks = 0", "Invalid table rules"},
+		{"unknown field", "decks = 8", "Failed to parse table rules file"},
+		{"fractional number of decks", "numberOfDecks = 6.5", "Failed to parse table rules file"},
+		{"missing value", "numberOfDecks =", "Failed to parse table rules file"},
+		{"duplicate key", "numberOfDecks = 6\nnumberOfDecks = 8", "Failed to parse table rules file"},
+		{"table", "[rules]\nnumberOfDecks = 8", "Failed to parse table rules file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadTableRules(writeFile(t, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadTableRules() error = %v should contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
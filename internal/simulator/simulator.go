@@ -1,180 +1,194 @@
 package simulator
 
 import (
+	"context"
 	"fmt"
-	"math/rand"
+	"sync"
 
 	"github.com/adequatica/punto-banco-golango/internal/deck"
 	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
 )
 
-var (
-	Bankroll   = 1000.0
-	MinimumBet = 10.0
+var MaxIntValue = int(^uint(0) >> 1)
 
-	MaxIntValue         = int(^uint(0) >> 1)
-	ProfitableThreshold = Bankroll * 1.01 // 101% of default bankroll
-)
-
-type StrategyType string
-
-const (
-	// Flat betting strategies
-	BetOnPunto      StrategyType = "Bet on Punto (player)"
-	BetOnBanco      StrategyType = "Bet on Banco (banker)"
-	BetOnEgalite    StrategyType = "Bet on Égalité (tie)"
-	BetOnLastHand   StrategyType = "Bet on Last Hand"
-	BetOnLastHandPB StrategyType = "Bet on Last Hand PB"
-	BetOnRandom     StrategyType = "Bet on Random PB"
-	// Progressive betting strategies
-	MartingaleOnPunto     StrategyType = "Martingale on Punto"
-	MartingaleOnBanco     StrategyType = "Martingale on Banco"
-	ParoliOnPunto         StrategyType = "Paroli on Punto"
-	ParoliOnBanco         StrategyType = "Paroli on Banco"
-	FibonacciOnPunto      StrategyType = "Fibonacci on Punto"
-	FibonacciOnBanco      StrategyType = "Fibonacci on Banco"
-	DAlembertOnPunto      StrategyType = "D'Alembert on Punto"
-	DAlembertOnBanco      StrategyType = "D'Alembert on Banco"
-	OneThreeTwoSixOnPunto StrategyType = "1-3-2-6 on Punto"
-	OneThreeTwoSixOnBanco StrategyType = "1-3-2-6 on Banco"
-)
-
-func GetStrategyOptions() []string {
-	return []string{
-		// Flat betting strategies
-		string(BetOnPunto),
-		string(BetOnBanco),
-		string(BetOnEgalite),
-		string(BetOnLastHand),
-		string(BetOnLastHandPB),
-		string(BetOnRandom),
-		// Progressive betting strategies
-		string(MartingaleOnPunto),
-		string(MartingaleOnBanco),
-		string(ParoliOnPunto),
-		string(ParoliOnBanco),
-		string(FibonacciOnPunto),
-		string(FibonacciOnBanco),
-		string(DAlembertOnPunto),
-		string(DAlembertOnBanco),
-		string(OneThreeTwoSixOnPunto),
-		string(OneThreeTwoSixOnBanco),
+// The same shuffler replays the same shoes, so a game can be reproduced from its seed
+func RunSimulator(strategy Strategy, settings Settings, dataCollector *DataCollector, shuffler deck.Shuffler) *SimulatorState {
+	observer, isObserver := strategy.(CardObserver)
+	if isObserver && settings.MaxShoes == 0 {
+		settings.MaxShoes = DefaultCountingMaxShoes
 	}
-}
 
-func GetRandomBetType() puntobanco.BetType {
-	if rand.Intn(2) == 0 {
-		return puntobanco.PuntoPlayer
-	} else {
-		return puntobanco.BancoBanker
+	state := NewSimulatorState(settings)
+	shoe := deck.MakeNewShoe(settings.Rules, shuffler)
+	state.ShoesPlayed = 1
+	strategy.Reset()
+	if isObserver {
+		observer.OnNewShoe(len(shoe.Cards))
 	}
-}
+	// The shoe is changed before the coup, the coup dealt from it is marked for the collected data
+	isNewShoe := false
 
-func GetOnlyPuntoBanco(lastWinningHand puntobanco.BetType) puntobanco.BetType {
-	if lastWinningHand == puntobanco.EgaliteTie {
-		// Bet on Banco to maximize chance of winning
-		return puntobanco.BancoBanker
-	} else {
-		return lastWinningHand
-	}
-}
+	// Deal a coup from the shoe, the result and the cards are seen by the strategy whether it bets or not
+	dealCoup := func() (puntobanco.GameResultState, error) {
+		gameResult, err := puntobanco.PlayPuntoBanco(shoe, settings.Rules, shuffler)
+		if err != nil {
+			return gameResult, err
+		}
+		gameResult.IsNewShoe = isNewShoe
+		isNewShoe = false
 
-func MakeStrategy(strategy StrategyType, state *SimulatorState) (puntobanco.BetType, float64) {
-	switch strategy {
-	case BetOnPunto:
-		return puntobanco.PuntoPlayer, MinimumBet
-	case BetOnBanco:
-		return puntobanco.BancoBanker, MinimumBet
-	case BetOnEgalite:
-		return puntobanco.EgaliteTie, MinimumBet
-	case BetOnLastHand:
-		return state.LastWinningHand, MinimumBet
-	case BetOnLastHandPB:
-		return GetOnlyPuntoBanco(state.LastWinningHand), MinimumBet
-	case BetOnRandom:
-		return GetRandomBetType(), MinimumBet
-
-	case MartingaleOnPunto:
-		return puntobanco.PuntoPlayer, state.BetAmount
-	case MartingaleOnBanco:
-		return puntobanco.BancoBanker, state.BetAmount
-
-	case ParoliOnPunto:
-		return puntobanco.PuntoPlayer, state.BetAmount
-	case ParoliOnBanco:
-		return puntobanco.BancoBanker, state.BetAmount
-
-	case FibonacciOnPunto:
-		return puntobanco.PuntoPlayer, state.BetAmount
-	case FibonacciOnBanco:
-		return puntobanco.BancoBanker, state.BetAmount
-
-	case DAlembertOnPunto:
-		return puntobanco.PuntoPlayer, state.BetAmount
-	case DAlembertOnBanco:
-		return puntobanco.BancoBanker, state.BetAmount
-
-	case OneThreeTwoSixOnPunto:
-		return puntobanco.PuntoPlayer, state.BetAmount
-	case OneThreeTwoSixOnBanco:
-		return puntobanco.BancoBanker, state.BetAmount
-
-	default:
-		return puntobanco.PuntoPlayer, MinimumBet
+		// Update shoe for next game
+		shoe = gameResult.RemainingShoe
+
+		// Track the last winning hand and the results history
+		if gameResult.Result != nil {
+			state.RecordResult(*gameResult.Result)
+		}
+		if isObserver {
+			observer.OnCardsDealt(gameResult.DealtCards())
+		}
+
+		return gameResult, nil
 	}
-}
 
-func RunSimulator(strategy StrategyType, dataCollector *DataCollector) *SimulatorState {
-	state := NewSimulatorState()
-	shoe := deck.MakeNewShoe()
+	// A capped progression hits the table maximum once until its bet is back within the maximum
+	isOverTableMaximum := false
 
 	// Initialize new game in data collection is enabled
 	if dataCollector != nil {
-		dataCollector.StartNewGame(shoe)
+		dataCollector.StartNewGame()
 	}
 
-	// Run simulation until player cannot bet anymore
-	for state.CanPlaceBet() {
-		betType, betAmount := MakeStrategy(strategy, state)
+	// Run simulation until player cannot bet anymore or walks away
+	for {
+		if reason, ok := state.SessionExitReason(); ok {
+			state.ExitReason = reason
+			break
+		}
+
+		// Counting strategies start a new count before they bet on the first coup of a new shoe
+		if shoe.NeedsChange() {
+			if settings.MaxShoes > 0 && state.ShoesPlayed >= settings.MaxShoes {
+				state.ExitReason = ExitShoeCap
+				break
+			}
+			if settings.SitOutMaxShoes > 0 && state.ShoesSatOut() >= settings.SitOutMaxShoes {
+				state.ExitReason = ExitSitOutCap
+				break
+			}
+			shoe = deck.MakeNewShoe(settings.Rules, shuffler)
+			state.ShoesPlayed++
+			isNewShoe = true
+			if isObserver {
+				observer.OnNewShoe(len(shoe.Cards))
+			}
+		}
+
+		// A gambler below the minimum bet cannot play anymore, even with a strategy sitting out the coups
+		if state.CurrentBankroll < settings.Rules.MinimumBet {
+			state.ExitReason = ExitBusted
+			state.RoundsToBust = state.RoundsPlayed
+			break
+		}
+
+		betType, betAmount := strategy.NextBet(state.History())
+		// The coup is dealt without a bet, it is observed but not played
+		if betAmount == 0 {
+			state.BettingOn = ""
+			state.BetAmount = 0
+
+			gameResult, err := dealCoup()
+			if err != nil {
+				state.DealError = err
+				break
+			}
+			if dataCollector != nil {
+				dataCollector.CollectHandData(state, &gameResult)
+			}
+
+			state.ProcessNoBet()
+			strategy.OnResult(BetResult{Outcome: OutcomeNoBet})
+			state.RoundsObserved++
+			continue
+		}
+		if settings.IsOverTableMaximum(betAmount) {
+			if !isOverTableMaximum {
+				state.TableMaximumHits++
+			}
+			// Only a capped progression stays over the maximum, a reset one starts over
+			isOverTableMaximum = settings.TableMaximumPolicy == TableMaximumCap
+			if settings.TableMaximumPolicy == TableMaximumStop {
+				state.ExitReason = ExitTableMaximum
+				break
+			}
+			if settings.TableMaximumPolicy == TableMaximumReset {
+				strategy.Reset()
+				betType, betAmount = strategy.NextBet(state.History())
+			}
+			// Cap the bet, the base bet of a strategy can be over the table maximum as well
+			if settings.IsOverTableMaximum(betAmount) {
+				betAmount = settings.Rules.MaximumBet
+			}
+		} else {
+			isOverTableMaximum = false
+		}
 		state.BettingOn = betType
 		state.BetAmount = betAmount
+		if lineStrategy, ok := strategy.(LineStrategy); ok {
+			state.LineLength = lineStrategy.LineLength()
+		}
 
+		if !state.CanPlaceBet() {
+			state.ExitReason = state.BetExitReason()
+			state.RoundsToBust = state.RoundsPlayed
+			break
+		}
 		state.PlaceBet()
-
-		// Track shoe length before playing (for data collection)
-		previousShoeLength := len(shoe)
+		state.LastBetShoe = state.ShoesPlayed
+		if sideBettor, ok := strategy.(SideBettor); ok {
+			state.PlaceSideBets(sideBettor.NextSideBets(state.History()))
+		}
 
 		// Play the game
-		gameResult, err := puntobanco.PlayPuntoBanco(shoe)
+		gameResult, err := dealCoup()
 		if err != nil {
-			fmt.Printf("Error playing game: %v\n", err)
+			state.DealError = err
 			break
 		}
 
 		// Collect hand data if data collection is enabled
 		if dataCollector != nil {
-			dataCollector.CollectHandData(state, &gameResult, previousShoeLength)
+			dataCollector.CollectHandData(state, &gameResult)
 		}
 
-		// Update shoe for next game
-		shoe = gameResult.RemainingShoe
-
-		// Track the last winning hand
+		outcome := OutcomeLoss
 		if gameResult.Result != nil {
-			state.LastWinningHand = *gameResult.Result
+			outcome = DetermineOutcome(state.BettingOn, *gameResult.Result)
 		}
 
-		if gameResult.Result != nil && *gameResult.Result == state.BettingOn {
-			state.ProcessWin(strategy)
-		} else {
-			state.ProcessLoss(strategy)
+		betResult := BetResult{Outcome: outcome, BetOn: state.BettingOn, BetAmount: state.BetAmount}
+		switch outcome {
+		case OutcomeWin:
+			betResult.Payout = CalculatePayout(state.BettingOn, state.BetAmount, settings.Rules)
+			state.ProcessWin()
+		case OutcomePush:
+			state.ProcessPush()
+		default:
+			state.ProcessLoss()
 		}
+		strategy.OnResult(betResult)
+		state.ProcessSideBets(&gameResult)
 
 		state.RoundsPlayed++
+		state.RoundsObserved++
+		state.BankrollHistory = append(state.BankrollHistory, state.CurrentBankroll)
+		if state.DoubledAtRound == 0 && state.CurrentBankroll >= 2*settings.Bankroll {
+			state.DoubledAtRound = state.RoundsPlayed
+		}
 	}
 
 	// Track if the game ended profitably (when player can't bet any longer)
-	if state.CurrentBankroll > ProfitableThreshold {
+	if state.CurrentBankroll > settings.ProfitableThreshold() {
 		state.GameEndedProfitably = true
 	}
 
@@ -182,33 +196,92 @@ func RunSimulator(strategy StrategyType, dataCollector *DataCollector) *Simulato
 }
 
 type MultipleSimulationsStats struct {
-	TotalSimulations int
-
-	AvgRoundsPerGame float64
-	MinRoundsPlayed  int
-	MaxRoundsPlayed  int
-
-	AvgWinsPerGames   float64
-	MinWins           int
-	MaxWins           int
-	WinRate           float64
-	GamesWithZeroWins int
-	ZeroWinsRate      float64
-
-	AvgMaxWinsStreak float64
-	MaxWinsStreak    int
-	AvgMaxLossStreak float64
-	MaxLossStreak    int
-
-	AvgMaxBankrollReached       float64
-	MaxBankrollReacorded        float64
-	GamesWithProfitableBankroll int
-	ProfitableBankrollRate      float64
-	GamesWithProfitableEnd      int
-	ProfitableEndGamesRate      float64
+	TotalSimulations int `json:"totalSimulations"`
+
+	AvgRoundsPerGame float64 `json:"avgRoundsPerGame"`
+	MinRoundsPlayed  int     `json:"minRoundsPlayed"`
+	MaxRoundsPlayed  int     `json:"maxRoundsPlayed"`
+	// Coups dealt including the rounds sat out, and the rounds with a bet per observed round in percent
+	AvgRoundsObservedPerGame float64 `json:"avgRoundsObservedPerGame"`
+	BetRate                  float64 `json:"betRate"`
+
+	AvgWinsPerGames   float64 `json:"avgWinsPerGame"`
+	MinWins           int     `json:"minWins"`
+	MaxWins           int     `json:"maxWins"`
+	WinRate           float64 `json:"winRate"`
+	GamesWithZeroWins int     `json:"gamesWithZeroWins"`
+	ZeroWinsRate      float64 `json:"zeroWinsRate"`
+
+	AvgPushesPerGame float64 `json:"avgPushesPerGame"`
+	MaxPushes        int     `json:"maxPushes"`
+	PushRate         float64 `json:"pushRate"`
+
+	AvgMaxWinsStreak float64 `json:"avgMaxWinsStreak"`
+	MaxWinsStreak    int     `json:"maxWinsStreak"`
+	AvgMaxLossStreak float64 `json:"avgMaxLossStreak"`
+	MaxLossStreak    int     `json:"maxLossStreak"`
+
+	AvgMaxBankrollReached       float64 `json:"avgMaxBankrollReached"`
+	MaxBankrollReacorded        float64 `json:"maxBankrollRecorded"`
+	GamesWithProfitableBankroll int     `json:"gamesWithProfitableBankroll"`
+	ProfitableBankrollRate      float64 `json:"profitableBankrollRate"`
+	GamesWithProfitableEnd      int     `json:"gamesWithProfitableEnd"`
+	ProfitableEndGamesRate      float64 `json:"profitableEndGamesRate"`
+
+	GamesBusted                   int     `json:"gamesBusted"`
+	BustedRate                    float64 `json:"bustedRate"`
+	GamesWithGoalHit              int     `json:"gamesWithGoalHit"`
+	GoalHitRate                   float64 `json:"goalHitRate"`
+	GamesWithLossLimit            int     `json:"gamesWithLossLimit"`
+	LossLimitRate                 float64 `json:"lossLimitRate"`
+	GamesWithRoundCap             int     `json:"gamesWithRoundCap"`
+	RoundCapRate                  float64 `json:"roundCapRate"`
+	GamesWithBetExceedingBankroll int     `json:"gamesWithBetExceedingBankroll"`
+	BetExceedsBankrollRate        float64 `json:"betExceedsBankrollRate"`
+	GamesWithTableMaximumExit     int     `json:"gamesWithTableMaximumExit"`
+	TableMaximumExitRate          float64 `json:"tableMaximumExitRate"`
+	GamesWithShoeCap              int     `json:"gamesWithShoeCap"`
+	ShoeCapRate                   float64 `json:"shoeCapRate"`
+	GamesWithSitOutCap            int     `json:"gamesWithSitOutCap"`
+	SitOutCapRate                 float64 `json:"sitOutCapRate"`
+
+	AvgTableMaximumHitsPerGame float64 `json:"avgTableMaximumHitsPerGame"`
+	GamesWithTableMaximumHit   int     `json:"gamesWithTableMaximumHit"`
+	TableMaximumHitRate        float64 `json:"tableMaximumHitRate"`
+
+	// Money flow, edges are the losses of the gambler per amount wagered in percent
+	TotalWagered         float64 `json:"totalWagered"`
+	AvgWageredPerGame    float64 `json:"avgWageredPerGame"`
+	AvgPaidOutPerGame    float64 `json:"avgPaidOutPerGame"`
+	AvgCommissionPerGame float64 `json:"avgCommissionPerGame"`
+	TotalNetResult       float64 `json:"totalNetResult"`
+	AvgNetResultPerGame  float64 `json:"avgNetResultPerGame"`
+	RealisedHouseEdge    float64 `json:"realisedHouseEdge"`
+	TheoreticalHouseEdge float64 `json:"theoreticalHouseEdge"`
+	// Side bets are a part of the money flow, their win rate is the side bets won per side bets placed in percent
+	AvgSideBetsWageredPerGame float64 `json:"avgSideBetsWageredPerGame"`
+	SideBetWinRate            float64 `json:"sideBetWinRate"`
+
+	RoundsPlayedDistribution  Distribution `json:"roundsPlayedDistribution"`
+	FinalBankrollDistribution Distribution `json:"finalBankrollDistribution"`
+	PeakBankrollDistribution  Distribution `json:"peakBankrollDistribution"`
+	WinsStreakDistribution    Distribution `json:"winsStreakDistribution"`
+	LossStreakDistribution    Distribution `json:"lossStreakDistribution"`
+
+	BankrollTrajectories BankrollTrajectories `json:"bankrollTrajectories"`
+	Survival             SurvivalAnalysis     `json:"survival"`
+
+	// 95% confidence intervals, so runs of different strategies can be told apart from noise
+	AvgRoundsPerGameInterval       ConfidenceInterval `json:"avgRoundsPerGameInterval"`
+	WinRateInterval                ConfidenceInterval `json:"winRateInterval"`
+	ZeroWinsRateInterval           ConfidenceInterval `json:"zeroWinsRateInterval"`
+	AvgMaxBankrollReachedInterval  ConfidenceInterval `json:"avgMaxBankrollReachedInterval"`
+	ProfitableBankrollRateInterval ConfidenceInterval `json:"profitableBankrollRateInterval"`
+	ProfitableEndGamesRateInterval ConfidenceInterval `json:"profitableEndGamesRateInterval"`
+	AvgNetResultPerGameInterval    ConfidenceInterval `json:"avgNetResultPerGameInterval"`
 }
 
-func NewMultipleSimulationsStats(numSimulations int) MultipleSimulationsStats {
+func NewMultipleSimulationsStats(numSimulations int, settings Settings) MultipleSimulationsStats {
 	if numSimulations <= 0 {
 		numSimulations = 1
 	}
@@ -220,6 +293,9 @@ func NewMultipleSimulationsStats(numSimulations int) MultipleSimulationsStats {
 		MinRoundsPlayed:  MaxIntValue,
 		MaxRoundsPlayed:  0,
 
+		AvgRoundsObservedPerGame: 0.0,
+		BetRate:                  0.0,
+
 		AvgWinsPerGames:   0.0,
 		MinWins:           MaxIntValue,
 		MaxWins:           0,
@@ -227,124 +303,529 @@ func NewMultipleSimulationsStats(numSimulations int) MultipleSimulationsStats {
 		GamesWithZeroWins: 0,
 		ZeroWinsRate:      0.0,
 
+		AvgPushesPerGame: 0.0,
+		MaxPushes:        0,
+		PushRate:         0.0,
+
 		AvgMaxWinsStreak: 0.0,
 		MaxWinsStreak:    0,
 		AvgMaxLossStreak: 0.0,
 		MaxLossStreak:    0,
 
 		AvgMaxBankrollReached:       0.0,
-		MaxBankrollReacorded:        Bankroll,
+		MaxBankrollReacorded:        settings.Bankroll,
 		GamesWithProfitableBankroll: 0,
 		ProfitableBankrollRate:      0.0,
 		GamesWithProfitableEnd:      0,
 		ProfitableEndGamesRate:      0.0,
+
+		GamesBusted:                   0,
+		BustedRate:                    0.0,
+		GamesWithGoalHit:              0,
+		GoalHitRate:                   0.0,
+		GamesWithLossLimit:            0,
+		LossLimitRate:                 0.0,
+		GamesWithRoundCap:             0,
+		RoundCapRate:                  0.0,
+		GamesWithBetExceedingBankroll: 0,
+		BetExceedsBankrollRate:        0.0,
+		GamesWithTableMaximumExit:     0,
+		TableMaximumExitRate:          0.0,
+		GamesWithShoeCap:              0,
+		ShoeCapRate:                   0.0,
+		GamesWithSitOutCap:            0,
+		SitOutCapRate:                 0.0,
+
+		AvgTableMaximumHitsPerGame: 0.0,
+		GamesWithTableMaximumHit:   0,
+		TableMaximumHitRate:        0.0,
+
+		TotalWagered:         0.0,
+		AvgWageredPerGame:    0.0,
+		AvgPaidOutPerGame:    0.0,
+		AvgCommissionPerGame: 0.0,
+		TotalNetResult:       0.0,
+		AvgNetResultPerGame:  0.0,
+		RealisedHouseEdge:    0.0,
+		TheoreticalHouseEdge: 0.0,
+
+		AvgSideBetsWageredPerGame: 0.0,
+		SideBetWinRate:            0.0,
 	}
 }
 
-func RunMultipleSimulations(strategy StrategyType, numSimulations int, saveData bool) MultipleSimulationsStats {
-	if numSimulations <= 0 {
-		numSimulations = 1
+// Running totals of finished games, which are folded in the order of game IDs,
+// so the stats do not depend on how games were spread across workers
+type statsAccumulator struct {
+	settings Settings
+	stats    MultipleSimulationsStats
+	games    int
+
+	totalRoundsPlayed       int
+	totalRoundsObserved     int
+	totalWins               int
+	totalWinRate            float64
+	totalPushes             int
+	totalPushRate           float64
+	totalMaxWinsStreak      int
+	totalMaxLossStreak      int
+	totalMaxBankrollReached float64
+	totalTableMaximumHits   int
+	winRate                 runningMoments
+	totalPaidOut            float64
+	totalCommission         float64
+	netResult               runningMoments
+	wageredByBet            map[puntobanco.BetType]float64
+	wageredBySideBet        map[puntobanco.SideBetType]float64
+	totalSideBetsWagered    float64
+	totalSideBetsPlaced     int
+	totalSideBetWins        int
+
+	roundsPlayed  *quantileSketch
+	finalBankroll *quantileSketch
+	peakBankroll  *quantileSketch
+	winsStreak    *quantileSketch
+	lossStreak    *quantileSketch
+
+	trajectories *trajectoryAccumulator
+	survival     *survivalAccumulator
+}
+
+func newStatsAccumulator(numSimulations int, settings Settings) *statsAccumulator {
+	return &statsAccumulator{
+		settings:         settings,
+		stats:            NewMultipleSimulationsStats(numSimulations, settings),
+		wageredByBet:     make(map[puntobanco.BetType]float64),
+		wageredBySideBet: make(map[puntobanco.SideBetType]float64),
+		roundsPlayed:     newDiscreteQuantileSketch(),
+		finalBankroll:    newQuantileSketch(),
+		peakBankroll:     newQuantileSketch(),
+		winsStreak:       newDiscreteQuantileSketch(),
+		lossStreak:       newDiscreteQuantileSketch(),
+		trajectories:     newTrajectoryAccumulator(),
+		survival:         newSurvivalAccumulator(),
 	}
+}
 
-	stats := NewMultipleSimulationsStats(numSimulations)
+func (a *statsAccumulator) add(state *SimulatorState) {
+	stats := &a.stats
+	a.games++
 
-	// Initialize data collector if saving data is enabled
-	var dataCollector *DataCollector
-	if saveData {
-		dataCollector = NewDataCollector(
-			strategy,
-			deck.NumberOfDecks,
-			Bankroll,
-			MinimumBet,
-			numSimulations,
-		)
+	// Track played games stats
+	a.totalRoundsPlayed += state.RoundsPlayed
+	if state.RoundsPlayed < stats.MinRoundsPlayed {
+		stats.MinRoundsPlayed = state.RoundsPlayed
+	}
+	if state.RoundsPlayed > stats.MaxRoundsPlayed {
+		stats.MaxRoundsPlayed = state.RoundsPlayed
 	}
+	a.totalRoundsObserved += state.RoundsObserved
 
-	totalRoundsPlayed := 0
-	totalWins := 0
-	totalWinRate := 0.0
-	totalMaxWinsStreak := 0
-	totalMaxLossStreak := 0
-	totalMaxBankrollReached := 0.0
+	// Track wins stats
+	a.totalWins += state.Wins
+	if state.Wins < stats.MinWins {
+		stats.MinWins = state.Wins
+	}
+	if state.Wins > stats.MaxWins {
+		stats.MaxWins = state.Wins
+	}
 
-	for i := 0; i < numSimulations; i++ {
-		state := RunSimulator(strategy, dataCollector)
+	// Track win rate, games without rounds have no wins
+	winRate := 0.0
+	if state.RoundsPlayed > 0 {
+		winRate = float64(state.Wins) / float64(state.RoundsPlayed) * 100
+		a.totalWinRate += winRate
+	}
+	a.winRate.add(winRate)
 
-		// Track played games stats
-		totalRoundsPlayed += state.RoundsPlayed
-		if state.RoundsPlayed < stats.MinRoundsPlayed {
-			stats.MinRoundsPlayed = state.RoundsPlayed
-		}
-		if state.RoundsPlayed > stats.MaxRoundsPlayed {
-			stats.MaxRoundsPlayed = state.RoundsPlayed
-		}
+	// Track pushes stats
+	a.totalPushes += state.Pushes
+	if state.Pushes > stats.MaxPushes {
+		stats.MaxPushes = state.Pushes
+	}
+	if state.RoundsPlayed > 0 {
+		pushRate := float64(state.Pushes) / float64(state.RoundsPlayed) * 100
+		a.totalPushRate += pushRate
+	}
+
+	// Track zero-wins games
+	if state.Wins == 0 {
+		stats.GamesWithZeroWins++
+	}
+
+	// Track wins streak stats
+	a.totalMaxWinsStreak += state.MaxWinsStreak
+	if state.MaxWinsStreak > stats.MaxWinsStreak {
+		stats.MaxWinsStreak = state.MaxWinsStreak
+	}
+
+	// Track loss streak stats
+	a.totalMaxLossStreak += state.MaxLossStreak
+	if state.MaxLossStreak > stats.MaxLossStreak {
+		stats.MaxLossStreak = state.MaxLossStreak
+	}
+
+	// Track max bankroll reached stats
+	a.totalMaxBankrollReached += state.MaxBankrollReached
+	if state.MaxBankrollReached > stats.MaxBankrollReacorded {
+		stats.MaxBankrollReacorded = state.MaxBankrollReached
+	}
+
+	// Track games with a profitable bankroll during the game
+	if state.MaxBankrollReached > a.settings.ProfitableThreshold() {
+		stats.GamesWithProfitableBankroll++
+	}
+
+	// Track games with profitable end (when player couldn't bet anymore)
+	if state.GameEndedProfitably {
+		stats.GamesWithProfitableEnd++
+	}
 
-		// Track wins stats
-		totalWins += state.Wins
-		if state.Wins < stats.MinWins {
-			stats.MinWins = state.Wins
+	// Track why games have ended
+	switch state.ExitReason {
+	case ExitBusted:
+		stats.GamesBusted++
+	case ExitGoalHit:
+		stats.GamesWithGoalHit++
+	case ExitLossLimit:
+		stats.GamesWithLossLimit++
+	case ExitRoundCap:
+		stats.GamesWithRoundCap++
+	case ExitBetExceedsBankroll:
+		stats.GamesWithBetExceedingBankroll++
+	case ExitTableMaximum:
+		stats.GamesWithTableMaximumExit++
+	case ExitShoeCap:
+		stats.GamesWithShoeCap++
+	case ExitSitOutCap:
+		stats.GamesWithSitOutCap++
+	}
+
+	// Track bets over the table maximum
+	a.totalTableMaximumHits += state.TableMaximumHits
+	if state.TableMaximumHits > 0 {
+		stats.GamesWithTableMaximumHit++
+	}
+
+	// Track money flow
+	stats.TotalWagered += state.TotalWagered
+	a.totalPaidOut += state.TotalPaidOut
+	a.totalCommission += state.CommissionPaid
+	stats.TotalNetResult += state.NetResult()
+	a.netResult.add(state.NetResult())
+	for betType, wagered := range state.WageredByBet {
+		a.wageredByBet[betType] += wagered
+	}
+	for sideBet, wagered := range state.WageredBySideBet {
+		a.wageredBySideBet[sideBet] += wagered
+	}
+	a.totalSideBetsWagered += state.SideBetsWagered
+	a.totalSideBetsPlaced += state.SideBetsPlaced
+	a.totalSideBetWins += state.SideBetWins
+
+	// Track distributions of the values of games
+	a.roundsPlayed.add(float64(state.RoundsPlayed))
+	a.finalBankroll.add(state.CurrentBankroll)
+	a.peakBankroll.add(state.MaxBankrollReached)
+	a.winsStreak.add(float64(state.MaxWinsStreak))
+	a.lossStreak.add(float64(state.MaxLossStreak))
+	a.trajectories.add(state.BankrollHistory)
+	a.survival.add(state)
+}
+
+// Stats of the games folded so far, which are the partial results of a cancelled run
+func (a *statsAccumulator) result() MultipleSimulationsStats {
+	stats := a.stats
+	stats.TotalSimulations = a.games
+	if a.games == 0 {
+		return stats
+	}
+
+	numSimulations := float64(a.games)
+
+	// Calculate averages
+	stats.AvgRoundsPerGame = float64(a.totalRoundsPlayed) / numSimulations
+	stats.AvgRoundsObservedPerGame = float64(a.totalRoundsObserved) / numSimulations
+	if a.totalRoundsObserved > 0 {
+		stats.BetRate = float64(a.totalRoundsPlayed) / float64(a.totalRoundsObserved) * 100
+	}
+	stats.AvgWinsPerGames = float64(a.totalWins) / numSimulations
+	stats.WinRate = a.totalWinRate / numSimulations
+	stats.ZeroWinsRate = float64(stats.GamesWithZeroWins) / numSimulations * 100
+	stats.AvgPushesPerGame = float64(a.totalPushes) / numSimulations
+	stats.PushRate = a.totalPushRate / numSimulations
+	stats.AvgMaxWinsStreak = float64(a.totalMaxWinsStreak) / numSimulations
+	stats.AvgMaxLossStreak = float64(a.totalMaxLossStreak) / numSimulations
+	stats.AvgMaxBankrollReached = a.totalMaxBankrollReached / numSimulations
+	stats.ProfitableBankrollRate = float64(stats.GamesWithProfitableBankroll) / numSimulations * 100
+	stats.ProfitableEndGamesRate = float64(stats.GamesWithProfitableEnd) / numSimulations * 100
+	stats.BustedRate = float64(stats.GamesBusted) / numSimulations * 100
+	stats.GoalHitRate = float64(stats.GamesWithGoalHit) / numSimulations * 100
+	stats.LossLimitRate = float64(stats.GamesWithLossLimit) / numSimulations * 100
+	stats.RoundCapRate = float64(stats.GamesWithRoundCap) / numSimulations * 100
+	stats.BetExceedsBankrollRate = float64(stats.GamesWithBetExceedingBankroll) / numSimulations * 100
+	stats.TableMaximumExitRate = float64(stats.GamesWithTableMaximumExit) / numSimulations * 100
+	stats.ShoeCapRate = float64(stats.GamesWithShoeCap) / numSimulations * 100
+	stats.SitOutCapRate = float64(stats.GamesWithSitOutCap) / numSimulations * 100
+	stats.AvgTableMaximumHitsPerGame = float64(a.totalTableMaximumHits) / numSimulations
+	stats.TableMaximumHitRate = float64(stats.GamesWithTableMaximumHit) / numSimulations * 100
+	stats.AvgWageredPerGame = stats.TotalWagered / numSimulations
+	stats.AvgPaidOutPerGame = a.totalPaidOut / numSimulations
+	stats.AvgCommissionPerGame = a.totalCommission / numSimulations
+	stats.AvgNetResultPerGame = stats.TotalNetResult / numSimulations
+	stats.RealisedHouseEdge = RealisedHouseEdge(stats.TotalNetResult, stats.TotalWagered)
+	stats.TheoreticalHouseEdge = weightedHouseEdge(a.wageredByBet, a.wageredBySideBet, a.settings.Rules)
+	stats.AvgSideBetsWageredPerGame = a.totalSideBetsWagered / numSimulations
+	if a.totalSideBetsPlaced > 0 {
+		stats.SideBetWinRate = float64(a.totalSideBetWins) / float64(a.totalSideBetsPlaced) * 100
+	}
+	stats.RoundsPlayedDistribution = a.roundsPlayed.distribution()
+	stats.FinalBankrollDistribution = a.finalBankroll.distribution()
+	stats.PeakBankrollDistribution = a.peakBankroll.distribution()
+	stats.WinsStreakDistribution = a.winsStreak.distribution()
+	stats.LossStreakDistribution = a.lossStreak.distribution()
+	stats.BankrollTrajectories = a.trajectories.result()
+	stats.Survival = a.survival.result()
+
+	// Calculate confidence intervals
+	stats.AvgRoundsPerGameInterval = meanInterval(a.roundsPlayed.moments)
+	stats.WinRateInterval = meanInterval(a.winRate)
+	stats.ZeroWinsRateInterval = wilsonInterval(stats.GamesWithZeroWins, a.games)
+	stats.AvgMaxBankrollReachedInterval = meanInterval(a.peakBankroll.moments)
+	stats.ProfitableBankrollRateInterval = wilsonInterval(stats.GamesWithProfitableBankroll, a.games)
+	stats.ProfitableEndGamesRateInterval = wilsonInterval(stats.GamesWithProfitableEnd, a.games)
+	stats.AvgNetResultPerGameInterval = meanInterval(a.netResult)
+
+	return stats
+}
+
+// Progress of a run with the running stats of the games finished so far.
+// The stats are only updated each hundredth of the run and after the last game, so they may lag behind the count.
+type Progress struct {
+	CompletedSimulations int
+	TotalSimulations     int
+	Stats                MultipleSimulationsStats
+}
+
+// Called after each finished game from the goroutine that runs the simulations,
+// so it should not block for long
+type ProgressFunc func(Progress)
+
+// Reports the progress after each folded game, the running stats are computed
+// only each hundredth of the run, since computing them takes longer than a game
+type progressReporter struct {
+	onProgress     ProgressFunc
+	numSimulations int
+	statsInterval  int
+	stats          MultipleSimulationsStats
+}
+
+func newProgressReporter(onProgress ProgressFunc, numSimulations int) *progressReporter {
+	return &progressReporter{
+		onProgress:     onProgress,
+		numSimulations: numSimulations,
+		statsInterval:  max(1, numSimulations/100),
+	}
+}
+
+func (r *progressReporter) report(completedSimulations int, runningStats func() MultipleSimulationsStats) {
+	if r.onProgress == nil {
+		return
+	}
+	if completedSimulations%r.statsInterval == 0 || completedSimulations == r.numSimulations {
+		r.stats = runningStats()
+	}
+	r.onProgress(Progress{
+		CompletedSimulations: completedSimulations,
+		TotalSimulations:     r.numSimulations,
+		Stats:                r.stats,
+	})
+}
+
+// Shoes and random choices of the strategy are drawn from separate seeds,
+// so the same shoe seed deals the same shoes to any strategy
+type simulationJob struct {
+	gameIndex    int
+	shoeSeed     int64
+	strategySeed int64
+}
+
+type simulationResult struct {
+	gameIndex int
+	shoeSeed  int64
+	state     *SimulatorState
+	hands     []Hands
+}
+
+// Number of games dispatched ahead of the next game to fold, so a slow game does not make the finished games pile up
+func maxGamesInFlight(workers int) int {
+	return 2 * workers
+}
+
+// Seeds of the games are drawn in order from one generator, the jobs channel is closed after the last game.
+// A game takes a slot before it is dispatched, and the slot is freed when the game is folded.
+func generateSimulationJobs(ctx context.Context, seed int64, numSimulations int, jobs chan<- simulationJob, slots chan<- struct{}) {
+	defer close(jobs)
+	seeds := deck.NewShuffler(seed)
+	for i := 0; i < numSimulations; i++ {
+		job := simulationJob{gameIndex: i, shoeSeed: seeds.Int63(), strategySeed: seeds.Int63()}
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			return
 		}
-		if state.Wins > stats.MaxWins {
-			stats.MaxWins = state.Wins
+		select {
+		case jobs <- job:
+		case <-ctx.Done():
+			return
 		}
+	}
+}
 
-		// Track win rate
-		if state.RoundsPlayed > 0 {
-			winRate := float64(state.Wins) / float64(state.RoundsPlayed) * 100
-			totalWinRate += winRate
-		}
+// Play a game of the job, the strategy is known to exist, it was checked before the run
+func playSimulation(strategy StrategyType, settings Settings, job simulationJob, dataCollector *DataCollector) *SimulatorState {
+	gameStrategy, _ := NewStrategy(strategy, settings, deck.NewShuffler(job.strategySeed))
+	return RunSimulator(gameStrategy, settings, dataCollector, deck.NewShuffler(job.shoeSeed))
+}
 
-		// Track zero-wins games
-		if state.Wins == 0 {
-			stats.GamesWithZeroWins++
-		}
+// Each game gets its own shufflers seeded from the master generator,
+// so the results are the same for any number of workers
+func runSimulationWorker(ctx context.Context, strategy StrategyType, settings Settings, collectData bool, jobs <-chan simulationJob, results chan<- simulationResult) {
+	// Games of the worker only play the strategy of the run
+	settings.TableMaximumPolicy = settings.TableMaximumPolicyFor(strategy)
 
-		// Track wins streak stats
-		totalMaxWinsStreak += state.MaxWinsStreak
-		if state.MaxWinsStreak > stats.MaxWinsStreak {
-			stats.MaxWinsStreak = state.MaxWinsStreak
+	for job := range jobs {
+		// Skip the remaining jobs of a cancelled run
+		if ctx.Err() != nil {
+			continue
 		}
 
-		// Track loss streak stats
-		totalMaxLossStreak += state.MaxLossStreak
-		if state.MaxLossStreak > stats.MaxLossStreak {
-			stats.MaxLossStreak = state.MaxLossStreak
+		var gameCollector *DataCollector
+		if collectData {
+			gameCollector = newGameDataCollector()
 		}
 
-		// Track max bankroll reached stats
-		totalMaxBankrollReached += state.MaxBankrollReached
-		if state.MaxBankrollReached > stats.MaxBankrollReacorded {
-			stats.MaxBankrollReacorded = state.MaxBankrollReached
-		}
+		state := playSimulation(strategy, settings, job, gameCollector)
 
-		// Track games with a profitable bankroll during the game
-		if state.MaxBankrollReached > ProfitableThreshold {
-			stats.GamesWithProfitableBankroll++
+		result := simulationResult{gameIndex: job.gameIndex, shoeSeed: job.shoeSeed, state: state}
+		if gameCollector != nil {
+			result.hands = gameCollector.data.Games[0]
 		}
+		results <- result
+	}
+}
+
+// The error of the first game which could not be dealt to the end is returned along with the stats
+func runSimulations(ctx context.Context, strategy StrategyType, settings Settings, numSimulations int, dataCollector *DataCollector, seed int64, onProgress ProgressFunc) (MultipleSimulationsStats, error) {
+	if numSimulations <= 0 {
+		numSimulations = 1
+	}
 
-		// Track games with profitable end (when player couldn't bet anymore)
-		if state.GameEndedProfitably {
-			stats.GamesWithProfitableEnd++
+	workers := settings.NumberOfWorkers(numSimulations)
+	jobs := make(chan simulationJob, workers)
+	results := make(chan simulationResult, workers)
+
+	slots := make(chan struct{}, maxGamesInFlight(workers))
+
+	go generateSimulationJobs(ctx, seed, numSimulations, jobs, slots)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			runSimulationWorker(ctx, strategy, settings, dataCollector != nil, jobs, results)
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	// Games finish out of order, so they wait here until all previous games are folded
+	accumulator := newStatsAccumulator(numSimulations, settings)
+	pending := make(map[int]simulationResult)
+	nextGameIndex := 0
+	progress := newProgressReporter(onProgress, numSimulations)
+	var dealError error
+
+	for result := range results {
+		pending[result.gameIndex] = result
+
+		for {
+			next, ok := pending[nextGameIndex]
+			if !ok {
+				break
+			}
+			delete(pending, nextGameIndex)
+			<-slots
+
+			accumulator.add(next.state)
+			if next.state.DealError != nil && dealError == nil {
+				dealError = fmt.Errorf("Failed to deal game %d: %w", nextGameIndex+1, next.state.DealError)
+			}
+			if dataCollector != nil {
+				dataCollector.appendGame(next.hands, next.shoeSeed)
+			}
+			nextGameIndex++
+
+			progress.report(nextGameIndex, accumulator.result)
 		}
 	}
 
-	// Calculate averages
-	stats.AvgRoundsPerGame = float64(totalRoundsPlayed) / float64(numSimulations)
-	stats.AvgWinsPerGames = float64(totalWins) / float64(numSimulations)
-	stats.WinRate = totalWinRate / float64(numSimulations)
-	stats.ZeroWinsRate = float64(stats.GamesWithZeroWins) / float64(numSimulations) * 100
-	stats.AvgMaxWinsStreak = float64(totalMaxWinsStreak) / float64(numSimulations)
-	stats.AvgMaxLossStreak = float64(totalMaxLossStreak) / float64(numSimulations)
-	stats.AvgMaxBankrollReached = totalMaxBankrollReached / float64(numSimulations)
-	stats.ProfitableBankrollRate = float64(stats.GamesWithProfitableBankroll) / float64(numSimulations) * 100
-	stats.ProfitableEndGamesRate = float64(stats.GamesWithProfitableEnd) / float64(numSimulations) * 100
+	return accumulator.result(), dealError
+}
+
+// Simulations are spread across workers, and the whole run can be regenerated from the seed.
+// A cancelled run returns the stats of the games finished before the cancellation along with the context error,
+// and a run whose data cannot be saved returns the stats along with the error of saving.
+func RunMultipleSimulations(
+	ctx context.Context,
+	strategy StrategyType,
+	settings Settings,
+	numSimulations int,
+	saveData bool,
+	seed int64,
+	onProgress ProgressFunc,
+) (MultipleSimulationsStats, error) {
+	if numSimulations <= 0 {
+		numSimulations = 1
+	}
+
+	if _, err := NewStrategy(strategy, settings, deck.NewShuffler(seed)); err != nil {
+		return NewMultipleSimulationsStats(numSimulations, settings), err
+	}
+
+	// Initialize data collector if saving data is enabled
+	var dataCollector *DataCollector
+	if saveData {
+		dataCollector = NewDataCollector(
+			strategy,
+			settings.Rules.NumberOfDecks,
+			settings.Bankroll,
+			settings.Rules.MinimumBet,
+			numSimulations,
+			seed,
+		)
+	}
+
+	stats, err := runSimulations(ctx, strategy, settings, numSimulations, dataCollector, seed, onProgress)
+
+	// Data of an incomplete run is not saved
+	if stats.TotalSimulations < numSimulations {
+		return stats, ctx.Err()
+	}
+	if err != nil {
+		return stats, err
+	}
 
 	// Save simulation data if collection was enabled
 	if dataCollector != nil {
 		if err := SaveSimulationData(dataCollector.GetSimulationData()); err != nil {
-			fmt.Printf("Failed to save simulation data: %v\n", err)
+			return stats, fmt.Errorf("Failed to save simulation data: %w", err)
 		}
 	}
 
-	return stats
+	return stats, nil
 }
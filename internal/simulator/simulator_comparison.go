@@ -0,0 +1,247 @@
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/adequatica/punto-banco-golango/internal/deck"
+)
+
+// Value of a game compared between strategies
+type PairedMetric string
+
+const (
+	MetricFinalBankroll PairedMetric = "final bankroll"
+	MetricPeakBankroll  PairedMetric = "peak bankroll"
+	MetricRoundsPlayed  PairedMetric = "rounds played"
+	MetricWins          PairedMetric = "wins"
+	// Percentage of games ended with a profit
+	MetricProfitableEnd PairedMetric = "profitable end"
+)
+
+func GetPairedMetrics() []PairedMetric {
+	return []PairedMetric{MetricFinalBankroll, MetricPeakBankroll, MetricRoundsPlayed, MetricWins, MetricProfitableEnd}
+}
+
+func pairedMetricValue(metric PairedMetric, state *SimulatorState) float64 {
+	switch metric {
+	case MetricFinalBankroll:
+		return state.CurrentBankroll
+	case MetricPeakBankroll:
+		return state.MaxBankrollReached
+	case MetricRoundsPlayed:
+		return float64(state.RoundsPlayed)
+	case MetricWins:
+		return float64(state.Wins)
+	case MetricProfitableEnd:
+		if state.GameEndedProfitably {
+			return 100
+		}
+	}
+	return 0
+}
+
+// Stats of one strategy of a comparison
+type StrategyStats struct {
+	Strategy StrategyType             `json:"strategy"`
+	Stats    MultipleSimulationsStats `json:"stats"`
+}
+
+// Mean difference of a metric between a strategy and the baseline, paired game by game over the same shoes
+type PairedDifference struct {
+	Strategy       StrategyType `json:"strategy"`
+	Baseline       StrategyType `json:"baseline"`
+	Metric         PairedMetric `json:"metric"`
+	MeanDifference float64      `json:"meanDifference"`
+	StdError       float64      `json:"stdError"`
+	// 95% confidence interval of the mean difference
+	ConfidenceLow  float64 `json:"confidenceLow"`
+	ConfidenceHigh float64 `json:"confidenceHigh"`
+	// Standard error the difference would have if the strategies were played on independent shoes
+	UnpairedStdError float64 `json:"unpairedStdError"`
+}
+
+// Zero difference is outside of the confidence interval
+func (d PairedDifference) IsSignificant() bool {
+	return d.ConfidenceLow > 0 || d.ConfidenceHigh < 0
+}
+
+// Strategies played on the same shoes, the first strategy is the baseline of the differences
+type StrategyComparison struct {
+	Strategies  []StrategyStats    `json:"strategies"`
+	Differences []PairedDifference `json:"differences"`
+}
+
+// Running totals of the games of all strategies, folded in the order of game IDs
+type comparisonAccumulator struct {
+	strategies []StrategyType
+	stats      []*statsAccumulator
+	// Metrics of each strategy and differences of each strategy from the baseline, indexed by strategy and metric
+	values      [][]runningMoments
+	differences [][]runningMoments
+}
+
+func newComparisonAccumulator(strategies []StrategyType, numSimulations int, settings Settings) *comparisonAccumulator {
+	a := &comparisonAccumulator{strategies: strategies}
+	for range strategies {
+		a.stats = append(a.stats, newStatsAccumulator(numSimulations, settings))
+		a.values = append(a.values, make([]runningMoments, len(GetPairedMetrics())))
+		a.differences = append(a.differences, make([]runningMoments, len(GetPairedMetrics())))
+	}
+	return a
+}
+
+// States of a game of every strategy, in the order of strategies
+func (a *comparisonAccumulator) add(states []*SimulatorState) {
+	for i, state := range states {
+		a.stats[i].add(state)
+		for m, metric := range GetPairedMetrics() {
+			value := pairedMetricValue(metric, state)
+			a.values[i][m].add(value)
+			a.differences[i][m].add(value - pairedMetricValue(metric, states[0]))
+		}
+	}
+}
+
+func (a *comparisonAccumulator) result() StrategyComparison {
+	comparison := StrategyComparison{
+		Strategies:  make([]StrategyStats, 0, len(a.strategies)),
+		Differences: make([]PairedDifference, 0, (len(a.strategies)-1)*len(GetPairedMetrics())),
+	}
+
+	for i, strategy := range a.strategies {
+		comparison.Strategies = append(comparison.Strategies, StrategyStats{Strategy: strategy, Stats: a.stats[i].result()})
+		if i == 0 {
+			continue
+		}
+
+		for m, metric := range GetPairedMetrics() {
+			difference := a.differences[i][m]
+			stdError := difference.stdError()
+			baseline, other := a.values[0][m], a.values[i][m]
+			unpairedStdError := 0.0
+			if difference.count > 0 {
+				unpairedStdError = math.Sqrt((baseline.variance() + other.variance()) / float64(difference.count))
+			}
+
+			comparison.Differences = append(comparison.Differences, PairedDifference{
+				Strategy:         strategy,
+				Baseline:         a.strategies[0],
+				Metric:           metric,
+				MeanDifference:   difference.mean,
+				StdError:         stdError,
+				ConfidenceLow:    difference.mean - z95*stdError,
+				ConfidenceHigh:   difference.mean + z95*stdError,
+				UnpairedStdError: unpairedStdError,
+			})
+		}
+	}
+
+	return comparison
+}
+
+type comparisonResult struct {
+	gameIndex int
+	states    []*SimulatorState
+}
+
+// Every strategy plays each game on the shoes of the same seed (common random numbers),
+// so the differences between strategies are not blurred by the luck of the shoes.
+// A cancelled run returns the stats of the games finished by all strategies along with the context error.
+// A game which could not be dealt to the end is reported by an error along with the stats of the whole run.
+func RunStrategyComparison(
+	ctx context.Context,
+	strategies []StrategyType,
+	settings Settings,
+	numSimulations int,
+	seed int64,
+	onProgress ProgressFunc,
+) (StrategyComparison, error) {
+	if numSimulations <= 0 {
+		numSimulations = 1
+	}
+	if len(strategies) < 2 {
+		return StrategyComparison{}, fmt.Errorf("comparison should have at least 2 strategies, got %d", len(strategies))
+	}
+
+	// Each strategy plays with its own table maximum policy
+	strategySettings := make([]Settings, 0, len(strategies))
+	for _, strategy := range strategies {
+		if _, err := NewStrategy(strategy, settings, deck.NewShuffler(seed)); err != nil {
+			return StrategyComparison{}, err
+		}
+		s := settings
+		s.TableMaximumPolicy = settings.TableMaximumPolicyFor(strategy)
+		strategySettings = append(strategySettings, s)
+	}
+
+	workers := settings.NumberOfWorkers(numSimulations)
+	jobs := make(chan simulationJob, workers)
+	results := make(chan comparisonResult, workers)
+	slots := make(chan struct{}, maxGamesInFlight(workers))
+
+	go generateSimulationJobs(ctx, seed, numSimulations, jobs, slots)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				// Skip the remaining jobs of a cancelled run
+				if ctx.Err() != nil {
+					continue
+				}
+
+				states := make([]*SimulatorState, len(strategies))
+				for i, strategy := range strategies {
+					states[i] = playSimulation(strategy, strategySettings[i], job, nil)
+				}
+				results <- comparisonResult{gameIndex: job.gameIndex, states: states}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	accumulator := newComparisonAccumulator(strategies, numSimulations, settings)
+	pending := make(map[int]comparisonResult)
+	nextGameIndex := 0
+	progress := newProgressReporter(onProgress, numSimulations)
+	var dealError error
+
+	for result := range results {
+		pending[result.gameIndex] = result
+
+		for {
+			next, ok := pending[nextGameIndex]
+			if !ok {
+				break
+			}
+			delete(pending, nextGameIndex)
+			<-slots
+
+			accumulator.add(next.states)
+			for i, state := range next.states {
+				if state.DealError != nil && dealError == nil {
+					dealError = fmt.Errorf("Failed to deal game %d of %s: %w", nextGameIndex+1, strategies[i], state.DealError)
+				}
+			}
+			nextGameIndex++
+
+			progress.report(nextGameIndex, accumulator.stats[0].result)
+		}
+	}
+
+	comparison := accumulator.result()
+	if nextGameIndex < numSimulations {
+		return comparison, ctx.Err()
+	}
+
+	return comparison, dealError
+}
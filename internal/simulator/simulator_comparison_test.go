@@ -0,0 +1,157 @@
+package simulator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRunStrategyComparison(t *testing.T) {
+	strategies := []StrategyType{MartingaleOnBanco, ParoliOnBanco, BetOnRandom}
+	numberOfTestSimulations := 20
+	settings := DefaultSettings()
+	settings.MaxRounds = 100
+
+	comparison, err := RunStrategyComparison(context.Background(), strategies, settings, numberOfTestSimulations, 42, nil)
+	if err != nil {
+		t.Fatalf("RunStrategyComparison() error: %v", err)
+	}
+	if len(comparison.Strategies) != len(strategies) {
+		t.Fatalf("comparison should have %d strategies, got %d", len(strategies), len(comparison.Strategies))
+	}
+
+	for i, result := range comparison.Strategies {
+		if result.Strategy != strategies[i] {
+			t.Errorf("result %d should be of %s, got %s", i, strategies[i], result.Strategy)
+		}
+
+		// Every strategy plays the same games as a run of its own with the same seed
+		stats, err := RunMultipleSimulations(context.Background(), strategies[i], settings, numberOfTestSimulations, false, 42, nil)
+		if err != nil {
+			t.Fatalf("RunMultipleSimulations() error: %v", err)
+		}
+		if !reflect.DeepEqual(result.Stats, stats) {
+			t.Errorf("stats of %s should be the same as of a single run with the same seed", result.Strategy)
+		}
+	}
+
+	wantDifferences := (len(strategies) - 1) * len(GetPairedMetrics())
+	if len(comparison.Differences) != wantDifferences {
+		t.Fatalf("comparison should have %d differences, got %d", wantDifferences, len(comparison.Differences))
+	}
+	for _, difference := range comparison.Differences {
+		if difference.Baseline != strategies[0] || difference.Strategy == strategies[0] {
+			t.Errorf("difference of %s should be from the baseline %s, got %s", difference.Strategy, strategies[0], difference.Baseline)
+		}
+		if difference.ConfidenceLow > difference.MeanDifference || difference.ConfidenceHigh < difference.MeanDifference {
+			t.Errorf("confidence interval [%f, %f] of %s should contain the mean difference %f", difference.ConfidenceLow, difference.ConfidenceHigh, difference.Metric, difference.MeanDifference)
+		}
+	}
+}
+
+func TestRunStrategyComparison_SameShoes(t *testing.T) {
+	// The same strategy on the same shoes plays the same games, so the paired difference has no noise at all
+	comparison, err := RunStrategyComparison(context.Background(), []StrategyType{MartingaleOnBanco, MartingaleOnBanco}, DefaultSettings(), 20, 42, nil)
+	if err != nil {
+		t.Fatalf("RunStrategyComparison() error: %v", err)
+	}
+
+	for _, difference := range comparison.Differences {
+		if difference.MeanDifference != 0 || difference.StdError != 0 {
+			t.Errorf("%s of the same strategy should not differ, got %f ± %f", difference.Metric, difference.MeanDifference, difference.StdError)
+		}
+		if difference.IsSignificant() {
+			t.Errorf("%s of the same strategy should not be a significant difference", difference.Metric)
+		}
+	}
+
+	rounds := comparison.Differences[2]
+	if rounds.Metric != MetricRoundsPlayed || rounds.UnpairedStdError == 0 {
+		t.Errorf("rounds played on independent shoes should have a standard error, got %f", rounds.UnpairedStdError)
+	}
+}
+
+func TestRunStrategyComparison_Progress(t *testing.T) {
+	numberOfTestSimulations := 5
+	var reports []Progress
+
+	_, err := RunStrategyComparison(context.Background(), []StrategyType{BetOnPunto, BetOnBanco}, DefaultSettings(), numberOfTestSimulations, 42, func(p Progress) {
+		reports = append(reports, p)
+	})
+	if err != nil {
+		t.Fatalf("RunStrategyComparison() error: %v", err)
+	}
+
+	if len(reports) != numberOfTestSimulations {
+		t.Fatalf("progress should be reported %d times, got %d", numberOfTestSimulations, len(reports))
+	}
+	for i, p := range reports {
+		if p.CompletedSimulations != i+1 {
+			t.Errorf("CompletedSimulations = %d should be %d", p.CompletedSimulations, i+1)
+		}
+		if p.TotalSimulations != numberOfTestSimulations {
+			t.Errorf("TotalSimulations = %d should be %d", p.TotalSimulations, numberOfTestSimulations)
+		}
+	}
+	if last := reports[len(reports)-1]; last.Stats.TotalSimulations != numberOfTestSimulations {
+		t.Errorf("last progress report should have the stats of %d games, got %d", numberOfTestSimulations, last.Stats.TotalSimulations)
+	}
+}
+
+func TestRunStrategyComparison_InvalidStrategies(t *testing.T) {
+	tests := []struct {
+		name       string
+		strategies []StrategyType
+	}{
+		{
+			name:       "unknown strategy",
+			strategies: []StrategyType{BetOnPunto, "Unknown"},
+		},
+		{
+			name:       "single strategy",
+			strategies: []StrategyType{BetOnPunto},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comparison, err := RunStrategyComparison(context.Background(), tt.strategies, DefaultSettings(), 5, 42, nil)
+			if err == nil {
+				t.Fatal("comparison should fail")
+			}
+			if len(comparison.Strategies) != 0 {
+				t.Errorf("failed comparison should not run any strategy, got %d results", len(comparison.Strategies))
+			}
+		})
+	}
+}
+
+func TestRunStrategyComparison_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Short games, so the run is long enough to be cancelled in progress
+	settings := DefaultSettings()
+	settings.MaxRounds = 10
+
+	numberOfTestSimulations := 10000
+	cancelAfter := 5
+	comparison, err := RunStrategyComparison(ctx, []StrategyType{BetOnPunto, BetOnBanco}, settings, numberOfTestSimulations, 42, func(p Progress) {
+		if p.CompletedSimulations == cancelAfter {
+			cancel()
+		}
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("error = %v should be %v", err, context.Canceled)
+	}
+
+	for _, result := range comparison.Strategies {
+		if result.Stats.TotalSimulations < cancelAfter || result.Stats.TotalSimulations >= numberOfTestSimulations {
+			t.Errorf("partial results of %s should cover at least %d games, got %d", result.Strategy, cancelAfter, result.Stats.TotalSimulations)
+		}
+		if result.Stats.TotalSimulations != comparison.Strategies[0].Stats.TotalSimulations {
+			t.Errorf("partial results of every strategy should cover the same games")
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package simulator
+
+import "math"
+
+// 95% confidence interval of a rate or a mean, rates are in percent as the rates of the stats
+type ConfidenceInterval struct {
+	Low      float64 `json:"low"`
+	High     float64 `json:"high"`
+	StdError float64 `json:"stdError"`
+}
+
+// Wilson score interval of a proportion, which stays within 0–100% and does not collapse
+// to a single value when there are no or only successes, unlike the normal approximation
+func wilsonInterval(successes int, total int) ConfidenceInterval {
+	if total <= 0 {
+		return ConfidenceInterval{}
+	}
+
+	n := float64(total)
+	p := float64(successes) / n
+	z2 := z95 * z95
+	center := (p + z2/(2*n)) / (1 + z2/n)
+	margin := z95 / (1 + z2/n) * math.Sqrt(p*(1-p)/n+z2/(4*n*n))
+
+	return ConfidenceInterval{
+		Low:      math.Max(0, center-margin) * 100,
+		High:     math.Min(1, center+margin) * 100,
+		StdError: math.Sqrt(p*(1-p)/n) * 100,
+	}
+}
+
+// Normal interval of a mean by the central limit theorem
+func meanInterval(moments runningMoments) ConfidenceInterval {
+	if moments.count == 0 {
+		return ConfidenceInterval{}
+	}
+
+	stdError := moments.stdError()
+	return ConfidenceInterval{
+		Low:      moments.mean - z95*stdError,
+		High:     moments.mean + z95*stdError,
+		StdError: stdError,
+	}
+}
@@ -0,0 +1,64 @@
+package simulator
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWilsonInterval(t *testing.T) {
+	tests := []struct {
+		name      string
+		successes int
+		total     int
+		want      ConfidenceInterval
+	}{
+		{
+			name: "no games",
+			want: ConfidenceInterval{},
+		},
+		{
+			name:      "half of games",
+			successes: 50,
+			total:     100,
+			want:      ConfidenceInterval{Low: 40.383, High: 59.617, StdError: 5},
+		},
+		{
+			name:      "no successes",
+			successes: 0,
+			total:     100,
+			want:      ConfidenceInterval{Low: 0, High: 3.699, StdError: 0},
+		},
+		{
+			name:      "only successes",
+			successes: 100,
+			total:     100,
+			want:      ConfidenceInterval{Low: 96.301, High: 100, StdError: 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := wilsonInterval(tt.successes, tt.total)
+			if math.Abs(got.Low-tt.want.Low) > 0.001 || math.Abs(got.High-tt.want.High) > 0.001 || math.Abs(got.StdError-tt.want.StdError) > 0.001 {
+				t.Errorf("wilsonInterval(%d, %d) = %+v should be %+v", tt.successes, tt.total, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMeanInterval(t *testing.T) {
+	var moments runningMoments
+	for _, value := range []float64{2, 4, 4, 4, 5, 5, 7, 9} {
+		moments.add(value)
+	}
+
+	got := meanInterval(moments)
+	stdError := math.Sqrt(32.0 / 7 / 8)
+	if math.Abs(got.Low-(5-z95*stdError)) > 1e-9 || math.Abs(got.High-(5+z95*stdError)) > 1e-9 || math.Abs(got.StdError-stdError) > 1e-9 {
+		t.Errorf("meanInterval() = %+v should be 5 ± %f", got, z95*stdError)
+	}
+
+	if got := meanInterval(runningMoments{}); got != (ConfidenceInterval{}) {
+		t.Errorf("meanInterval() of no values = %+v should be empty", got)
+	}
+}
@@ -14,12 +14,15 @@ import (
 
 // Data structures for saving simulation data
 type SimulationData struct {
-	Strategy            string    `json:"strategy"`
-	DecksInShoe         int       `json:"decksInShoe"`
-	StartingBankroll    float64   `json:"startingBankroll"`
-	StandardBet         float64   `json:"standardBet"`
-	NumberOfSimulations int       `json:"numberOfSimulations"`
-	Games               [][]Hands `json:"games"`
+	Strategy            string  `json:"strategy"`
+	DecksInShoe         int     `json:"decksInShoe"`
+	StartingBankroll    float64 `json:"startingBankroll"`
+	StandardBet         float64 `json:"standardBet"`
+	NumberOfSimulations int     `json:"numberOfSimulations"`
+	Seed                int64   `json:"seed"`
+	// Seed of the shoes of each game in the order of games, the shoes of a game can be dealt again from it
+	ShoeSeeds []int64   `json:"shoeSeeds"`
+	Games     [][]Hands `json:"games"`
 }
 
 type Hands struct {
@@ -32,14 +35,36 @@ type Hands struct {
 	BankoTotal int      `json:"bankoTotal"`
 	Result     string   `json:"result"`
 	Bet        BetData  `json:"bet"`
+	// Only for side betting strategies
+	SideBets []SideBetData `json:"sideBets,omitempty"`
 }
 
 type BetData struct {
 	BetOn         string  `json:"betOn"`
 	IsWin         bool    `json:"isWin"`
+	IsPush        bool    `json:"push"`
 	BetAmount     float64 `json:"betAmount"`
 	Payout        float64 `json:"payout"`
 	FinalBankroll float64 `json:"finalBankroll"`
+	// Only for cancellation strategies
+	LineLength int `json:"lineLength,omitempty"`
+}
+
+// Sat out hands have no bet and are saved as an empty object
+func (b BetData) MarshalJSON() ([]byte, error) {
+	if b.BetOn == "" {
+		return []byte("{}"), nil
+	}
+	// Alias drops the method, so the bet is marshalled field by field
+	type betData BetData
+	return json.Marshal(betData(b))
+}
+
+type SideBetData struct {
+	SideBet   string  `json:"sideBet"`
+	IsWin     bool    `json:"isWin"`
+	BetAmount float64 `json:"betAmount"`
+	Payout    float64 `json:"payout"`
 }
 
 func FormatCard(card *deck.Card) string {
@@ -78,12 +103,26 @@ func FormatBetAndResultType(betType puntobanco.BetType) string {
 	}
 }
 
+func FormatSideBetType(sideBet puntobanco.SideBetType) string {
+	switch sideBet {
+	case puntobanco.PuntoPair:
+		return "puntoPair"
+	case puntobanco.BancoPair:
+		return "bankoPair"
+	case puntobanco.EitherPair:
+		return "eitherPair"
+	case puntobanco.PerfectPair:
+		return "perfectPair"
+	default:
+		return ""
+	}
+}
+
 type DataCollector struct {
-	data            *SimulationData
-	currentGameID   int
-	currentHandID   int
-	currentShoeNum  int
-	previousShoeLen int
+	data           *SimulationData
+	currentGameID  int
+	currentHandID  int
+	currentShoeNum int
 }
 
 // Create a new data collector for simulation data
@@ -93,7 +132,8 @@ func NewDataCollector(
 	decksInShoe int,
 	startingBankroll float64,
 	standardBet float64,
-	numberOfSimulations int) *DataCollector {
+	numberOfSimulations int,
+	seed int64) *DataCollector {
 	return &DataCollector{
 		data: &SimulationData{
 			Strategy:            string(strategy),
@@ -101,21 +141,40 @@ func NewDataCollector(
 			StartingBankroll:    startingBankroll,
 			StandardBet:         standardBet,
 			NumberOfSimulations: numberOfSimulations,
+			Seed:                seed,
+			ShoeSeeds:           make([]int64, 0, numberOfSimulations),
 			Games:               make([][]Hands, 0, numberOfSimulations),
 		},
-		currentGameID:   0,
-		currentHandID:   0,
-		currentShoeNum:  0,
-		previousShoeLen: 0,
+		currentGameID:  0,
+		currentHandID:  0,
+		currentShoeNum: 0,
 	}
 }
 
+// Collector of a single game played by a simulation worker
+func newGameDataCollector() *DataCollector {
+	return &DataCollector{
+		data: &SimulationData{
+			Games: make([][]Hands, 0, 1),
+		},
+	}
+}
+
+// Append a game collected separately, numbering it after the games already collected
+func (dc *DataCollector) appendGame(hands []Hands, shoeSeed int64) {
+	dc.currentGameID++
+	for i := range hands {
+		hands[i].GameID = dc.currentGameID
+	}
+	dc.data.ShoeSeeds = append(dc.data.ShoeSeeds, shoeSeed)
+	dc.data.Games = append(dc.data.Games, hands)
+}
+
 // Initialize a new game in the data collector
-func (dc *DataCollector) StartNewGame(shoe []deck.Card) {
+func (dc *DataCollector) StartNewGame() {
 	dc.currentGameID++
 	dc.currentHandID = 0
 	dc.currentShoeNum = 1
-	dc.previousShoeLen = len(shoe)
 	dc.data.Games = append(dc.data.Games, make([]Hands, 0))
 }
 
@@ -123,21 +182,14 @@ func (dc *DataCollector) StartNewGame(shoe []deck.Card) {
 func (dc *DataCollector) CollectHandData(
 	state *SimulatorState,
 	gameResult *puntobanco.GameResultState,
-	previousShoeLength int,
 ) {
 	if dc.data == nil {
 		return
 	}
 
-	// Track shoe number - increment when a new shoe is created
-	if gameResult != nil {
-		currentShoeLength := len(gameResult.RemainingShoe)
-		// A new shoe is created when the remaining shoe has less than 8 cards
-		// If previous shoe length was small (< 8) and current is large (>= 8), then a new shoe was created
-		if previousShoeLength < 8 && currentShoeLength >= 8 {
-			dc.currentShoeNum++
-		}
-		dc.previousShoeLen = currentShoeLength
+	// Track shoe number - increment when the coup is dealt from a new shoe
+	if gameResult != nil && gameResult.IsNewShoe {
+		dc.currentShoeNum++
 	}
 
 	// Increment hand ID each round
@@ -182,28 +234,52 @@ func (dc *DataCollector) CollectHandData(
 		}
 	}
 
-	// Get bet information
+	// Get bet information, a sat out hand has none
 	var betOn string
 	var isWin bool
+	var isPush bool
 	var betAmount float64
 	var payout float64
 	var finalBankroll float64
+	var lineLength int
 
-	if state != nil {
+	if state != nil && !state.IsSittingOut() {
 		betOn = FormatBetAndResultType(state.BettingOn)
 		betAmount = state.BetAmount
 		finalBankroll = state.CurrentBankroll
+		lineLength = state.LineLength
 
-		// Determine if this hand was a win
-		if gameResult != nil && gameResult.Result != nil && *gameResult.Result == state.BettingOn {
-			isWin = true
-			payout = CalculatePayout(state.BettingOn, state.BetAmount)
+		// Determine if this hand was a win, or the bet was returned on a tie
+		outcome := OutcomeLoss
+		if gameResult != nil && gameResult.Result != nil {
+			outcome = DetermineOutcome(state.BettingOn, *gameResult.Result)
+		}
+
+		isWin = outcome == OutcomeWin
+		isPush = outcome == OutcomePush
+		if isWin {
+			payout = CalculatePayout(state.BettingOn, state.BetAmount, state.Settings.Rules)
 		} else {
-			isWin = false
 			payout = 0.0
 		}
 	}
 
+	// Get side bets information, they are placed only together with a bet
+	var sideBets []SideBetData
+	if state != nil && !state.IsSittingOut() {
+		for _, sideBet := range state.SideBets {
+			sideBetData := SideBetData{
+				SideBet:   FormatSideBetType(sideBet.Type),
+				BetAmount: sideBet.Amount,
+			}
+			if gameResult != nil && gameResult.IsSideBetWon(sideBet.Type) {
+				sideBetData.IsWin = true
+				sideBetData.Payout = sideBet.Amount * sideBet.Type.Payout()
+			}
+			sideBets = append(sideBets, sideBetData)
+		}
+	}
+
 	// Create hand data
 	handData := Hands{
 		GameID:     dc.currentGameID,
@@ -217,10 +293,13 @@ func (dc *DataCollector) CollectHandData(
 		Bet: BetData{
 			BetOn:         betOn,
 			IsWin:         isWin,
+			IsPush:        isPush,
 			BetAmount:     betAmount,
 			Payout:        payout,
 			FinalBankroll: finalBankroll,
+			LineLength:    lineLength,
 		},
+		SideBets: sideBets,
 	}
 
 	// This should not happen in normal flow, but handle gracefully
@@ -247,6 +326,7 @@ func CreateSimulationDataFilename(strategy string, numberOfSimulations int, useG
 		"'", "",
 		"é", "e",
 		"É", "E",
+		"è", "e",
 	)
 	sanitizedStrategy := replacer.Replace(strategy)
 
@@ -1,12 +1,15 @@
 package simulator
 
 import (
+	"encoding/json"
+	"reflect"
 	"regexp"
 	"strings"
 	"testing"
 
 	"github.com/adequatica/punto-banco-golango/internal/deck"
 	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
+	"github.com/adequatica/punto-banco-golango/internal/rules"
 )
 
 func TestFormatCard(t *testing.T) {
@@ -115,6 +118,27 @@ func TestFormatBetAndResultType(t *testing.T) {
 	}
 }
 
+func TestFormatSideBetType(t *testing.T) {
+	tests := []struct {
+		sideBet  puntobanco.SideBetType
+		expected string
+	}{
+		{sideBet: puntobanco.PuntoPair, expected: "puntoPair"},
+		{sideBet: puntobanco.BancoPair, expected: "bankoPair"},
+		{sideBet: puntobanco.EitherPair, expected: "eitherPair"},
+		{sideBet: puntobanco.PerfectPair, expected: "perfectPair"},
+		{sideBet: puntobanco.SideBetType("Unknown"), expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.sideBet), func(t *testing.T) {
+			if result := FormatSideBetType(tt.sideBet); result != tt.expected {
+				t.Errorf("FormatSideBetType() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestNewDataCollector(t *testing.T) {
 	tests := []struct {
 		name                string
@@ -123,11 +147,13 @@ func TestNewDataCollector(t *testing.T) {
 		startingBankroll    float64
 		standardBet         float64
 		numberOfSimulations int
+		seed                int64
 		expectedStrategy    string
 		expectedDecksInShoe int
 		expectedBankroll    float64
 		expectedBet         float64
 		expectedSimulations int
+		expectedSeed        int64
 	}{
 		{
 			name:                "Create data collector with valid parameters",
@@ -136,11 +162,13 @@ func TestNewDataCollector(t *testing.T) {
 			startingBankroll:    1000.0,
 			standardBet:         10.0,
 			numberOfSimulations: 100,
+			seed:                42,
 			expectedStrategy:    string(BetOnPunto),
 			expectedDecksInShoe: 6,
 			expectedBankroll:    1000.0,
 			expectedBet:         10.0,
 			expectedSimulations: 100,
+			expectedSeed:        42,
 		},
 		{
 			name:                "Create data collector with different parameters",
@@ -149,11 +177,13 @@ func TestNewDataCollector(t *testing.T) {
 			startingBankroll:    2000.0,
 			standardBet:         20.0,
 			numberOfSimulations: 10000,
+			seed:                -7,
 			expectedStrategy:    string(MartingaleOnPunto),
 			expectedDecksInShoe: 8,
 			expectedBankroll:    2000.0,
 			expectedBet:         20.0,
 			expectedSimulations: 10000,
+			expectedSeed:        -7,
 		},
 	}
 
@@ -165,6 +195,7 @@ func TestNewDataCollector(t *testing.T) {
 				tt.startingBankroll,
 				tt.standardBet,
 				tt.numberOfSimulations,
+				tt.seed,
 			)
 
 			if dc == nil {
@@ -195,6 +226,10 @@ func TestNewDataCollector(t *testing.T) {
 				t.Errorf("NumberOfSimulations = %v, want %v", dc.data.NumberOfSimulations, tt.expectedSimulations)
 			}
 
+			if dc.data.Seed != tt.expectedSeed {
+				t.Errorf("Seed = %v, want %v", dc.data.Seed, tt.expectedSeed)
+			}
+
 			if dc.data.Games == nil {
 				t.Fatal("Games slice should not be nil")
 			}
@@ -219,11 +254,10 @@ func TestNewDataCollector(t *testing.T) {
 }
 
 func TestDataCollector_StartNewGame(t *testing.T) {
-	dc := NewDataCollector(BetOnPunto, 6, 1000.0, 10.0, 100)
-	shoe := deck.MakeNewShoe()
+	dc := NewDataCollector(BetOnPunto, 6, 1000.0, 10.0, 100, 42)
 
 	// Start first game
-	dc.StartNewGame(shoe)
+	dc.StartNewGame()
 
 	if dc.currentGameID != 1 {
 		t.Errorf("currentGameID = %d, want 1", dc.currentGameID)
@@ -237,10 +271,6 @@ func TestDataCollector_StartNewGame(t *testing.T) {
 		t.Errorf("currentShoeNum = %d, want 1", dc.currentShoeNum)
 	}
 
-	if dc.previousShoeLen != len(shoe) {
-		t.Errorf("previousShoeLen = %d, want %d", dc.previousShoeLen, len(shoe))
-	}
-
 	if len(dc.data.Games) != 1 {
 		t.Errorf("Games length = %d, want 1", len(dc.data.Games))
 	}
@@ -250,7 +280,7 @@ func TestDataCollector_StartNewGame(t *testing.T) {
 	}
 
 	// Start second game
-	dc.StartNewGame(shoe)
+	dc.StartNewGame()
 
 	if dc.currentGameID != 2 {
 		t.Errorf("currentGameID = %d, want 2", dc.currentGameID)
@@ -266,12 +296,13 @@ func TestDataCollector_StartNewGame(t *testing.T) {
 }
 
 func TestDataCollector_CollectHandData(t *testing.T) {
-	dc := NewDataCollector(BetOnPunto, 6, 1000.0, 10.0, 100)
-	shoe := deck.MakeNewShoe()
-	dc.StartNewGame(shoe)
+	dc := NewDataCollector(BetOnPunto, 6, 1000.0, 10.0, 100, 42)
+	shoe := deck.MakeNewShoe(rules.DefaultTableRules(), deck.NewShuffler(deck.NewRandomSeed()))
+	dc.StartNewGame()
 
 	// Create test state
 	state := &SimulatorState{
+		Settings:        DefaultSettings(),
 		CurrentBankroll: 1000.0,
 		BettingOn:       puntobanco.PuntoPlayer,
 		BetAmount:       10.0,
@@ -298,11 +329,11 @@ func TestDataCollector_CollectHandData(t *testing.T) {
 			ThirdCard:  nil,
 			Points:     0,
 		},
-		RemainingShoe: shoe[4:],
+		RemainingShoe: deck.Shoe{Cards: shoe.Cards[4:]},
 	}
 
 	// Collect hand data
-	dc.CollectHandData(state, gameResult, len(shoe))
+	dc.CollectHandData(state, gameResult)
 
 	// Verify data was collected
 	if len(dc.data.Games) != 1 {
@@ -371,6 +402,10 @@ func TestDataCollector_CollectHandData(t *testing.T) {
 		t.Errorf("Bet.IsWin = %v, want true", hand.Bet.IsWin)
 	}
 
+	if hand.Bet.IsPush {
+		t.Errorf("Bet.IsPush = %v, want false", hand.Bet.IsPush)
+	}
+
 	if hand.Bet.BetAmount != 10.0 {
 		t.Errorf("Bet.BetAmount = %f, want 10.0", hand.Bet.BetAmount)
 	}
@@ -381,11 +416,12 @@ func TestDataCollector_CollectHandData(t *testing.T) {
 }
 
 func TestDataCollector_CollectHandData_WithThirdCard(t *testing.T) {
-	dc := NewDataCollector(BetOnBanco, 6, 1000.0, 10.0, 100)
-	shoe := deck.MakeNewShoe()
-	dc.StartNewGame(shoe)
+	dc := NewDataCollector(BetOnBanco, 6, 1000.0, 10.0, 100, 42)
+	shoe := deck.MakeNewShoe(rules.DefaultTableRules(), deck.NewShuffler(deck.NewRandomSeed()))
+	dc.StartNewGame()
 
 	state := &SimulatorState{
+		Settings:        DefaultSettings(),
 		CurrentBankroll: 1000.0,
 		BettingOn:       puntobanco.BancoBanker,
 		BetAmount:       10.0,
@@ -413,10 +449,10 @@ func TestDataCollector_CollectHandData_WithThirdCard(t *testing.T) {
 			ThirdCard:  bancoCard3,
 			Points:     0,
 		},
-		RemainingShoe: shoe[6:],
+		RemainingShoe: deck.Shoe{Cards: shoe.Cards[6:]},
 	}
 
-	dc.CollectHandData(state, gameResult, len(shoe))
+	dc.CollectHandData(state, gameResult)
 
 	hand := dc.data.Games[0][0]
 
@@ -437,34 +473,184 @@ func TestDataCollector_CollectHandData_WithThirdCard(t *testing.T) {
 	}
 }
 
+func TestDataCollector_CollectHandData_Push(t *testing.T) {
+	dc := NewDataCollector(BetOnBanco, 6, 1000.0, 10.0, 100, 42)
+	dc.StartNewGame()
+
+	state := &SimulatorState{
+		Settings:        DefaultSettings(),
+		CurrentBankroll: 990.0,
+		BettingOn:       puntobanco.BancoBanker,
+		BetAmount:       10.0,
+	}
+
+	result := puntobanco.EgaliteTie
+	gameResult := &puntobanco.GameResultState{
+		Result: &result,
+	}
+
+	dc.CollectHandData(state, gameResult)
+
+	hand := dc.data.Games[0][0]
+
+	if hand.Result != "egalite" {
+		t.Errorf("Result = %s, want egalite", hand.Result)
+	}
+
+	if hand.Bet.IsWin {
+		t.Errorf("Bet.IsWin = %v, want false", hand.Bet.IsWin)
+	}
+
+	if !hand.Bet.IsPush {
+		t.Errorf("Bet.IsPush = %v, want true", hand.Bet.IsPush)
+	}
+
+	if hand.Bet.Payout != 0.0 {
+		t.Errorf("Bet.Payout = %f, want 0.0", hand.Bet.Payout)
+	}
+
+	jsonData, err := json.Marshal(hand.Bet)
+	if err != nil {
+		t.Fatalf("Failed to marshal bet data: %v", err)
+	}
+	if !strings.Contains(string(jsonData), `"push":true`) {
+		t.Errorf("bet data JSON should contain push flag, got %s", jsonData)
+	}
+}
+
+func TestDataCollector_CollectHandData_SatOut(t *testing.T) {
+	dc := NewDataCollector(CardCountPB, 8, 1000.0, 10.0, 100, 42)
+	dc.StartNewGame()
+
+	state := &SimulatorState{
+		Settings:        DefaultSettings(),
+		CurrentBankroll: 1000.0,
+		BettingOn:       puntobanco.BancoBanker,
+		BetAmount:       0,
+	}
+
+	result := puntobanco.PuntoPlayer
+	gameResult := &puntobanco.GameResultState{
+		Result: &result,
+	}
+
+	dc.CollectHandData(state, gameResult)
+
+	hand := dc.data.Games[0][0]
+
+	if hand.Result != "punto" {
+		t.Errorf("Result = %s, want punto", hand.Result)
+	}
+
+	if hand.Bet != (BetData{}) {
+		t.Errorf("Bet = %+v, want no bet", hand.Bet)
+	}
+
+	jsonData, err := json.Marshal(hand)
+	if err != nil {
+		t.Fatalf("Failed to marshal hand data: %v", err)
+	}
+	if !strings.Contains(string(jsonData), `"bet":{}`) {
+		t.Errorf("hand data JSON should contain an empty bet, got %s", jsonData)
+	}
+}
+
+func TestDataCollector_CollectHandData_SideBets(t *testing.T) {
+	dc := NewDataCollector(BancoWithEitherPair, 6, 1000.0, 10.0, 100, 42)
+	dc.StartNewGame()
+
+	state := &SimulatorState{
+		Settings:        DefaultSettings(),
+		CurrentBankroll: 980.0,
+		BettingOn:       puntobanco.BancoBanker,
+		BetAmount:       10.0,
+		SideBets: []SideBet{
+			{Type: puntobanco.EitherPair, Amount: 10.0},
+			{Type: puntobanco.PerfectPair, Amount: 5.0},
+		},
+	}
+
+	result := puntobanco.BancoBanker
+	gameResult := &puntobanco.GameResultState{
+		Result: &result,
+		PuntoState: &puntobanco.PlayerState{
+			FirstCard:  &deck.Card{Card: "4", Value: 4, Suit: "Hearts"},
+			SecondCard: &deck.Card{Card: "4", Value: 4, Suit: "Spades"},
+		},
+		BancoState: &puntobanco.PlayerState{
+			FirstCard:  &deck.Card{Card: "9", Value: 9, Suit: "Clubs"},
+			SecondCard: &deck.Card{Card: "K", Value: 0, Suit: "Clubs"},
+		},
+	}
+
+	dc.CollectHandData(state, gameResult)
+
+	want := []SideBetData{
+		{SideBet: "eitherPair", IsWin: true, BetAmount: 10.0, Payout: 50.0},
+		{SideBet: "perfectPair", IsWin: false, BetAmount: 5.0, Payout: 0.0},
+	}
+	if hand := dc.data.Games[0][0]; !reflect.DeepEqual(hand.SideBets, want) {
+		t.Errorf("SideBets = %+v, want %+v", hand.SideBets, want)
+	}
+
+	// Hands without side bets do not have them in JSON
+	state.SideBets = nil
+	dc.CollectHandData(state, gameResult)
+
+	jsonData, err := json.Marshal(dc.data.Games[0][1])
+	if err != nil {
+		t.Fatalf("Failed to marshal hand data: %v", err)
+	}
+	if strings.Contains(string(jsonData), "sideBets") {
+		t.Errorf("hand data JSON should not contain side bets, got %s", jsonData)
+	}
+}
+
 func TestDataCollector_CollectHandData_NewShoeDetection(t *testing.T) {
-	dc := NewDataCollector(BetOnPunto, 6, 1000.0, 10.0, 100)
-	shoe := deck.MakeNewShoe()
-	dc.StartNewGame(shoe)
+	dc := NewDataCollector(BetOnPunto, 6, 1000.0, 10.0, 100, 42)
+	shoe := deck.MakeNewShoe(rules.DefaultTableRules(), deck.NewShuffler(deck.NewRandomSeed()))
+	dc.StartNewGame()
 
 	state := &SimulatorState{
+		Settings:        DefaultSettings(),
 		CurrentBankroll: 1000.0,
 		BettingOn:       puntobanco.PuntoPlayer,
 		BetAmount:       10.0,
 	}
 
-	// Simulate a new shoe being created (previous length < 8, current >= 8)
+	// Coup from the same shoe
 	result := puntobanco.PuntoPlayer
 	gameResult := &puntobanco.GameResultState{
 		Result:        &result,
-		RemainingShoe: shoe, // Large shoe (>= 8 cards)
+		RemainingShoe: shoe,
 	}
 
-	dc.previousShoeLen = 5 // Previous shoe was small
-	dc.CollectHandData(state, gameResult, 5)
+	dc.CollectHandData(state, gameResult)
+
+	if dc.currentShoeNum != 1 {
+		t.Errorf("currentShoeNum = %d, want 1 (same shoe)", dc.currentShoeNum)
+	}
+
+	// Coup dealt from a new shoe
+	gameResult = &puntobanco.GameResultState{
+		Result:        &result,
+		RemainingShoe: shoe,
+		IsNewShoe:     true,
+	}
+
+	dc.CollectHandData(state, gameResult)
 
 	if dc.currentShoeNum != 2 {
 		t.Errorf("currentShoeNum = %d, want 2 (new shoe detected)", dc.currentShoeNum)
 	}
+
+	if dc.data.Games[0][1].ShoeNumber != 2 {
+		t.Errorf("ShoeNumber = %d, want 2", dc.data.Games[0][1].ShoeNumber)
+	}
 }
 
 func TestDataCollector_GetData(t *testing.T) {
-	dc := NewDataCollector(BetOnPunto, 6, 1000.0, 10.0, 100)
+	dc := NewDataCollector(BetOnPunto, 6, 1000.0, 10.0, 100, 42)
 
 	data := dc.GetSimulationData()
 
@@ -477,8 +663,7 @@ func TestDataCollector_GetData(t *testing.T) {
 	}
 
 	// Modify data through collector
-	shoe := deck.MakeNewShoe()
-	dc.StartNewGame(shoe)
+	dc.StartNewGame()
 
 	// Get data again and verify it's the same reference
 	data2 := dc.GetSimulationData()
@@ -534,6 +719,14 @@ func TestCreateSimulationDataFilename(t *testing.T) {
 			expectedExtension:   ".json.gz",
 			expectedSanitized:   "Egalite",
 		},
+		{
+			name:                "Strategy with special character è",
+			strategy:            "Labouchère on Banco",
+			numberOfSimulations: 10,
+			useGzip:             false,
+			expectedExtension:   ".json",
+			expectedSanitized:   "Labouchere_on_Banco",
+		},
 		{
 			name:                "Strategy with multiple special characters",
 			strategy:            "Test (Strategy) with 'quotes'",
@@ -0,0 +1,188 @@
+package simulator
+
+import (
+	"maps"
+	"math"
+	"slices"
+)
+
+const (
+	// Relative accuracy of the quantiles estimated by the sketch
+	sketchRelativeAccuracy = 0.01
+	numberOfHistogramBins  = 10
+)
+
+// Range of values of a histogram, the last bin includes its upper bound,
+// bins of whole numbers include both bounds
+type HistogramBin struct {
+	From  float64 `json:"from"`
+	To    float64 `json:"to"`
+	Count int     `json:"count"`
+}
+
+// Spread of the values of a metric over all games
+type Distribution struct {
+	Mean      float64        `json:"mean"`
+	StdDev    float64        `json:"stdDev"`
+	Min       float64        `json:"min"`
+	P5        float64        `json:"p5"`
+	P25       float64        `json:"p25"`
+	Median    float64        `json:"median"`
+	P75       float64        `json:"p75"`
+	P95       float64        `json:"p95"`
+	P99       float64        `json:"p99"`
+	Max       float64        `json:"max"`
+	Histogram []HistogramBin `json:"histogram"`
+}
+
+// Streaming quantile sketch with logarithmic buckets (DDSketch), so the values of a million games
+// do not need to be held in memory. Every quantile is estimated within the relative accuracy,
+// and the number of buckets depends on the range of the values, not on their number.
+// Values are not negative, zero values are counted separately.
+type quantileSketch struct {
+	discrete  bool // Values are whole numbers, e.g. rounds
+	gamma     float64
+	logGamma  float64
+	buckets   map[int]int
+	keys      []int // Sorted keys of the buckets
+	zeroCount int
+	count     int
+	min       float64
+	max       float64
+	moments   runningMoments
+}
+
+func newQuantileSketch() *quantileSketch {
+	gamma := (1 + sketchRelativeAccuracy) / (1 - sketchRelativeAccuracy)
+	return &quantileSketch{
+		gamma:    gamma,
+		logGamma: math.Log(gamma),
+		buckets:  make(map[int]int),
+	}
+}
+
+// Estimates of a sketch of whole numbers are whole numbers as well
+func newDiscreteQuantileSketch() *quantileSketch {
+	s := newQuantileSketch()
+	s.discrete = true
+	return s
+}
+
+func (s *quantileSketch) add(value float64) {
+	if s.count == 0 || value < s.min {
+		s.min = value
+	}
+	if s.count == 0 || value > s.max {
+		s.max = value
+	}
+	s.count++
+	s.moments.add(value)
+
+	if value <= 0 {
+		s.zeroCount++
+		return
+	}
+
+	key := int(math.Ceil(math.Log(value) / s.logGamma))
+	if _, ok := s.buckets[key]; !ok {
+		i, _ := slices.BinarySearch(s.keys, key)
+		s.keys = slices.Insert(s.keys, i, key)
+	}
+	s.buckets[key]++
+}
+
+func (s *quantileSketch) clone() *quantileSketch {
+	c := *s
+	c.buckets = maps.Clone(s.buckets)
+	c.keys = slices.Clone(s.keys)
+	return &c
+}
+
+// Value in the middle of the bucket, which is within the relative accuracy of any value of the bucket
+func (s *quantileSketch) bucketValue(key int) float64 {
+	value := 2 * math.Pow(s.gamma, float64(key)) / (s.gamma + 1)
+	if s.discrete {
+		value = math.Round(value)
+	}
+	return math.Min(math.Max(value, s.min), s.max)
+}
+
+func (s *quantileSketch) quantile(q float64) float64 {
+	if s.count == 0 {
+		return 0
+	}
+
+	rank := q * float64(s.count-1)
+	cumulative := s.zeroCount
+	if rank < float64(cumulative) {
+		return math.Max(0, s.min)
+	}
+	for _, key := range s.keys {
+		cumulative += s.buckets[key]
+		if rank < float64(cumulative) {
+			return s.bucketValue(key)
+		}
+	}
+
+	return s.max
+}
+
+// Equal-width bins from the minimum to the maximum value, values of a bucket fall into one bin
+func (s *quantileSketch) histogram() []HistogramBin {
+	if s.count == 0 {
+		return nil
+	}
+	if s.min == s.max {
+		return []HistogramBin{{From: s.min, To: s.max, Count: s.count}}
+	}
+
+	width := (s.max - s.min) / numberOfHistogramBins
+	numberOfBins := numberOfHistogramBins
+	if s.discrete {
+		// Bins of whole numbers are as wide as needed to have every number in one bin
+		width = math.Ceil((s.max - s.min + 1) / numberOfHistogramBins)
+		numberOfBins = int(math.Ceil((s.max - s.min + 1) / width))
+	}
+
+	bins := make([]HistogramBin, numberOfBins)
+	for i := range bins {
+		bins[i].From = s.min + float64(i)*width
+		bins[i].To = s.min + float64(i+1)*width
+		if s.discrete {
+			bins[i].To--
+		}
+	}
+	bins[len(bins)-1].To = s.max
+
+	binOf := func(value float64) int {
+		return min(int((value-s.min)/width), numberOfBins-1)
+	}
+	if s.zeroCount > 0 {
+		bins[binOf(math.Max(0, s.min))].Count += s.zeroCount
+	}
+	for _, key := range s.keys {
+		bins[binOf(s.bucketValue(key))].Count += s.buckets[key]
+	}
+
+	return bins
+}
+
+func (s *quantileSketch) distribution() Distribution {
+	if s.count == 0 {
+		return Distribution{}
+	}
+
+	return Distribution{
+		Mean:      s.moments.mean,
+		StdDev:    s.moments.stdDev(),
+		Min:       s.min,
+		P5:        s.quantile(0.05),
+		P25:       s.quantile(0.25),
+		Median:    s.quantile(0.5),
+		P75:       s.quantile(0.75),
+		P95:       s.quantile(0.95),
+		P99:       s.quantile(0.99),
+		Max:       s.max,
+		Histogram: s.histogram(),
+	}
+}
@@ -0,0 +1,120 @@
+package simulator
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestQuantileSketch_Quantile(t *testing.T) {
+	sketch := newQuantileSketch()
+	// Values from 1 to 1000 in an order which is not sorted
+	for i := 0; i < 1000; i++ {
+		sketch.add(float64((i*337)%1000 + 1))
+	}
+
+	tests := []struct {
+		q    float64
+		want float64
+	}{
+		{q: 0, want: 1},
+		{q: 0.05, want: 50.95},
+		{q: 0.25, want: 250.75},
+		{q: 0.5, want: 500.5},
+		{q: 0.75, want: 750.25},
+		{q: 0.95, want: 950.05},
+		{q: 0.99, want: 990.01},
+		{q: 1, want: 1000},
+	}
+
+	for _, tt := range tests {
+		got := sketch.quantile(tt.q)
+		// One more value of the rank is within the accuracy as well
+		if math.Abs(got-tt.want) > tt.want*sketchRelativeAccuracy+1 {
+			t.Errorf("quantile(%v) = %f should be within %.0f%% of %f", tt.q, got, sketchRelativeAccuracy*100, tt.want)
+		}
+	}
+}
+
+func TestQuantileSketch_Distribution(t *testing.T) {
+	t.Run("no values", func(t *testing.T) {
+		got := newQuantileSketch().distribution()
+		if got.Median != 0 || got.Histogram != nil {
+			t.Errorf("distribution of no values should be empty, got %+v", got)
+		}
+	})
+
+	t.Run("the same values", func(t *testing.T) {
+		sketch := newQuantileSketch()
+		for i := 0; i < 5; i++ {
+			sketch.add(7)
+		}
+
+		got := sketch.distribution()
+		if got.Min != 7 || got.P5 != 7 || got.Median != 7 || got.P99 != 7 || got.Max != 7 || got.StdDev != 0 {
+			t.Errorf("every quantile of the same values should be the value, got %+v", got)
+		}
+		if len(got.Histogram) != 1 || got.Histogram[0].Count != 5 {
+			t.Errorf("histogram of the same values should have one bin, got %+v", got.Histogram)
+		}
+	})
+
+	t.Run("zero values", func(t *testing.T) {
+		sketch := newQuantileSketch()
+		for _, value := range []float64{0, 0, 0, 10, 20} {
+			sketch.add(value)
+		}
+
+		got := sketch.distribution()
+		if got.Median != 0 || got.Max != 20 {
+			t.Errorf("median = %f should be 0 and max = %f should be 20", got.Median, got.Max)
+		}
+		if got.Mean != 6 {
+			t.Errorf("mean = %f should be 6", got.Mean)
+		}
+		if got.Histogram[0].Count != 3 || got.Histogram[len(got.Histogram)-1].Count != 1 {
+			t.Errorf("zero values should fall into the first bin, got %+v", got.Histogram)
+		}
+	})
+
+	t.Run("whole numbers", func(t *testing.T) {
+		sketch := newDiscreteQuantileSketch()
+		for _, value := range []float64{3, 4, 4, 5, 6, 6, 6, 7} {
+			sketch.add(value)
+		}
+
+		got := sketch.distribution()
+		if got.Median != 5 || got.P25 != 4 || got.P99 != 6 {
+			t.Errorf("quantiles of whole numbers should be exact, got %+v", got)
+		}
+		want := []HistogramBin{{From: 3, To: 3, Count: 1}, {From: 4, To: 4, Count: 2}, {From: 5, To: 5, Count: 1}, {From: 6, To: 6, Count: 3}, {From: 7, To: 7, Count: 1}}
+		if !reflect.DeepEqual(got.Histogram, want) {
+			t.Errorf("histogram = %+v should have a bin for every number %+v", got.Histogram, want)
+		}
+	})
+
+	t.Run("histogram counts every value", func(t *testing.T) {
+		sketch := newQuantileSketch()
+		for i := 1; i <= 250; i++ {
+			sketch.add(float64(i * i))
+		}
+
+		got := sketch.distribution()
+		if len(got.Histogram) != numberOfHistogramBins {
+			t.Fatalf("histogram should have %d bins, got %d", numberOfHistogramBins, len(got.Histogram))
+		}
+		total := 0
+		for _, bin := range got.Histogram {
+			total += bin.Count
+		}
+		if total != 250 {
+			t.Errorf("histogram should count 250 values, got %d", total)
+		}
+		if got.Histogram[0].From != 1 || got.Histogram[len(got.Histogram)-1].To != 62500 {
+			t.Errorf("histogram should range from the minimum to the maximum, got %+v", got.Histogram)
+		}
+		if got.Histogram[0].Count <= got.Histogram[len(got.Histogram)-1].Count {
+			t.Errorf("squares should be denser near the minimum, got %+v", got.Histogram)
+		}
+	})
+}
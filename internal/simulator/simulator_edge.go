@@ -0,0 +1,56 @@
+package simulator
+
+import (
+	"github.com/adequatica/punto-banco-golango/internal/analysis"
+	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
+	"github.com/adequatica/punto-banco-golango/internal/rules"
+)
+
+// Expected loss of the gambler per amount wagered on the bet in percent, computed exactly for a fresh shoe of the table
+func TheoreticalHouseEdge(betType puntobanco.BetType, tableRules rules.TableRules) float64 {
+	shoe, err := analysis.AnalyzeShoe(tableRules)
+	if err != nil {
+		// Table rules are validated before simulations, so the shoe always has enough cards
+		return 0
+	}
+	return shoe.HouseEdge(betType, tableRules)
+}
+
+// Expected loss of the gambler per amount wagered on the side bet in percent, computed exactly for a fresh shoe of the table
+func TheoreticalSideBetHouseEdge(sideBet puntobanco.SideBetType, tableRules rules.TableRules) float64 {
+	probability, err := analysis.FreshShoeSideBetProbability(sideBet, tableRules.NumberOfDecks)
+	if err != nil {
+		return 0
+	}
+	return analysis.SideBetHouseEdge(sideBet, probability)
+}
+
+// Realised edge of the house in percent, which is the loss of the gambler per amount wagered
+func RealisedHouseEdge(netResult float64, wagered float64) float64 {
+	if wagered <= 0 {
+		return 0
+	}
+	return -netResult / wagered * 100
+}
+
+// Theoretical edge of the bets weighted by the amounts wagered on each bet,
+// so it applies to strategies that change the side of their bets or place side bets as well
+func weightedHouseEdge(wageredByBet map[puntobanco.BetType]float64, wageredBySideBet map[puntobanco.SideBetType]float64, tableRules rules.TableRules) float64 {
+	total := 0.0
+	weighted := 0.0
+	for _, betType := range []puntobanco.BetType{puntobanco.PuntoPlayer, puntobanco.BancoBanker, puntobanco.EgaliteTie} {
+		total += wageredByBet[betType]
+		weighted += wageredByBet[betType] * TheoreticalHouseEdge(betType, tableRules)
+	}
+	for _, option := range puntobanco.GetSideBettingOptions() {
+		sideBet := puntobanco.SideBetType(option)
+		if wageredBySideBet[sideBet] > 0 {
+			total += wageredBySideBet[sideBet]
+			weighted += wageredBySideBet[sideBet] * TheoreticalSideBetHouseEdge(sideBet, tableRules)
+		}
+	}
+	if total <= 0 {
+		return 0
+	}
+	return weighted / total
+}
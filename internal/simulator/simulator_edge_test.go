@@ -0,0 +1,87 @@
+package simulator
+
+import (
+	"math"
+	"testing"
+
+	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
+	"github.com/adequatica/punto-banco-golango/internal/rules"
+)
+
+func TestTheoreticalHouseEdge(t *testing.T) {
+	noCommission := rules.DefaultTableRules()
+	noCommission.BancoCommission = 0
+	eightDecks := rules.DefaultTableRules()
+	eightDecks.NumberOfDecks = 8
+
+	tests := []struct {
+		name       string
+		betType    puntobanco.BetType
+		tableRules rules.TableRules
+		want       float64
+	}{
+		{name: "Punto", betType: puntobanco.PuntoPlayer, tableRules: rules.DefaultTableRules(), want: 1.24},
+		{name: "Banco", betType: puntobanco.BancoBanker, tableRules: rules.DefaultTableRules(), want: 1.06},
+		{name: "Égalité", betType: puntobanco.EgaliteTie, tableRules: rules.DefaultTableRules(), want: 14.44},
+		{name: "Banco without commission", betType: puntobanco.BancoBanker, tableRules: noCommission, want: -1.24},
+		{name: "Égalité from 8 decks", betType: puntobanco.EgaliteTie, tableRules: eightDecks, want: 14.36},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TheoreticalHouseEdge(tt.betType, tt.tableRules)
+			if math.Abs(got-tt.want) > 0.01 {
+				t.Errorf("TheoreticalHouseEdge() = %.4f should be %.2f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWeightedHouseEdge(t *testing.T) {
+	tableRules := rules.DefaultTableRules()
+	wageredByBet := map[puntobanco.BetType]float64{
+		puntobanco.PuntoPlayer: 100,
+		puntobanco.BancoBanker: 300,
+	}
+
+	want := (100*TheoreticalHouseEdge(puntobanco.PuntoPlayer, tableRules) + 300*TheoreticalHouseEdge(puntobanco.BancoBanker, tableRules)) / 400
+	if got := weightedHouseEdge(wageredByBet, nil, tableRules); math.Abs(got-want) > 1e-9 {
+		t.Errorf("weightedHouseEdge() = %f should be %f", got, want)
+	}
+	if got := weightedHouseEdge(nil, nil, tableRules); got != 0 {
+		t.Errorf("weightedHouseEdge() without bets = %f should be 0", got)
+	}
+
+	// Side bets are weighted together with the main bets
+	wageredBySideBet := map[puntobanco.SideBetType]float64{puntobanco.EitherPair: 100}
+	want = (400*want + 100*TheoreticalSideBetHouseEdge(puntobanco.EitherPair, tableRules)) / 500
+	if got := weightedHouseEdge(wageredByBet, wageredBySideBet, tableRules); math.Abs(got-want) > 1e-9 {
+		t.Errorf("weightedHouseEdge() with side bets = %f should be %f", got, want)
+	}
+}
+
+func TestTheoreticalSideBetHouseEdge(t *testing.T) {
+	eightDecks := rules.DefaultTableRules()
+	eightDecks.NumberOfDecks = 8
+
+	tests := []struct {
+		name       string
+		sideBet    puntobanco.SideBetType
+		tableRules rules.TableRules
+		want       float64
+	}{
+		{name: "Punto pair", sideBet: puntobanco.PuntoPair, tableRules: rules.DefaultTableRules(), want: 11.25},
+		{name: "Banco pair from 8 decks", sideBet: puntobanco.BancoPair, tableRules: eightDecks, want: 10.36},
+		{name: "Either pair from 8 decks", sideBet: puntobanco.EitherPair, tableRules: eightDecks, want: 13.71},
+		{name: "Perfect pair from 8 decks", sideBet: puntobanco.PerfectPair, tableRules: eightDecks, want: 13.03},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TheoreticalSideBetHouseEdge(tt.sideBet, tt.tableRules)
+			if math.Abs(got-tt.want) > 0.01 {
+				t.Errorf("TheoreticalSideBetHouseEdge() = %.4f should be %.2f", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package simulator
+
+import "math"
+
+// Critical value of the normal distribution for 95% confidence
+const z95 = 1.959964
+
+// Mean and variance of a stream of values updated one value at a time (Welford's algorithm),
+// so the values do not need to be held in memory
+type runningMoments struct {
+	count int
+	mean  float64
+	m2    float64
+}
+
+func (r *runningMoments) add(value float64) {
+	r.count++
+	delta := value - r.mean
+	r.mean += delta / float64(r.count)
+	r.m2 += delta * (value - r.mean)
+}
+
+// Sample variance
+func (r *runningMoments) variance() float64 {
+	if r.count < 2 {
+		return 0
+	}
+	return r.m2 / float64(r.count-1)
+}
+
+func (r *runningMoments) stdDev() float64 {
+	return math.Sqrt(r.variance())
+}
+
+// Standard error of the mean
+func (r *runningMoments) stdError() float64 {
+	if r.count == 0 {
+		return 0
+	}
+	return math.Sqrt(r.variance() / float64(r.count))
+}
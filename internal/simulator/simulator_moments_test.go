@@ -0,0 +1,56 @@
+package simulator
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRunningMoments(t *testing.T) {
+	tests := []struct {
+		name         string
+		values       []float64
+		wantMean     float64
+		wantVariance float64
+		wantStdError float64
+	}{
+		{
+			name: "no values",
+		},
+		{
+			name:     "single value",
+			values:   []float64{5},
+			wantMean: 5,
+		},
+		{
+			name:         "several values",
+			values:       []float64{2, 4, 4, 4, 5, 5, 7, 9},
+			wantMean:     5,
+			wantVariance: 32.0 / 7,
+			wantStdError: math.Sqrt(32.0 / 7 / 8),
+		},
+		{
+			name:     "the same values",
+			values:   []float64{3, 3, 3},
+			wantMean: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var moments runningMoments
+			for _, value := range tt.values {
+				moments.add(value)
+			}
+
+			if math.Abs(moments.mean-tt.wantMean) > 1e-9 {
+				t.Errorf("mean = %f should be %f", moments.mean, tt.wantMean)
+			}
+			if math.Abs(moments.variance()-tt.wantVariance) > 1e-9 {
+				t.Errorf("variance() = %f should be %f", moments.variance(), tt.wantVariance)
+			}
+			if math.Abs(moments.stdError()-tt.wantStdError) > 1e-9 {
+				t.Errorf("stdError() = %f should be %f", moments.stdError(), tt.wantStdError)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package simulator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Why a game session has ended
+type ExitReason string
+
+const (
+	// Bankroll is below the minimum bet
+	ExitBusted ExitReason = "busted"
+	// Profit has reached the stop-win
+	ExitGoalHit ExitReason = "goal hit"
+	// Loss has reached the stop-loss
+	ExitLossLimit ExitReason = "loss limit"
+	// Maximum number of rounds has been played
+	ExitRoundCap ExitReason = "round cap"
+	// Next bet of the progression is larger than the bankroll
+	ExitBetExceedsBankroll ExitReason = "bet exceeds bankroll"
+	// Next bet of the progression is larger than the table maximum, and the policy is to stop
+	ExitTableMaximum ExitReason = "table maximum"
+	// Maximum number of shoes has been dealt
+	ExitShoeCap ExitReason = "shoe cap"
+	// Strategy has sat out every coup of the maximum number of shoes in a row
+	ExitSitOutCap ExitReason = "sit-out cap"
+)
+
+func GetExitReasons() []ExitReason {
+	return []ExitReason{ExitBusted, ExitGoalHit, ExitLossLimit, ExitRoundCap, ExitBetExceedsBankroll, ExitTableMaximum, ExitShoeCap, ExitSitOutCap}
+}
+
+// Stop-win or stop-loss of a session, either an amount of money or a percentage of the starting bankroll.
+// Zero amount means no limit.
+type SessionLimit struct {
+	Amount    float64
+	IsPercent bool
+}
+
+// Parse a session limit written as an amount, e.g. "200", or a percentage of the bankroll, e.g. "20%"
+func ParseSessionLimit(limit string) (SessionLimit, error) {
+	limit = strings.TrimSpace(limit)
+	isPercent := strings.HasSuffix(limit, "%")
+	amount, err := strconv.ParseFloat(strings.TrimSuffix(limit, "%"), 64)
+	if err != nil {
+		return SessionLimit{}, fmt.Errorf("Failed to parse session limit %q: %w", limit, err)
+	}
+	if amount < 0 {
+		return SessionLimit{}, fmt.Errorf("session limit should not be negative, got %q", limit)
+	}
+
+	return SessionLimit{Amount: amount, IsPercent: isPercent}, nil
+}
+
+func (l SessionLimit) IsSet() bool {
+	return l.Amount > 0
+}
+
+// Amount of money of the limit for the starting bankroll
+func (l SessionLimit) Value(bankroll float64) float64 {
+	if l.IsPercent {
+		return bankroll * l.Amount / 100
+	}
+	return l.Amount
+}
+
+func (l SessionLimit) String() string {
+	if !l.IsSet() {
+		return "none"
+	}
+	amount := strconv.FormatFloat(l.Amount, 'f', -1, 64)
+	if l.IsPercent {
+		return amount + "%"
+	}
+	return amount
+}
@@ -0,0 +1,57 @@
+package simulator
+
+import (
+	"testing"
+)
+
+func TestParseSessionLimit(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     string
+		want      SessionLimit
+		wantValue float64
+		wantErr   bool
+	}{
+		{"amount", "200", SessionLimit{Amount: 200}, 200.0, false},
+		{"percentage of the bankroll", "20%", SessionLimit{Amount: 20, IsPercent: true}, 200.0, false},
+		{"fractional percentage", " 2.5% ", SessionLimit{Amount: 2.5, IsPercent: true}, 25.0, false},
+		{"no limit", "0", SessionLimit{}, 0.0, false},
+		{"negative amount", "-100", SessionLimit{}, 0.0, true},
+		{"not a number", "half", SessionLimit{}, 0.0, true},
+		{"empty limit", "", SessionLimit{}, 0.0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseSessionLimit(tt.limit)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSessionLimit(%q) error = %v, wantErr %v", tt.limit, err, tt.wantErr)
+			}
+			if result != tt.want {
+				t.Errorf("ParseSessionLimit(%q) = %+v should be %+v", tt.limit, result, tt.want)
+			}
+			if value := result.Value(DefaultBankroll); value != tt.wantValue {
+				t.Errorf("Value(%.2f) = %.2f should be %.2f", DefaultBankroll, value, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestSessionLimitString(t *testing.T) {
+	tests := []struct {
+		limit SessionLimit
+		want  string
+	}{
+		{SessionLimit{}, "none"},
+		{SessionLimit{Amount: 250}, "250"},
+		{SessionLimit{Amount: 12.5, IsPercent: true}, "12.5%"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if result := tt.limit.String(); result != tt.want {
+				t.Errorf("String() = %q should be %q", result, tt.want)
+			}
+		})
+	}
+}
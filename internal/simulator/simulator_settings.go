@@ -0,0 +1,124 @@
+package simulator
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/adequatica/punto-banco-golango/internal/rules"
+)
+
+const (
+	DefaultBankroll = 1000.0
+	// Paroli progression ends after three consecutive wins
+	DefaultParoliMaxLevel = 3
+	// Games end after this number of shoes in a row without a bet
+	DefaultSitOutMaxShoes = 100
+)
+
+// Settings of a simulation run: the rules of the table and the gambler's own limits.
+// They are passed by value, so simulations with different settings can run concurrently.
+type Settings struct {
+	Rules          rules.TableRules
+	Bankroll       float64
+	ParoliMaxLevel int
+	// Starting line of Labouchère strategies in wager units
+	LabouchereLine []int
+	// Progression of the stake of pattern strategies
+	PatternStake Stake
+	// The gambler walks away once the profit reaches the stop-win or the loss reaches the stop-loss
+	StopWin  SessionLimit
+	StopLoss SessionLimit
+	// Maximum number of rounds of a game, 0 means no cap
+	MaxRounds int
+	// Maximum number of shoes of a game, 0 means no cap
+	MaxShoes int
+	// Maximum number of shoes in a row whose coups are all sat out, 0 means no cap.
+	// A strategy may wait for a bet forever, so the game ends once it has not bet for so long.
+	SitOutMaxShoes int
+	// What progressions do when the next bet exceeds the maximum bet of the table rules,
+	// policies of single strategies override the default one
+	TableMaximumPolicy           TableMaximumPolicy
+	StrategyTableMaximumPolicies map[StrategyType]TableMaximumPolicy
+	// Number of goroutines running simulations, 0 means GOMAXPROCS
+	Workers int
+}
+
+func DefaultSettings() Settings {
+	return Settings{
+		Rules:          rules.DefaultTableRules(),
+		Bankroll:       DefaultBankroll,
+		ParoliMaxLevel: DefaultParoliMaxLevel,
+		LabouchereLine: DefaultLabouchereLine(),
+		PatternStake:   StakeFlat,
+		SitOutMaxShoes: DefaultSitOutMaxShoes,
+
+		TableMaximumPolicy: TableMaximumCap,
+	}
+}
+
+// 101% of the starting bankroll
+func (s Settings) ProfitableThreshold() float64 {
+	return s.Bankroll * 1.01
+}
+
+// Number of goroutines to run simulations, GOMAXPROCS by default
+func (s Settings) NumberOfWorkers(numSimulations int) int {
+	workers := s.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > numSimulations {
+		workers = numSimulations
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	return workers
+}
+
+func (s Settings) Validate() error {
+	if err := s.Rules.Validate(); err != nil {
+		return err
+	}
+	if s.Bankroll < s.Rules.MinimumBet {
+		return fmt.Errorf("bankroll of %.2f should not be less than minimum bet of %.2f", s.Bankroll, s.Rules.MinimumBet)
+	}
+	if s.ParoliMaxLevel < 1 {
+		return fmt.Errorf("Paroli max level should be at least 1, got %d", s.ParoliMaxLevel)
+	}
+	if err := validateLabouchereLine(s.LabouchereLine); err != nil {
+		return err
+	}
+	if _, err := ParseStake(string(s.PatternStake)); err != nil {
+		return err
+	}
+	if s.StopWin.Amount < 0 || s.StopLoss.Amount < 0 {
+		return fmt.Errorf("stop-win and stop-loss should not be negative, got %s and %s", s.StopWin, s.StopLoss)
+	}
+	if s.StopLoss.IsPercent && s.StopLoss.Amount > 100 {
+		return fmt.Errorf("stop-loss should not be more than 100%% of the bankroll, got %s", s.StopLoss)
+	}
+	if s.MaxRounds < 0 {
+		return fmt.Errorf("maximum number of rounds should not be negative, got %d", s.MaxRounds)
+	}
+	if s.MaxShoes < 0 {
+		return fmt.Errorf("maximum number of shoes should not be negative, got %d", s.MaxShoes)
+	}
+	if s.SitOutMaxShoes < 0 {
+		return fmt.Errorf("maximum number of shoes sat out should not be negative, got %d", s.SitOutMaxShoes)
+	}
+	if _, err := ParseTableMaximumPolicy(string(s.TableMaximumPolicy)); err != nil {
+		return err
+	}
+	for _, policy := range s.StrategyTableMaximumPolicies {
+		if _, err := ParseTableMaximumPolicy(string(policy)); err != nil {
+			return err
+		}
+	}
+	if s.Workers < 0 {
+		return fmt.Errorf("number of workers should not be negative, got %d", s.Workers)
+	}
+
+	return nil
+}
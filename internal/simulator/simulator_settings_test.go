@@ -0,0 +1,160 @@
+package simulator
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/adequatica/punto-banco-golango/internal/rules"
+)
+
+func TestDefaultSettings(t *testing.T) {
+	settings := DefaultSettings()
+
+	if err := settings.Validate(); err != nil {
+		t.Errorf("default settings should be valid: %v", err)
+	}
+
+	if settings.Bankroll != 1000.0 {
+		t.Errorf("Bankroll = %.2f should be 1000.00", settings.Bankroll)
+	}
+	if settings.Rules != rules.DefaultTableRules() {
+		t.Errorf("Rules = %+v should be default table rules", settings.Rules)
+	}
+	if settings.ProfitableThreshold() != 1010.0 {
+		t.Errorf("ProfitableThreshold() = %.2f should be 1010.00", settings.ProfitableThreshold())
+	}
+}
+
+func TestSettingsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(s *Settings)
+		wantErr bool
+	}{
+		{
+			name:    "default settings",
+			modify:  func(s *Settings) {},
+			wantErr: false,
+		},
+		{
+			name:    "invalid table rules",
+			modify:  func(s *Settings) { s.Rules.NumberOfDecks = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "bankroll below minimum bet",
+			modify:  func(s *Settings) { s.Bankroll = 5 },
+			wantErr: true,
+		},
+		{
+			name:    "bankroll of one minimum bet",
+			modify:  func(s *Settings) { s.Bankroll = s.Rules.MinimumBet },
+			wantErr: false,
+		},
+		{
+			name:    "zero Paroli level",
+			modify:  func(s *Settings) { s.ParoliMaxLevel = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "empty Labouchère line",
+			modify:  func(s *Settings) { s.LabouchereLine = nil },
+			wantErr: true,
+		},
+		{
+			name:    "unknown pattern stake",
+			modify:  func(s *Settings) { s.PatternStake = "paroli" },
+			wantErr: true,
+		},
+		{
+			name:    "stop-loss over the whole bankroll",
+			modify:  func(s *Settings) { s.StopLoss = SessionLimit{Amount: 150, IsPercent: true} },
+			wantErr: true,
+		},
+		{
+			name:    "negative maximum number of rounds",
+			modify:  func(s *Settings) { s.MaxRounds = -1 },
+			wantErr: true,
+		},
+		{
+			name:    "negative maximum number of shoes",
+			modify:  func(s *Settings) { s.MaxShoes = -1 },
+			wantErr: true,
+		},
+		{
+			name:    "unknown table maximum policy",
+			modify:  func(s *Settings) { s.TableMaximumPolicy = "double" },
+			wantErr: true,
+		},
+		{
+			name: "unknown table maximum policy of a strategy",
+			modify: func(s *Settings) {
+				s.StrategyTableMaximumPolicies = map[StrategyType]TableMaximumPolicy{BetOnPunto: ""}
+			},
+			wantErr: true,
+		},
+		{
+			name:    "negative number of workers",
+			modify:  func(s *Settings) { s.Workers = -1 },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := DefaultSettings()
+			tt.modify(&settings)
+
+			err := settings.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSettingsNumberOfWorkers(t *testing.T) {
+	tests := []struct {
+		name           string
+		workers        int
+		numSimulations int
+		want           int
+	}{
+		{
+			name:           "GOMAXPROCS by default",
+			workers:        0,
+			numSimulations: 1000000,
+			want:           runtime.GOMAXPROCS(0),
+		},
+		{
+			name:           "configured number of workers",
+			workers:        3,
+			numSimulations: 100,
+			want:           3,
+		},
+		{
+			name:           "no more workers than simulations",
+			workers:        8,
+			numSimulations: 2,
+			want:           2,
+		},
+		{
+			name:           "at least one worker",
+			workers:        4,
+			numSimulations: 0,
+			want:           1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := DefaultSettings()
+			settings.Workers = tt.workers
+
+			result := settings.NumberOfWorkers(tt.numSimulations)
+			if result != tt.want {
+				t.Errorf("NumberOfWorkers(%d) = %d should be %d", tt.numSimulations, result, tt.want)
+			}
+		})
+	}
+}
@@ -2,104 +2,133 @@ package simulator
 
 import (
 	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
+	"github.com/adequatica/punto-banco-golango/internal/rules"
 )
 
-var ParoliMaxLevel = 3
+type Outcome string
+
+const (
+	OutcomeWin  Outcome = "win"
+	OutcomeLoss Outcome = "loss"
+	// Punto and Banco bets are returned to the gambler on Égalité
+	OutcomePush Outcome = "push"
+	// The coup is sat out, nothing is wagered
+	OutcomeNoBet Outcome = "no bet"
+)
+
+func DetermineOutcome(bettingOn puntobanco.BetType, result puntobanco.BetType) Outcome {
+	if result == bettingOn {
+		return OutcomeWin
+	}
+
+	if result == puntobanco.EgaliteTie {
+		return OutcomePush
+	}
+
+	return OutcomeLoss
+}
+
+// Number of the last results kept in the state for pattern strategies
+const MaxResultsHistory = 100
 
 type SimulatorState struct {
-	CurrentBankroll     float64
-	MaxBankrollReached  float64
-	LastWinningHand     puntobanco.BetType
-	BettingOn           puntobanco.BetType
-	RoundsPlayed        int
+	Settings           Settings
+	CurrentBankroll    float64
+	MaxBankrollReached float64
+	LastWinningHand    puntobanco.BetType
+	BettingOn          puntobanco.BetType
+	// Rounds with a bet
+	RoundsPlayed int
+	// Coups dealt while the gambler is at the table, including the rounds sat out
+	RoundsObserved      int
 	Wins                int
+	Pushes              int
+	LastOutcome         Outcome
 	BetAmount           float64
 	GameEndedProfitably bool
-	// Martingale-specific fields
-	BaseBetAmount float64
+	ExitReason          ExitReason
+	// Times the progression crossed the table maximum, the capped bets of one crossing are counted once
+	TableMaximumHits int
+	// Last results of the game including Égalité, bounded by MaxResultsHistory
+	Results []puntobanco.BetType
+	// Numbers left on the line of cancellation strategies when the bet is placed
+	LineLength int
+	// Streaks of the game
 	LossStreak    int
 	WinsStreak    int
 	MaxLossStreak int
 	MaxWinsStreak int
-	// Fibonacci-specific fields
-	FibonacciSequenceIndex int     // Current position in Fibonacci sequence (0=based)
-	FibonacciProfit        float64 // Current profit in wager units (1 unit = MinimumBet)
-	// Paroli-specific fields
-	IsInParoliProgression  bool
-	ParoliProgressionLevel int // Current level in the Paroli progression (1, 2, 3)
-	ParoliGoal             int // Goal to reach before resetting
-	// D'Alembert-specific fields
-	DAlembertUnitSize float64 // Base unit size for D'Alembert progression
-	DAlembertLevel    int     // Current level in D'Alembert progression
-	// 1-3-2-6 specific fields
-	OneThreeTwoSixSequenceIndex int // Current position in 1-3-2-6 sequence (0=1, 1=3, 2=2, 3=6)
+	// Money flow of the game, paid out are winnings without the returned bets
+	TotalWagered   float64
+	TotalPaidOut   float64
+	CommissionPaid float64
+	// Amounts wagered on each side, so the theoretical edge can be weighted by them
+	WageredByBet map[puntobanco.BetType]float64
+	// Bankroll before the first round followed by the bankroll after each round
+	BankrollHistory []float64
+	// Rounds played until the bankroll could not cover the next bet, zero if the game was not ruined
+	RoundsToBust int
+	// Round of the game when the bankroll first reached double the starting bankroll, zero if it never did
+	DoubledAtRound int
+	// Shoes dealt in the game including the current one
+	ShoesPlayed int
+	// Shoe of the last bet, zero before the first bet
+	LastBetShoe int
+	// Side bets placed alongside the current bet, and their money flow which is a part of the money flow of the game
+	SideBets         []SideBet
+	SideBetWins      int
+	SideBetsPlaced   int
+	SideBetsWagered  float64
+	WageredBySideBet map[puntobanco.SideBetType]float64
+	// Error of dealing a coup, which ends the game without an exit reason
+	DealError error
 }
 
-func NewSimulatorState() *SimulatorState {
+func NewSimulatorState(settings Settings) *SimulatorState {
 	return &SimulatorState{
-		CurrentBankroll:     Bankroll,
-		MaxBankrollReached:  Bankroll,
+		Settings:            settings,
+		CurrentBankroll:     settings.Bankroll,
+		MaxBankrollReached:  settings.Bankroll,
 		LastWinningHand:     puntobanco.PuntoPlayer,
 		BettingOn:           puntobanco.PuntoPlayer,
 		RoundsPlayed:        0,
 		Wins:                0,
-		BetAmount:           MinimumBet,
+		Pushes:              0,
+		LastOutcome:         "",
+		BetAmount:           settings.Rules.MinimumBet,
 		GameEndedProfitably: false,
-		// Martingale-specific fields
-		BaseBetAmount: MinimumBet,
+		// Streaks of the game
 		LossStreak:    0,
 		WinsStreak:    0,
 		MaxLossStreak: 0,
 		MaxWinsStreak: 0,
-		// Paroli-specific fields
-		IsInParoliProgression:  false,
-		ParoliProgressionLevel: 0,
-		ParoliGoal:             ParoliMaxLevel,
-		// Fibonacci-specific fields
-		FibonacciSequenceIndex: 0,
-		FibonacciProfit:        0.0,
-		// D'Alembert-specific fields
-		DAlembertUnitSize: MinimumBet,
-		DAlembertLevel:    0,
-		// 1-3-2-6 specific fields
-		OneThreeTwoSixSequenceIndex: 0,
+		// Trajectory of the game
+		BankrollHistory: []float64{settings.Bankroll},
 	}
 }
 
-// Fibonacci sequence 0, 1, 1, 2, 3, 5, 8, 13, 21, 34, 55, 89, 144, ...
-func GetFibonacciValue(index int) int {
-	if index <= 0 {
-		return 1
-	}
-	if index == 1 {
-		return 1
-	}
-	// Prevent memory exhaustion
-	if index > 100000 {
-		return 1
-	}
-
-	// Calculation for larger indices
-	fib := make([]int, index+1)
-	fib[0] = 1
-	fib[1] = 1
-
-	for i := 2; i <= index; i++ {
-		fib[i] = fib[i-1] + fib[i-2]
+// History of the game shown to the strategy
+func (s *SimulatorState) History() History {
+	return History{
+		LastWinningHand: s.LastWinningHand,
+		RoundsPlayed:    s.RoundsPlayed,
+		RoundsObserved:  s.RoundsObserved,
+		CurrentBankroll: s.CurrentBankroll,
+		Results:         s.Results,
 	}
-
-	return fib[index]
 }
 
-func GetOneThreeTwoSixValue(index int) int {
-	sequence := []int{1, 3, 2, 6}
-	if index < 0 || index >= len(sequence) {
-		return 1 // Default to 1 unit if index is out of bounds
+// Track the result of a coup
+func (s *SimulatorState) RecordResult(result puntobanco.BetType) {
+	s.LastWinningHand = result
+
+	if len(s.Results) >= MaxResultsHistory {
+		s.Results = append(s.Results[:0], s.Results[len(s.Results)-MaxResultsHistory+1:]...)
 	}
-	return sequence[index]
+	s.Results = append(s.Results, result)
 }
 
-func CalculatePayout(betType puntobanco.BetType, betAmount float64) float64 {
+func CalculatePayout(betType puntobanco.BetType, betAmount float64, tableRules rules.TableRules) float64 {
 	switch betType {
 
 	case puntobanco.PuntoPlayer:
@@ -107,175 +136,161 @@ func CalculatePayout(betType puntobanco.BetType, betAmount float64) float64 {
 		return betAmount
 
 	case puntobanco.BancoBanker:
-		// Winning bets on Banco hand pay 19 to 20 (5% commission)
-		return betAmount * 0.95
+		// Winning bets on Banco hand pay even money minus commission (19 to 20 for 5% commission)
+		return betAmount * (1 - tableRules.BancoCommission)
 
 	case puntobanco.EgaliteTie:
 		// Standard payout for tie bet is 8-to-1
-		return betAmount * 8.0
+		return betAmount * tableRules.TiePayout
 
 	default:
 		return 0.0
 	}
 }
 
-func (s *SimulatorState) CanPlaceBet() bool {
-	return s.CurrentBankroll >= s.BetAmount
+// The strategy sits the coup out with a zero bet
+func (s *SimulatorState) IsSittingOut() bool {
+	return s.BetAmount == 0
 }
 
-func (s *SimulatorState) PlaceBet() {
-	s.CurrentBankroll -= s.BetAmount
+func (s *SimulatorState) RoundsSatOut() int {
+	return s.RoundsObserved - s.RoundsPlayed
 }
 
-func (s *SimulatorState) ProcessWin(strategy StrategyType) {
-	s.Wins++
+func (s *SimulatorState) CanPlaceBet() bool {
+	return s.CurrentBankroll >= s.BetAmount
+}
 
-	payoutAmount := CalculatePayout(s.BettingOn, s.BetAmount)
-	s.CurrentBankroll += s.BetAmount + payoutAmount
-	// Track maximum bankroll reached
-	if s.CurrentBankroll > s.MaxBankrollReached {
-		s.MaxBankrollReached = s.CurrentBankroll
+// Reason to walk away before the next bet, when a session limit is reached
+func (s *SimulatorState) SessionExitReason() (ExitReason, bool) {
+	if s.Settings.StopWin.IsSet() && s.CurrentBankroll >= s.Settings.Bankroll+s.Settings.StopWin.Value(s.Settings.Bankroll) {
+		return ExitGoalHit, true
 	}
-
-	// Martingale strategy: reset loss streak and return to base bet.
-	s.LossStreak = 0
-	s.WinsStreak++
-	// Track maximum wins streak
-	if s.WinsStreak > s.MaxWinsStreak {
-		s.MaxWinsStreak = s.WinsStreak
+	if s.Settings.StopLoss.IsSet() && s.CurrentBankroll <= s.Settings.Bankroll-s.Settings.StopLoss.Value(s.Settings.Bankroll) {
+		return ExitLossLimit, true
 	}
-	// If your bet wins, your stake remains the same for the next round, and you return to your original bet amount.
-	// This means you continue betting the same amount.
-	// Once you win a hand, you return to your original bet unit and start the process over again
-	if strategy == MartingaleOnPunto || strategy == MartingaleOnBanco {
-		s.BetAmount = s.BaseBetAmount
+	if s.Settings.MaxRounds > 0 && s.RoundsPlayed >= s.Settings.MaxRounds {
+		return ExitRoundCap, true
 	}
 
-	// Paroli strategy: handle progression after a win.
-	if strategy == ParoliOnPunto || strategy == ParoliOnBanco {
-		if !s.IsInParoliProgression {
-			// Start Paroli progression with base bet
-			s.IsInParoliProgression = true
-			s.ParoliProgressionLevel = 1
-			s.BetAmount = s.BaseBetAmount
-		} else {
-			// Continue progression
-			s.ParoliProgressionLevel++
-			if s.ParoliProgressionLevel <= s.ParoliGoal {
-				// Double the bet for next round
-				s.BetAmount = s.BetAmount * 2
-			} else {
-				// Goal reached: reset to base bet and end progression
-				s.BetAmount = s.BaseBetAmount
-				s.IsInParoliProgression = false
-				s.ParoliProgressionLevel = 0
-			}
-		}
+	return "", false
+}
+
+// Reason of the end of the game when the next bet cannot be placed
+func (s *SimulatorState) BetExitReason() ExitReason {
+	if s.CurrentBankroll < s.Settings.Rules.MinimumBet {
+		return ExitBusted
 	}
+	return ExitBetExceedsBankroll
+}
 
-	// Fibonacci strategy: move back two places in sequence after a win.
-	if strategy == FibonacciOnPunto || strategy == FibonacciOnBanco {
-		// Calculate profit in wager units (1 unit = MinimumBet)
-		payoutUnits := payoutAmount / MinimumBet
-		s.FibonacciProfit += payoutUnits
-
-		// Move back two places in the Fibonacci sequence
-		if s.FibonacciSequenceIndex >= 2 {
-			s.FibonacciSequenceIndex -= 2
-		} else {
-			s.FibonacciSequenceIndex = 0
-		}
+func (s *SimulatorState) PlaceBet() {
+	s.CurrentBankroll -= s.BetAmount
 
-		// Calculate new bet amount based on Fibonacci sequence
-		fibValue := GetFibonacciValue(s.FibonacciSequenceIndex)
-		s.BetAmount = float64(fibValue) * MinimumBet
+	s.TotalWagered += s.BetAmount
+	if s.WageredByBet == nil {
+		s.WageredByBet = make(map[puntobanco.BetType]float64)
+	}
+	s.WageredByBet[s.BettingOn] += s.BetAmount
+}
 
-		// Reset if profit reaches +1 wager unit
-		if s.FibonacciProfit >= 1.0 {
-			s.FibonacciSequenceIndex = 0
-			s.FibonacciProfit = 0.0
-			s.BetAmount = MinimumBet
+// Place the side bets the bankroll can cover after the main bet, the others are dropped
+func (s *SimulatorState) PlaceSideBets(sideBets []SideBet) {
+	s.SideBets = s.SideBets[:0]
+	for _, sideBet := range sideBets {
+		if sideBet.Amount <= 0 || s.CurrentBankroll < sideBet.Amount {
+			continue
 		}
+		s.SideBets = append(s.SideBets, sideBet)
+
+		s.CurrentBankroll -= sideBet.Amount
+		s.TotalWagered += sideBet.Amount
+		s.SideBetsWagered += sideBet.Amount
+		s.SideBetsPlaced++
+		if s.WageredBySideBet == nil {
+			s.WageredBySideBet = make(map[puntobanco.SideBetType]float64)
+		}
+		s.WageredBySideBet[sideBet.Type] += sideBet.Amount
 	}
+}
 
-	// D'Alembert strategy: decrease bet by 1 unit after a win.
-	if strategy == DAlembertOnPunto || strategy == DAlembertOnBanco {
-		if s.DAlembertLevel > 0 {
-			s.DAlembertLevel--
+// Settle the side bets on the first two cards of the hands, they do not change the streaks of the main bet
+func (s *SimulatorState) ProcessSideBets(gameResult *puntobanco.GameResultState) {
+	for _, sideBet := range s.SideBets {
+		if !gameResult.IsSideBetWon(sideBet.Type) {
+			continue
 		}
-		// Calculate new bet amount: base + (level * unit size)
-		newBetAmount := s.DAlembertUnitSize + (float64(s.DAlembertLevel) * s.DAlembertUnitSize)
-		// Ensure bet doesn't go below minimum bet
-		if newBetAmount < MinimumBet {
-			newBetAmount = MinimumBet
-			s.DAlembertLevel = 0
-		}
-		s.BetAmount = newBetAmount
+		s.SideBetWins++
+
+		payoutAmount := sideBet.Amount * sideBet.Type.Payout()
+		s.CurrentBankroll += sideBet.Amount + payoutAmount
+		s.TotalPaidOut += payoutAmount
+	}
+	// Track maximum bankroll reached
+	if s.CurrentBankroll > s.MaxBankrollReached {
+		s.MaxBankrollReached = s.CurrentBankroll
 	}
+}
 
-	// 1-3-2-6 strategy: progress through sequence after a win.
-	if strategy == OneThreeTwoSixOnPunto || strategy == OneThreeTwoSixOnBanco {
-		// Move to next position in the sequence
-		s.OneThreeTwoSixSequenceIndex++
+// Win or loss of the game so far
+func (s *SimulatorState) NetResult() float64 {
+	return s.CurrentBankroll - s.Settings.Bankroll
+}
 
-		// Reset to start if the sequence is completed (reached index 3)
-		if s.OneThreeTwoSixSequenceIndex >= 4 {
-			s.OneThreeTwoSixSequenceIndex = 0
-		}
+// Loss per amount wagered in percent, negative when the game is won
+func (s *SimulatorState) RealisedHouseEdge() float64 {
+	return RealisedHouseEdge(s.NetResult(), s.TotalWagered)
+}
+
+func (s *SimulatorState) ProcessWin() {
+	s.Wins++
+	s.LastOutcome = OutcomeWin
+
+	payoutAmount := CalculatePayout(s.BettingOn, s.BetAmount, s.Settings.Rules)
+	s.CurrentBankroll += s.BetAmount + payoutAmount
+	s.TotalPaidOut += payoutAmount
+	if s.BettingOn == puntobanco.BancoBanker {
+		s.CommissionPaid += s.BetAmount * s.Settings.Rules.BancoCommission
+	}
+	// Track maximum bankroll reached
+	if s.CurrentBankroll > s.MaxBankrollReached {
+		s.MaxBankrollReached = s.CurrentBankroll
+	}
 
-		// Calculate new bet amount based on current position in sequence
-		sequenceValue := GetOneThreeTwoSixValue(s.OneThreeTwoSixSequenceIndex)
-		s.BetAmount = float64(sequenceValue) * MinimumBet
+	s.LossStreak = 0
+	s.WinsStreak++
+	// Track maximum wins streak
+	if s.WinsStreak > s.MaxWinsStreak {
+		s.MaxWinsStreak = s.WinsStreak
 	}
 }
 
-func (s *SimulatorState) ProcessLoss(strategy StrategyType) {
-	// Martingale strategy: increment loss streakand double the bet for next round.
+func (s *SimulatorState) ProcessLoss() {
+	s.LastOutcome = OutcomeLoss
+
 	s.LossStreak++
 	s.WinsStreak = 0
 	// Track maximum loss streak
 	if s.LossStreak > s.MaxLossStreak {
 		s.MaxLossStreak = s.LossStreak
 	}
-	// If your bet loses, you double your bet size for the next hand.
-	// You continue doubling your bet after each loss until you win a hand.
-	if strategy == MartingaleOnPunto || strategy == MartingaleOnBanco {
-		s.BetAmount = s.BetAmount * 2
-	}
-
-	// Paroli strategy: reset to base bet when a loss occurs.
-	if strategy == ParoliOnPunto || strategy == ParoliOnBanco {
-		s.BetAmount = s.BaseBetAmount
-		s.IsInParoliProgression = false
-		s.ParoliProgressionLevel = 0
-	}
-
-	// Fibonacci strategy: move to next number in sequence after a loss.
-	if strategy == FibonacciOnPunto || strategy == FibonacciOnBanco {
-		// Calculate loss in wager units (1 unit = MinimumBet)
-		lossUnits := s.BetAmount / MinimumBet
-		s.FibonacciProfit -= lossUnits
+}
 
-		// Move to next number in the Fibonacci sequence
-		s.FibonacciSequenceIndex++
+// Shoes dealt to the end since the last bet, all their coups were sat out
+func (s *SimulatorState) ShoesSatOut() int {
+	return s.ShoesPlayed - s.LastBetShoe
+}
 
-		// Calculate new bet amount based on Fibonacci sequence
-		fibValue := GetFibonacciValue(s.FibonacciSequenceIndex)
-		s.BetAmount = float64(fibValue) * MinimumBet
-	}
+// Nothing is wagered, so the bankroll and the streaks stay as they are
+func (s *SimulatorState) ProcessNoBet() {
+	s.LastOutcome = OutcomeNoBet
+}
 
-	// D'Alembert strategy: increase bet by 1 unit after a loss.
-	if strategy == DAlembertOnPunto || strategy == DAlembertOnBanco {
-		s.DAlembertLevel++
-		// Calculate new bet amount: base + (level * unit size)
-		newBetAmount := s.DAlembertUnitSize + (float64(s.DAlembertLevel) * s.DAlembertUnitSize)
-		s.BetAmount = newBetAmount
-	}
+func (s *SimulatorState) ProcessPush() {
+	s.Pushes++
+	s.LastOutcome = OutcomePush
 
-	// 1-3-2-6 strategy: reset to start of sequence after a loss.
-	if strategy == OneThreeTwoSixOnPunto || strategy == OneThreeTwoSixOnBanco {
-		// Reset to the beginning of the sequence (1 unit)
-		s.OneThreeTwoSixSequenceIndex = 0
-		s.BetAmount = MinimumBet
-	}
+	// The bet is returned to the gambler.
+	// A push neither breaks nor extends the streaks, and strategies repeat the same bet.
+	s.CurrentBankroll += s.BetAmount
 }
@@ -6,86 +6,35 @@ import (
 	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
 )
 
-func TestSimulatorStateProcessLoss_FlatBettingStrategies(t *testing.T) {
+func TestSimulatorStateProcessLoss(t *testing.T) {
 	tests := []struct {
-		name     string
-		strategy StrategyType
-		betType  puntobanco.BetType
+		name    string
+		betType puntobanco.BetType
 	}{
 		{
-			name:     "Bet on Punto",
-			strategy: BetOnPunto,
-			betType:  puntobanco.PuntoPlayer,
+			name:    "Bet on Punto",
+			betType: puntobanco.PuntoPlayer,
 		},
 		{
-			name:     "Bet on Banco",
-			strategy: BetOnBanco,
-			betType:  puntobanco.BancoBanker,
+			name:    "Bet on Banco",
+			betType: puntobanco.BancoBanker,
 		},
 		{
-			name:     "Bet on Égalité",
-			strategy: BetOnEgalite,
-			betType:  puntobanco.EgaliteTie,
-		},
-		{
-			name:     "Bet on Last Hand",
-			strategy: BetOnLastHand,
-			betType:  puntobanco.PuntoPlayer,
-		},
-		// Don't test "Bet on random" cause it is a case of "Bet on Punto/Banco"
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			state := NewSimulatorState()
-			initialLossStreak := state.LossStreak
-			initialBetAmount := state.BetAmount
-
-			state.BettingOn = tt.betType
-			state.ProcessLoss(tt.strategy)
-
-			if state.LossStreak != initialLossStreak+1 {
-				t.Errorf("LossStreak should increment: got %d, want %d", state.LossStreak, initialLossStreak+1)
-			}
-			if state.WinsStreak != 0 {
-				t.Errorf("WinsStreak should reset: got %d, want 0", state.WinsStreak)
-			}
-			if state.MaxLossStreak != 1 {
-				t.Errorf("MaxLossStreak should updat: got %d, want 1", state.MaxLossStreak)
-			}
-			if state.BetAmount != initialBetAmount {
-				t.Errorf("BetAmount should not change: got %.2f, want %.2f", state.BetAmount, initialBetAmount)
-			}
-		})
-	}
-}
-
-func TestSimulatorStateProcessLoss_MartingaleStrategies(t *testing.T) {
-	tests := []struct {
-		name     string
-		strategy StrategyType
-		betType  puntobanco.BetType
-	}{
-		{
-			name:     "Martingale on Punto",
-			strategy: MartingaleOnPunto,
-			betType:  puntobanco.PuntoPlayer,
-		},
-		{
-			name:     "Martingale on Banco",
-			strategy: MartingaleOnBanco,
-			betType:  puntobanco.BancoBanker,
+			name:    "Bet on Égalité",
+			betType: puntobanco.EgaliteTie,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			state := NewSimulatorState()
+			state := NewSimulatorState(DefaultSettings())
 			initialLossStreak := state.LossStreak
 			initialBetAmount := state.BetAmount
 
 			state.BettingOn = tt.betType
-			state.ProcessLoss(tt.strategy)
+			state.PlaceBet()
+			bankrollAfterBet := state.CurrentBankroll
+			state.ProcessLoss()
 
 			if state.LossStreak != initialLossStreak+1 {
 				t.Errorf("LossStreak should increment: got %d, want %d", state.LossStreak, initialLossStreak+1)
@@ -96,219 +45,39 @@ func TestSimulatorStateProcessLoss_MartingaleStrategies(t *testing.T) {
 			if state.MaxLossStreak != 1 {
 				t.Errorf("MaxLossStreak should update: got %d, want 1", state.MaxLossStreak)
 			}
-			if state.BetAmount != initialBetAmount*2 {
-				t.Errorf("BetAmount should not double: got %.2f, want %.2f", state.BetAmount, initialBetAmount*2)
+			if state.LastOutcome != OutcomeLoss {
+				t.Errorf("LastOutcome should be loss: got %s, want %s", state.LastOutcome, OutcomeLoss)
 			}
-		})
-	}
-}
-
-func TestSimulatorStateProcessLoss_FibonacciStrategies(t *testing.T) {
-	tests := []struct {
-		name     string
-		strategy StrategyType
-		betType  puntobanco.BetType
-	}{
-		{
-			name:     "Fibonacci on Punto",
-			strategy: FibonacciOnPunto,
-			betType:  puntobanco.PuntoPlayer,
-		},
-		{
-			name:     "Fibonacci on Banco",
-			strategy: FibonacciOnBanco,
-			betType:  puntobanco.BancoBanker,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			state := NewSimulatorState()
-			initialLossStreak := state.LossStreak
-			initialFibonacciIndex := state.FibonacciSequenceIndex
-			initialFibonacciProfit := state.FibonacciProfit
-
-			state.BettingOn = tt.betType
-			state.ProcessLoss(tt.strategy)
-
-			if state.LossStreak != initialLossStreak+1 {
-				t.Errorf("LossStreak should incremente: got %d, want %d", state.LossStreak, initialLossStreak+1)
-			}
-			if state.WinsStreak != 0 {
-				t.Errorf("WinsStreak should reset: got %d, want 0", state.WinsStreak)
+			if state.CurrentBankroll != bankrollAfterBet {
+				t.Errorf("lost bet should not be returned: got %.2f, want %.2f", state.CurrentBankroll, bankrollAfterBet)
 			}
-			if state.FibonacciSequenceIndex != initialFibonacciIndex+1 {
-				t.Errorf("Fibonacci sequence index should incremente: got %d, want %d", state.FibonacciSequenceIndex, initialFibonacciIndex+1)
-			}
-
-			expectedLossUnits := state.BetAmount / MinimumBet
-			expectedProfit := initialFibonacciProfit - expectedLossUnits
-			if state.FibonacciProfit != expectedProfit {
-				t.Errorf("Fibonacci profit should decrease: got %.2f, want %.2f", state.FibonacciProfit, expectedProfit)
-			}
-
-			expectedBetAmount := float64(GetFibonacciValue(state.FibonacciSequenceIndex)) * MinimumBet
-			if state.BetAmount != expectedBetAmount {
-				t.Errorf("BetAmount should update: got %.2f, want %.2f", state.BetAmount, expectedBetAmount)
+			if state.BetAmount != initialBetAmount {
+				t.Errorf("BetAmount should not change: got %.2f, want %.2f", state.BetAmount, initialBetAmount)
 			}
 		})
 	}
 }
 
-func TestSimulatorStateProcessLoss_ParoliStrategies(t *testing.T) {
-	tests := []struct {
-		name     string
-		strategy StrategyType
-		betType  puntobanco.BetType
-	}{
-		{
-			name:     "Paroli on Punto",
-			strategy: ParoliOnPunto,
-			betType:  puntobanco.PuntoPlayer,
-		},
-		{
-			name:     "Paroli on Banco",
-			strategy: ParoliOnBanco,
-			betType:  puntobanco.BancoBanker,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			state := NewSimulatorState()
-			initialLossStreak := state.LossStreak
+func TestSimulatorStateProcessLoss_AfterWinsStreak(t *testing.T) {
+	state := NewSimulatorState(DefaultSettings())
 
-			// Precondition of Paroli progression
-			state.IsInParoliProgression = true
-			state.ParoliProgressionLevel = 2
-			state.BetAmount = state.BaseBetAmount * 2
+	// Precondition of wins streak
+	state.WinsStreak = 4
+	state.MaxWinsStreak = 4
+	state.MaxLossStreak = 2
 
-			state.BettingOn = tt.betType
-			state.ProcessLoss(tt.strategy)
+	state.ProcessLoss()
 
-			if state.LossStreak != initialLossStreak+1 {
-				t.Errorf("LossStreak should increment: got %d, want %d", state.LossStreak, initialLossStreak+1)
-			}
-			if state.WinsStreak != 0 {
-				t.Errorf("WinsStreak should reset: got %d, want 0", state.WinsStreak)
-			}
-			if state.IsInParoliProgression {
-				t.Error("Paroli progression should reset")
-			}
-			if state.ParoliProgressionLevel != 0 {
-				t.Errorf("Paroli progression level should reset: got %d, want 0", state.ParoliProgressionLevel)
-			}
-			if state.BetAmount != state.BaseBetAmount {
-				t.Errorf("BetAmount should reset to base bet: got %.2f, want %.2f", state.BetAmount, state.BaseBetAmount)
-			}
-		})
+	if state.WinsStreak != 0 {
+		t.Errorf("WinsStreak should reset: got %d, want 0", state.WinsStreak)
 	}
-}
-
-func TestSimulatorStateProcessLoss_DAlembertStrategies(t *testing.T) {
-	tests := []struct {
-		name     string
-		strategy StrategyType
-		betType  puntobanco.BetType
-	}{
-		{
-			name:     "D'Alembert on Punto",
-			strategy: DAlembertOnPunto,
-			betType:  puntobanco.PuntoPlayer,
-		},
-		{
-			name:     "D'Alembert on Banco",
-			strategy: DAlembertOnBanco,
-			betType:  puntobanco.BancoBanker,
-		},
+	if state.MaxWinsStreak != 4 {
+		t.Errorf("MaxWinsStreak should not change: got %d, want 4", state.MaxWinsStreak)
 	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			state := NewSimulatorState()
-			initialLossStreak := state.LossStreak
-			initialDAlembertLevel := state.DAlembertLevel
-
-			state.BettingOn = tt.betType
-			state.ProcessLoss(tt.strategy)
-
-			if state.LossStreak != initialLossStreak+1 {
-				t.Errorf("LossStreak should increment: got %d, want %d", state.LossStreak, initialLossStreak+1)
-			}
-			if state.WinsStreak != 0 {
-				t.Errorf("WinsStreak should reset: got %d, want 0", state.WinsStreak)
-			}
-			if state.DAlembertLevel != initialDAlembertLevel+1 {
-				t.Errorf("D'Alembert level should increment: got %d, want %d", state.DAlembertLevel, initialDAlembertLevel+1)
-			}
-
-			expectedBetAmount := state.DAlembertUnitSize + (float64(state.DAlembertLevel) * state.DAlembertUnitSize)
-			if state.BetAmount != expectedBetAmount {
-				t.Errorf("BetAmount should update: got %.2f, want %.2f", state.BetAmount, expectedBetAmount)
-			}
-		})
+	if state.LossStreak != 1 {
+		t.Errorf("LossStreak should increment: got %d, want 1", state.LossStreak)
 	}
-}
-
-func TestSimulatorStateProcessLoss_OneThreeTwoSixStrategies(t *testing.T) {
-	tests := []struct {
-		name     string
-		strategy StrategyType
-		betType  puntobanco.BetType
-	}{
-		{
-			name:     "1-3-2-6 on Punto",
-			strategy: OneThreeTwoSixOnPunto,
-			betType:  puntobanco.PuntoPlayer,
-		},
-		{
-			name:     "1-3-2-6 on Banco",
-			strategy: OneThreeTwoSixOnBanco,
-			betType:  puntobanco.BancoBanker,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			t.Run("Loss from initial position (1 unit)", func(t *testing.T) {
-				state := NewSimulatorState()
-				initialLossStreak := state.LossStreak
-
-				state.BettingOn = tt.betType
-				state.ProcessLoss(tt.strategy)
-
-				if state.LossStreak != initialLossStreak+1 {
-					t.Errorf("LossStreak should increment: got %d, want %d", state.LossStreak, initialLossStreak+1)
-				}
-				if state.WinsStreak != 0 {
-					t.Errorf("WinsStreak should reset: got %d, want 0", state.WinsStreak)
-				}
-				if state.OneThreeTwoSixSequenceIndex != 0 {
-					t.Errorf("Sequence index should reset: got %d, want 0", state.OneThreeTwoSixSequenceIndex)
-				}
-				expectedBetAmount := float64(GetOneThreeTwoSixValue(0)) * MinimumBet
-				if state.BetAmount != expectedBetAmount {
-					t.Errorf("BetAmount should reset: got %.2f, want %.2f", state.BetAmount, expectedBetAmount)
-				}
-			})
-
-			t.Run("Loss from non first position", func(t *testing.T) {
-				state := NewSimulatorState()
-
-				// Precondition of position 2 (2 units)
-				state.OneThreeTwoSixSequenceIndex = 2
-				state.BetAmount = float64(GetOneThreeTwoSixValue(2)) * MinimumBet
-
-				state.ProcessLoss(tt.strategy)
-
-				if state.OneThreeTwoSixSequenceIndex != 0 {
-					t.Errorf("Sequence index should reset: got %d, want 0", state.OneThreeTwoSixSequenceIndex)
-				}
-				expectedBetAmount := float64(GetOneThreeTwoSixValue(0)) * MinimumBet
-				if state.BetAmount != expectedBetAmount {
-					t.Errorf("BetAmount should reset: got %.2f, want %.2f", state.BetAmount, expectedBetAmount)
-				}
-			})
-		})
+	if state.MaxLossStreak != 2 {
+		t.Errorf("MaxLossStreak should not change: got %d, want 2", state.MaxLossStreak)
 	}
 }
@@ -0,0 +1,75 @@
+package simulator
+
+import (
+	"testing"
+
+	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
+)
+
+func TestSimulatorStateProcessPush_FlatBettingStrategies(t *testing.T) {
+	tests := []struct {
+		name    string
+		betType puntobanco.BetType
+	}{
+		{
+			name:    "Bet on Punto",
+			betType: puntobanco.PuntoPlayer,
+		},
+		{
+			name:    "Bet on Banco",
+			betType: puntobanco.BancoBanker,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := NewSimulatorState(DefaultSettings())
+			startingBankroll := state.CurrentBankroll
+			initialBetAmount := state.BetAmount
+
+			state.BettingOn = tt.betType
+			state.PlaceBet()
+			state.ProcessPush()
+
+			if state.Pushes != 1 {
+				t.Errorf("Pushes should increment: got %d, want 1", state.Pushes)
+			}
+			if state.Wins != 0 {
+				t.Errorf("Wins should not change: got %d, want 0", state.Wins)
+			}
+			if state.LastOutcome != OutcomePush {
+				t.Errorf("LastOutcome should be push: got %s, want %s", state.LastOutcome, OutcomePush)
+			}
+			if state.CurrentBankroll != startingBankroll {
+				t.Errorf("bet should be returned: got %.2f, want %.2f", state.CurrentBankroll, startingBankroll)
+			}
+			if state.MaxBankrollReached != startingBankroll {
+				t.Errorf("MaxBankrollReached should not change: got %.2f, want %.2f", state.MaxBankrollReached, startingBankroll)
+			}
+			if state.BetAmount != initialBetAmount {
+				t.Errorf("BetAmount should not change: got %.2f, want %.2f", state.BetAmount, initialBetAmount)
+			}
+		})
+	}
+}
+
+func TestSimulatorStateProcessPush_Streaks(t *testing.T) {
+	state := NewSimulatorState(DefaultSettings())
+
+	// Precondition of wins streak
+	state.WinsStreak = 2
+	state.MaxWinsStreak = 2
+	state.MaxLossStreak = 3
+
+	state.ProcessPush()
+
+	if state.WinsStreak != 2 {
+		t.Errorf("WinsStreak should not change: got %d, want 2", state.WinsStreak)
+	}
+	if state.LossStreak != 0 {
+		t.Errorf("LossStreak should not change: got %d, want 0", state.LossStreak)
+	}
+	if state.MaxWinsStreak != 2 || state.MaxLossStreak != 3 {
+		t.Errorf("max streaks should not change: got %d and %d, want 2 and 3", state.MaxWinsStreak, state.MaxLossStreak)
+	}
+}
@@ -1,73 +1,86 @@
 package simulator
 
 import (
+	"reflect"
 	"testing"
 
+	"github.com/adequatica/punto-banco-golango/internal/deck"
 	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
+	"github.com/adequatica/punto-banco-golango/internal/rules"
 )
 
 func TestNewSimulatorState(t *testing.T) {
-	newState := NewSimulatorState()
+	newState := NewSimulatorState(DefaultSettings())
 
 	if newState == nil {
 		t.Error("new simulator state should not be nil")
 	}
 }
 
-func TestGetFibonacciValue(t *testing.T) {
-	tests := []struct {
-		index int
-		want  int
-		name  string
-	}{
-		{0, 1, "Fibonacci(0) should be 1"},
-		{1, 1, "Fibonacci(1) should be 1"},
-		{2, 2, "Fibonacci(2) should be 2"},
-		{3, 3, "Fibonacci(3) should be 3"},
-		{4, 5, "Fibonacci(4) should be 5"},
-		{5, 8, "Fibonacci(5) should be 8"},
-		{6, 13, "Fibonacci(6) should be 13"},
-		{7, 21, "Fibonacci(7) should be 21"},
-		{8, 34, "Fibonacci(8) should be 34"},
-		{9, 55, "Fibonacci(9) should be 55"},
-		{10, 89, "Fibonacci(10) should be 89"},
-		{11, 144, "Fibonacci(11) should be 144"},
-		{12, 233, "Fibonacci(12) should be 233"},
-		{13, 377, "Fibonacci(13) should be 377"},
-		{14, 610, "Fibonacci(14) should be 610"},
-		{15, 987, "Fibonacci(15) should be 987"},
-		{-1, 1, "Fibonacci(-1) should be 1 (handles negative)"},
+func TestSimulatorStateHistory(t *testing.T) {
+	state := NewSimulatorState(DefaultSettings())
+	state.LastWinningHand = puntobanco.BancoBanker
+	state.RoundsPlayed = 7
+	state.RoundsObserved = 12
+	state.CurrentBankroll = 950.0
+
+	state.Results = road("PBB")
+
+	want := History{
+		LastWinningHand: puntobanco.BancoBanker,
+		RoundsPlayed:    7,
+		RoundsObserved:  12,
+		CurrentBankroll: 950.0,
+		Results:         road("PBB"),
 	}
+	if result := state.History(); !reflect.DeepEqual(result, want) {
+		t.Errorf("History() = %+v should be %+v", result, want)
+	}
+}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := GetFibonacciValue(tt.index)
-			if result != tt.want {
-				t.Errorf("GetFibonacciValue(%d) = %d should be %d", tt.index, result, tt.want)
-			}
-		})
+func TestSimulatorStateRecordResult(t *testing.T) {
+	state := NewSimulatorState(DefaultSettings())
+
+	for range MaxResultsHistory {
+		state.RecordResult(puntobanco.PuntoPlayer)
+	}
+	state.RecordResult(puntobanco.EgaliteTie)
+	state.RecordResult(puntobanco.BancoBanker)
+
+	if len(state.Results) != MaxResultsHistory {
+		t.Fatalf("results history should be bounded: got %d, want %d", len(state.Results), MaxResultsHistory)
+	}
+	if state.Results[MaxResultsHistory-2] != puntobanco.EgaliteTie || state.Results[MaxResultsHistory-1] != puntobanco.BancoBanker {
+		t.Errorf("results history should end with the last results, got %v", state.Results[MaxResultsHistory-2:])
+	}
+	if state.LastWinningHand != puntobanco.BancoBanker {
+		t.Errorf("LastWinningHand = %v should be %v", state.LastWinningHand, puntobanco.BancoBanker)
 	}
 }
 
-func TestGetOneThreeTwoSixValue(t *testing.T) {
+func TestDetermineOutcome(t *testing.T) {
 	tests := []struct {
-		index int
-		want  int
-		name  string
+		name      string
+		bettingOn puntobanco.BetType
+		result    puntobanco.BetType
+		want      Outcome
 	}{
-		{0, 1, "1-3-2-6(0) should be 1"},
-		{1, 3, "1-3-2-6(1) should be 3"},
-		{2, 2, "1-3-2-6(2) should be 2"},
-		{3, 6, "1-3-2-6(3) should be 6"},
-		{4, 1, "1-3-2-6(4) should be 1 (out of bounds)"},
-		{-1, 1, "1-3-2-6(-1) should be 1 (handles negative)"},
+		{"Punto bet wins on Punto", puntobanco.PuntoPlayer, puntobanco.PuntoPlayer, OutcomeWin},
+		{"Punto bet loses on Banco", puntobanco.PuntoPlayer, puntobanco.BancoBanker, OutcomeLoss},
+		{"Punto bet pushes on Égalité", puntobanco.PuntoPlayer, puntobanco.EgaliteTie, OutcomePush},
+		{"Banco bet wins on Banco", puntobanco.BancoBanker, puntobanco.BancoBanker, OutcomeWin},
+		{"Banco bet loses on Punto", puntobanco.BancoBanker, puntobanco.PuntoPlayer, OutcomeLoss},
+		{"Banco bet pushes on Égalité", puntobanco.BancoBanker, puntobanco.EgaliteTie, OutcomePush},
+		{"Égalité bet wins on Égalité", puntobanco.EgaliteTie, puntobanco.EgaliteTie, OutcomeWin},
+		{"Égalité bet loses on Punto", puntobanco.EgaliteTie, puntobanco.PuntoPlayer, OutcomeLoss},
+		{"Égalité bet loses on Banco", puntobanco.EgaliteTie, puntobanco.BancoBanker, OutcomeLoss},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := GetOneThreeTwoSixValue(tt.index)
+			result := DetermineOutcome(tt.bettingOn, tt.result)
 			if result != tt.want {
-				t.Errorf("GetOneThreeTwoSixValue(%d) = %d should be %d", tt.index, result, tt.want)
+				t.Errorf("DetermineOutcome(%v, %v) = %v should be %v", tt.bettingOn, tt.result, result, tt.want)
 			}
 		})
 	}
@@ -108,7 +121,7 @@ func TestCalculatePayout(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := CalculatePayout(tt.betType, tt.betAmount)
+			result := CalculatePayout(tt.betType, tt.betAmount, rules.DefaultTableRules())
 			if result != tt.want {
 				t.Errorf("calculatePayout(%v, %.2f) = %.2f, want %.2f",
 					tt.betType, tt.betAmount, result, tt.want)
@@ -117,6 +130,43 @@ func TestCalculatePayout(t *testing.T) {
 	}
 }
 
+func TestCalculatePayout_TableRules(t *testing.T) {
+	tableRules := rules.DefaultTableRules()
+	tableRules.BancoCommission = 0.04
+	tableRules.TiePayout = 9.0
+
+	tests := []struct {
+		name    string
+		betType puntobanco.BetType
+		want    float64
+	}{
+		{
+			name:    "Punto Player bet is not affected",
+			betType: puntobanco.PuntoPlayer,
+			want:    100.0,
+		},
+		{
+			name:    "Banco Banker bet - 4% commission",
+			betType: puntobanco.BancoBanker,
+			want:    96.0,
+		},
+		{
+			name:    "Egalite Tie bet - 9:1 payout",
+			betType: puntobanco.EgaliteTie,
+			want:    900.0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := CalculatePayout(tt.betType, 100.0, tableRules)
+			if result != tt.want {
+				t.Errorf("calculatePayout(%v, 100.00) = %.2f, want %.2f", tt.betType, result, tt.want)
+			}
+		})
+	}
+}
+
 func TestSimulatorStateCanPlaceBet(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -159,6 +209,7 @@ func TestSimulatorStateCanPlaceBet(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			state := &SimulatorState{
+				Settings:        DefaultSettings(),
 				CurrentBankroll: tt.currentBankroll,
 				BetAmount:       tt.betAmount,
 			}
@@ -194,6 +245,7 @@ func TestSimulatorStatePlaceBet(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			state := &SimulatorState{
+				Settings:        DefaultSettings(),
 				CurrentBankroll: tt.startingBankroll,
 				BetAmount:       tt.betAmount,
 			}
@@ -205,6 +257,186 @@ func TestSimulatorStatePlaceBet(t *testing.T) {
 				t.Errorf("PlaceBet() changed bankroll from %.2f to %.2f, want %.2f",
 					startingBankroll, state.CurrentBankroll, tt.wantBankroll)
 			}
+			if state.TotalWagered != tt.betAmount || state.WageredByBet[state.BettingOn] != tt.betAmount {
+				t.Errorf("PlaceBet() should add the bet of %.2f to the wagered amounts, got %.2f", tt.betAmount, state.TotalWagered)
+			}
+		})
+	}
+}
+
+func TestSimulatorStateRealisedHouseEdge(t *testing.T) {
+	tests := []struct {
+		name     string
+		bankroll float64
+		wagered  float64
+		wantNet  float64
+		wantEdge float64
+	}{
+		{name: "no bets", bankroll: 1000, wantNet: 0, wantEdge: 0},
+		{name: "lost game", bankroll: 990, wagered: 500, wantNet: -10, wantEdge: 2},
+		{name: "won game", bankroll: 1025, wagered: 250, wantNet: 25, wantEdge: -10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := NewSimulatorState(DefaultSettings())
+			state.CurrentBankroll = tt.bankroll
+			state.TotalWagered = tt.wagered
+
+			if state.NetResult() != tt.wantNet {
+				t.Errorf("NetResult() = %.2f should be %.2f", state.NetResult(), tt.wantNet)
+			}
+			if state.RealisedHouseEdge() != tt.wantEdge {
+				t.Errorf("RealisedHouseEdge() = %.2f should be %.2f", state.RealisedHouseEdge(), tt.wantEdge)
+			}
+		})
+	}
+}
+
+func TestSimulatorStateSessionExitReason(t *testing.T) {
+	tests := []struct {
+		name            string
+		modify          func(s *Settings)
+		currentBankroll float64
+		roundsPlayed    int
+		want            ExitReason
+		wantOk          bool
+	}{
+		{
+			name:            "no session limits",
+			modify:          func(s *Settings) {},
+			currentBankroll: 5000.0,
+			roundsPlayed:    10000,
+			wantOk:          false,
+		},
+		{
+			name:            "profit reaches the stop-win amount",
+			modify:          func(s *Settings) { s.StopWin = SessionLimit{Amount: 200} },
+			currentBankroll: 1200.0,
+			want:            ExitGoalHit,
+			wantOk:          true,
+		},
+		{
+			name:            "profit below the stop-win percentage",
+			modify:          func(s *Settings) { s.StopWin = SessionLimit{Amount: 20, IsPercent: true} },
+			currentBankroll: 1190.0,
+			wantOk:          false,
+		},
+		{
+			name:            "loss reaches the stop-loss percentage",
+			modify:          func(s *Settings) { s.StopLoss = SessionLimit{Amount: 50, IsPercent: true} },
+			currentBankroll: 500.0,
+			want:            ExitLossLimit,
+			wantOk:          true,
+		},
+		{
+			name:            "loss below the stop-loss amount",
+			modify:          func(s *Settings) { s.StopLoss = SessionLimit{Amount: 500} },
+			currentBankroll: 510.0,
+			wantOk:          false,
+		},
+		{
+			name:            "maximum number of rounds is played",
+			modify:          func(s *Settings) { s.MaxRounds = 100 },
+			currentBankroll: 1000.0,
+			roundsPlayed:    100,
+			want:            ExitRoundCap,
+			wantOk:          true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := DefaultSettings()
+			tt.modify(&settings)
+			state := NewSimulatorState(settings)
+			state.CurrentBankroll = tt.currentBankroll
+			state.RoundsPlayed = tt.roundsPlayed
+
+			result, ok := state.SessionExitReason()
+			if ok != tt.wantOk || result != tt.want {
+				t.Errorf("SessionExitReason() = %q, %v should be %q, %v", result, ok, tt.want, tt.wantOk)
+			}
 		})
 	}
 }
+
+func TestSimulatorStateBetExitReason(t *testing.T) {
+	state := NewSimulatorState(DefaultSettings())
+
+	state.CurrentBankroll = 5.0
+	if result := state.BetExitReason(); result != ExitBusted {
+		t.Errorf("BetExitReason() = %q should be %q when bankroll is below the minimum bet", result, ExitBusted)
+	}
+
+	state.CurrentBankroll = 70.0
+	state.BetAmount = 80.0
+	if result := state.BetExitReason(); result != ExitBetExceedsBankroll {
+		t.Errorf("BetExitReason() = %q should be %q", result, ExitBetExceedsBankroll)
+	}
+}
+
+func TestSimulatorStateProcessNoBet(t *testing.T) {
+	state := NewSimulatorState(DefaultSettings())
+	state.ProcessLoss()
+	state.BetAmount = 0
+	state.RoundsPlayed = 1
+	state.RoundsObserved = 3
+
+	state.ProcessNoBet()
+
+	if !state.IsSittingOut() {
+		t.Error("state with a zero bet should sit the coup out")
+	}
+	if state.LastOutcome != OutcomeNoBet {
+		t.Errorf("LastOutcome = %q should be %q", state.LastOutcome, OutcomeNoBet)
+	}
+	if state.CurrentBankroll != state.Settings.Bankroll || state.LossStreak != 1 {
+		t.Errorf("sat out coup should not change the bankroll or the streaks, got %.2f and loss streak %d", state.CurrentBankroll, state.LossStreak)
+	}
+	if result := state.RoundsSatOut(); result != 2 {
+		t.Errorf("RoundsSatOut() = %d should be 2", result)
+	}
+}
+
+func TestSimulatorStateSideBets(t *testing.T) {
+	state := NewSimulatorState(DefaultSettings())
+	state.CurrentBankroll = 25.0
+
+	// The last side bet is over the bankroll left after the others
+	state.PlaceSideBets([]SideBet{
+		{Type: puntobanco.PuntoPair, Amount: 10.0},
+		{Type: puntobanco.PerfectPair, Amount: 10.0},
+		{Type: puntobanco.EitherPair, Amount: 10.0},
+	})
+
+	if len(state.SideBets) != 2 || state.SideBetsPlaced != 2 {
+		t.Fatalf("side bets covered by the bankroll should be placed, got %+v", state.SideBets)
+	}
+	if state.CurrentBankroll != 5.0 || state.TotalWagered != 20.0 || state.SideBetsWagered != 20.0 {
+		t.Errorf("placed side bets should be wagered, got bankroll %.2f and wagered %.2f", state.CurrentBankroll, state.TotalWagered)
+	}
+	if state.WageredBySideBet[puntobanco.PuntoPair] != 10.0 || state.WageredBySideBet[puntobanco.EitherPair] != 0 {
+		t.Errorf("side bets should be wagered by type, got %v", state.WageredBySideBet)
+	}
+
+	gameResult := &puntobanco.GameResultState{
+		PuntoState: &puntobanco.PlayerState{
+			FirstCard:  &deck.Card{Card: "2", Value: 2, Suit: "Hearts"},
+			SecondCard: &deck.Card{Card: "2", Value: 2, Suit: "Clubs"},
+		},
+		BancoState: &puntobanco.PlayerState{
+			FirstCard:  &deck.Card{Card: "A", Value: 1, Suit: "Clubs"},
+			SecondCard: &deck.Card{Card: "Q", Value: 0, Suit: "Clubs"},
+		},
+	}
+	state.ProcessSideBets(gameResult)
+
+	// Punto pair pays 11 to 1 and returns the bet, Perfect pair is lost
+	if state.CurrentBankroll != 125.0 || state.TotalPaidOut != 110.0 || state.SideBetWins != 1 {
+		t.Errorf("won side bet should be paid, got bankroll %.2f and paid out %.2f", state.CurrentBankroll, state.TotalPaidOut)
+	}
+	if state.MaxBankrollReached != 1000.0 {
+		t.Errorf("MaxBankrollReached = %.2f should stay at the starting bankroll", state.MaxBankrollReached)
+	}
+}
@@ -0,0 +1,116 @@
+package simulator
+
+import (
+	"maps"
+	"slices"
+)
+
+// The curve is kept at no more than this number of rounds
+const maxSurvivalPoints = 100
+
+// Share of games still alive after a round in percent, and the number of games that have played this many rounds
+type SurvivalPoint struct {
+	Round    int     `json:"round"`
+	Survival float64 `json:"survival"`
+	AtRisk   int     `json:"atRisk"`
+}
+
+// Risk of ruin of a strategy, ruin is the end of a game when the bankroll cannot cover the next bet,
+// either busted or not enough for the bet of the progression.
+// Games that end for other reasons, e.g. at stop-win or at round cap, are censored:
+// they count as alive until they end, and nothing is known about them after that (Kaplan–Meier estimator).
+type SurvivalAnalysis struct {
+	Curve []SurvivalPoint `json:"curve"`
+	// Zero when at least half of games survive until the end
+	MedianRoundsToRuin   int                `json:"medianRoundsToRuin"`
+	GamesRuined          int                `json:"gamesRuined"`
+	GamesDoubled         int                `json:"gamesDoubled"`
+	DoublingRate         float64            `json:"doublingRate"`
+	DoublingRateInterval ConfidenceInterval `json:"doublingRateInterval"`
+}
+
+// Numbers of ruined and censored games by the number of rounds they have played
+type survivalAccumulator struct {
+	games    int
+	ruined   map[int]int
+	censored map[int]int
+	doubled  int
+}
+
+func newSurvivalAccumulator() *survivalAccumulator {
+	return &survivalAccumulator{
+		ruined:   make(map[int]int),
+		censored: make(map[int]int),
+	}
+}
+
+func (a *survivalAccumulator) add(state *SimulatorState) {
+	a.games++
+	if state.ExitReason == ExitBusted || state.ExitReason == ExitBetExceedsBankroll {
+		a.ruined[state.RoundsToBust]++
+	} else {
+		a.censored[state.RoundsPlayed]++
+	}
+	// A ruined game cannot double the bankroll after the ruin
+	if state.DoubledAtRound > 0 {
+		a.doubled++
+	}
+}
+
+func (a *survivalAccumulator) result() SurvivalAnalysis {
+	analysis := SurvivalAnalysis{GamesDoubled: a.doubled}
+	if a.games == 0 {
+		return analysis
+	}
+	analysis.DoublingRate = float64(a.doubled) / float64(a.games) * 100
+	analysis.DoublingRateInterval = wilsonInterval(a.doubled, a.games)
+
+	rounds := slices.Collect(maps.Keys(a.ruined))
+	for round := range a.censored {
+		if _, ok := a.ruined[round]; !ok {
+			rounds = append(rounds, round)
+		}
+	}
+	slices.Sort(rounds)
+
+	// Survival after each round with ended games, and the number of games at risk in the round
+	survivals := make([]float64, len(rounds))
+	atRisks := make([]int, len(rounds))
+	survival := 1.0
+	atRisk := a.games
+	for i, round := range rounds {
+		if ruined := a.ruined[round]; ruined > 0 {
+			survival *= 1 - float64(ruined)/float64(atRisk)
+			analysis.GamesRuined += ruined
+			if analysis.MedianRoundsToRuin == 0 && survival <= 0.5 {
+				analysis.MedianRoundsToRuin = round
+			}
+		}
+		survivals[i] = survival
+		atRisks[i] = atRisk
+		atRisk -= a.ruined[round] + a.censored[round]
+	}
+
+	// Rounds of the curve are spread evenly up to the longest game
+	lastRound := rounds[len(rounds)-1]
+	stride := max(1, (lastRound+maxSurvivalPoints-2)/(maxSurvivalPoints-1))
+	i := 0 // Index of the first round with ended games at or after the round of the point
+	for round := 0; ; round = min(round+stride, lastRound) {
+		for rounds[i] < round {
+			i++
+		}
+		point := SurvivalPoint{Round: round, Survival: 100, AtRisk: atRisks[i]}
+		if rounds[i] == round {
+			point.Survival = survivals[i] * 100
+		} else if i > 0 {
+			point.Survival = survivals[i-1] * 100
+		}
+		analysis.Curve = append(analysis.Curve, point)
+
+		if round == lastRound {
+			break
+		}
+	}
+
+	return analysis
+}
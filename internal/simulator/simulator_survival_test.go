@@ -0,0 +1,100 @@
+package simulator
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+func TestSurvivalAccumulator(t *testing.T) {
+	a := newSurvivalAccumulator()
+	games := []*SimulatorState{
+		{ExitReason: ExitBusted, RoundsPlayed: 2, RoundsToBust: 2},
+		{ExitReason: ExitGoalHit, RoundsPlayed: 3, DoubledAtRound: 3},
+		{ExitReason: ExitBetExceedsBankroll, RoundsPlayed: 5, RoundsToBust: 5},
+		{ExitReason: ExitBusted, RoundsPlayed: 5, RoundsToBust: 5, DoubledAtRound: 4},
+		{ExitReason: ExitRoundCap, RoundsPlayed: 8},
+	}
+	for _, state := range games {
+		a.add(state)
+	}
+
+	got := a.result()
+	// Kaplan–Meier: 4 of 5 games survive round 2, 1 of 3 games at risk survives round 5
+	want := []SurvivalPoint{
+		{Round: 0, Survival: 100, AtRisk: 5},
+		{Round: 1, Survival: 100, AtRisk: 5},
+		{Round: 2, Survival: 80, AtRisk: 5},
+		{Round: 3, Survival: 80, AtRisk: 4},
+		{Round: 4, Survival: 80, AtRisk: 3},
+		{Round: 5, Survival: 80.0 / 3, AtRisk: 3},
+		{Round: 6, Survival: 80.0 / 3, AtRisk: 1},
+		{Round: 7, Survival: 80.0 / 3, AtRisk: 1},
+		{Round: 8, Survival: 80.0 / 3, AtRisk: 1},
+	}
+	if len(got.Curve) != len(want) {
+		t.Fatalf("curve should have %d points, got %+v", len(want), got.Curve)
+	}
+	for i, point := range got.Curve {
+		if point.Round != want[i].Round || point.AtRisk != want[i].AtRisk || math.Abs(point.Survival-want[i].Survival) > 1e-9 {
+			t.Errorf("point %d = %+v should be %+v", i, point, want[i])
+		}
+	}
+
+	if got.MedianRoundsToRuin != 5 {
+		t.Errorf("MedianRoundsToRuin = %d should be 5", got.MedianRoundsToRuin)
+	}
+	if got.GamesRuined != 3 || got.GamesDoubled != 2 || got.DoublingRate != 40 {
+		t.Errorf("3 games should be ruined and 2 of 5 games should double, got %+v", got)
+	}
+}
+
+func TestSurvivalAccumulator_NoRuin(t *testing.T) {
+	a := newSurvivalAccumulator()
+	for i := 0; i < 3; i++ {
+		a.add(&SimulatorState{ExitReason: ExitRoundCap, RoundsPlayed: 1000})
+	}
+
+	got := a.result()
+	if got.MedianRoundsToRuin != 0 || got.GamesRuined != 0 {
+		t.Errorf("games without ruin should have no median rounds to ruin, got %+v", got)
+	}
+	if len(got.Curve) > maxSurvivalPoints {
+		t.Errorf("curve should have no more than %d points, got %d", maxSurvivalPoints, len(got.Curve))
+	}
+	last := got.Curve[len(got.Curve)-1]
+	if last.Round != 1000 || last.Survival != 100 || last.AtRisk != 3 {
+		t.Errorf("curve should end at the longest game with every game alive, got %+v", last)
+	}
+
+	if got := newSurvivalAccumulator().result(); got.Curve != nil {
+		t.Errorf("survival of no games should be empty, got %+v", got)
+	}
+}
+
+func TestRunMultipleSimulations_Survival(t *testing.T) {
+	settings := DefaultSettings()
+	settings.MaxRounds = 200
+
+	stats, err := RunMultipleSimulations(context.Background(), MartingaleOnBanco, settings, 200, false, 42, nil)
+	if err != nil {
+		t.Fatalf("RunMultipleSimulations() error: %v", err)
+	}
+
+	survival := stats.Survival
+	if ruined := stats.GamesBusted + stats.GamesWithBetExceedingBankroll; survival.GamesRuined != ruined {
+		t.Errorf("GamesRuined = %d should be the number of games without money for the next bet %d", survival.GamesRuined, ruined)
+	}
+	if survival.Curve[0].Survival != 100 || survival.Curve[0].AtRisk != stats.TotalSimulations {
+		t.Errorf("every game should be alive before the first round, got %+v", survival.Curve[0])
+	}
+	for i := 1; i < len(survival.Curve); i++ {
+		if survival.Curve[i].Survival > survival.Curve[i-1].Survival {
+			t.Errorf("survival should not increase, got %+v after %+v", survival.Curve[i], survival.Curve[i-1])
+		}
+	}
+	// Peak bankroll of a doubled game is at least double the bankroll
+	if survival.GamesDoubled > stats.GamesWithProfitableBankroll {
+		t.Errorf("GamesDoubled = %d should not exceed profitable games %d", survival.GamesDoubled, stats.GamesWithProfitableBankroll)
+	}
+}
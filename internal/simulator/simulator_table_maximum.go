@@ -0,0 +1,75 @@
+package simulator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// What a strategy does when the next step of its progression exceeds the table maximum
+type TableMaximumPolicy string
+
+const (
+	// Bet the table maximum instead
+	TableMaximumCap TableMaximumPolicy = "cap"
+	// Start the progression over from the base bet
+	TableMaximumReset TableMaximumPolicy = "reset"
+	// Walk away from the table
+	TableMaximumStop TableMaximumPolicy = "stop"
+)
+
+func GetTableMaximumPolicies() []TableMaximumPolicy {
+	return []TableMaximumPolicy{TableMaximumCap, TableMaximumReset, TableMaximumStop}
+}
+
+func ParseTableMaximumPolicy(name string) (TableMaximumPolicy, error) {
+	for _, policy := range GetTableMaximumPolicies() {
+		if strings.EqualFold(strings.TrimSpace(name), string(policy)) {
+			return policy, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown table maximum policy %q, use cap, reset or stop", name)
+}
+
+// Parse the default policy and the policies of single strategies separated by commas,
+// e.g. "cap,martingale-on-banco=reset,paroli-on-punto=stop"
+func ParseTableMaximumPolicies(policies string) (TableMaximumPolicy, map[StrategyType]TableMaximumPolicy, error) {
+	defaultPolicy := TableMaximumCap
+	strategyPolicies := make(map[StrategyType]TableMaximumPolicy)
+
+	for _, entry := range strings.Split(policies, ",") {
+		name, policyName, isStrategyPolicy := strings.Cut(entry, "=")
+		if !isStrategyPolicy {
+			policy, err := ParseTableMaximumPolicy(entry)
+			if err != nil {
+				return "", nil, err
+			}
+			defaultPolicy = policy
+			continue
+		}
+
+		strategy, err := ParseStrategy(strings.TrimSpace(name))
+		if err != nil {
+			return "", nil, err
+		}
+		policy, err := ParseTableMaximumPolicy(policyName)
+		if err != nil {
+			return "", nil, err
+		}
+		strategyPolicies[strategy] = policy
+	}
+
+	return defaultPolicy, strategyPolicies, nil
+}
+
+// Policy of a strategy, the policy of the strategy overrides the default one
+func (s Settings) TableMaximumPolicyFor(strategy StrategyType) TableMaximumPolicy {
+	if policy, ok := s.StrategyTableMaximumPolicies[strategy]; ok {
+		return policy
+	}
+	return s.TableMaximumPolicy
+}
+
+func (s Settings) IsOverTableMaximum(betAmount float64) bool {
+	return s.Rules.MaximumBet > 0 && betAmount > s.Rules.MaximumBet+lineEpsilon
+}
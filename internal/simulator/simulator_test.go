@@ -1,251 +1,520 @@
 package simulator
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/adequatica/punto-banco-golango/internal/deck"
 	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
+	"github.com/adequatica/punto-banco-golango/internal/rules"
 )
 
-func TestGetStrategyOptions(t *testing.T) {
-	want := GetStrategyOptions()
+func TestRunSimulator(t *testing.T) {
+	strategy := NewFlatStrategy(puntobanco.PuntoPlayer, rules.DefaultMinimumBet)
+	result := RunSimulator(strategy, DefaultSettings(), nil, deck.NewShuffler(deck.NewRandomSeed()))
+	if result == nil {
+		t.Fatal("simulator's result should not be nil")
+	}
+	if result.RoundsPlayed == 0 {
+		t.Fatal("simulator should play at least one round")
+	}
+	if len(result.BankrollHistory) != result.RoundsPlayed+1 {
+		t.Errorf("bankroll history should have %d bankrolls, got %d", result.RoundsPlayed+1, len(result.BankrollHistory))
+	}
+	if result.BankrollHistory[0] != DefaultBankroll || result.BankrollHistory[len(result.BankrollHistory)-1] != result.CurrentBankroll {
+		t.Errorf("bankroll history should go from the bankroll to the final bankroll, got %v", result.BankrollHistory)
+	}
+}
+
+func TestRunSimulator_Seed(t *testing.T) {
+	var seed int64 = 42
 
-	if len(want) != 16 {
-		t.Errorf("Strategy options of length %d should be 16", len(want))
+	runGame := func(strategyType StrategyType) *SimulatorState {
+		shuffler := deck.NewShuffler(seed)
+		strategy, err := NewStrategy(strategyType, DefaultSettings(), shuffler)
+		if err != nil {
+			t.Fatalf("Failed to make strategy: %v", err)
+		}
+		return RunSimulator(strategy, DefaultSettings(), nil, shuffler)
 	}
 
-	if len(want) > 0 && want[0] != "Bet on Punto (player)" {
-		t.Errorf("First betting option of '%s' should be 'Bet on Punto (player)'", want[0])
+	for _, strategy := range []StrategyType{BetOnRandom, MartingaleOnBanco} {
+		t.Run(string(strategy), func(t *testing.T) {
+			first := runGame(strategy)
+			second := runGame(strategy)
+
+			if !reflect.DeepEqual(first, second) {
+				t.Errorf("games simulated with the same seed should be equal")
+			}
+		})
 	}
 }
 
-func TestGetRandomBetType(t *testing.T) {
-	result := GetRandomBetType()
+func TestRunSimulator_LineLength(t *testing.T) {
+	settings := DefaultSettings()
+	strategy := NewLabouchere(puntobanco.PuntoPlayer, settings.Rules.MinimumBet, settings.LabouchereLine)
+	dataCollector := NewDataCollector(LabouchereOnPunto, settings.Rules.NumberOfDecks, settings.Bankroll, settings.Rules.MinimumBet, 1, 42)
+
+	RunSimulator(strategy, settings, dataCollector, deck.NewShuffler(42))
 
-	if result != puntobanco.PuntoPlayer && result != puntobanco.BancoBanker {
-		t.Errorf("GetRandomBetType() returned unexpected value: %s", result)
+	hands := dataCollector.GetSimulationData().Games[0]
+	if hands[0].Bet.LineLength != len(settings.LabouchereLine) {
+		t.Errorf("line length of the first bet = %d should be %d", hands[0].Bet.LineLength, len(settings.LabouchereLine))
+	}
+	for _, hand := range hands {
+		if hand.Bet.LineLength < 1 {
+			t.Fatalf("hand %d should record the line length, got %d", hand.HandID, hand.Bet.LineLength)
+		}
 	}
 }
 
-func TestGetOnlyPuntoBanco(t *testing.T) {
+func TestRunSimulator_ExitReason(t *testing.T) {
 	tests := []struct {
-		name            string
-		lastWinningHand puntobanco.BetType
-		want            puntobanco.BetType
+		name     string
+		strategy StrategyType
+		modify   func(s *Settings)
+		want     ExitReason
 	}{
 		{
-			name:            "if last winning hand is PuntoPlayer, return PuntoPlayer",
-			lastWinningHand: puntobanco.PuntoPlayer,
-			want:            puntobanco.PuntoPlayer,
+			name:     "flat betting ends busted",
+			strategy: BetOnBanco,
+			modify:   func(s *Settings) {},
+			want:     ExitBusted,
 		},
 		{
-			name:            "if last winning hand is BancoBanker, return BancoBanker",
-			lastWinningHand: puntobanco.BancoBanker,
-			want:            puntobanco.BancoBanker,
+			name:     "round cap",
+			strategy: BetOnPunto,
+			modify:   func(s *Settings) { s.MaxRounds = 5 },
+			want:     ExitRoundCap,
 		},
 		{
-			name:            "if last winning hand is EgaliteTie, return BancoBanker",
-			lastWinningHand: puntobanco.EgaliteTie,
-			want:            puntobanco.BancoBanker,
+			name:     "stop-loss",
+			strategy: BetOnEgalite,
+			modify:   func(s *Settings) { s.StopLoss = SessionLimit{Amount: 5, IsPercent: true} },
+			want:     ExitLossLimit,
+		},
+		{
+			name:     "stop-win",
+			strategy: MartingaleOnPunto,
+			modify:   func(s *Settings) { s.StopWin = SessionLimit{Amount: 20} },
+			want:     ExitGoalHit,
+		},
+		{
+			name:     "shoe cap",
+			strategy: BetOnBanco,
+			modify:   func(s *Settings) { s.MaxShoes = 2 },
+			want:     ExitShoeCap,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := GetOnlyPuntoBanco(tt.lastWinningHand)
-			if got != tt.want {
-				t.Errorf("GetOnlyPuntoBanco(%v) = %v, want %v", tt.lastWinningHand, got, tt.want)
+			settings := DefaultSettings()
+			tt.modify(&settings)
+			shuffler := deck.NewShuffler(42)
+			strategy, err := NewStrategy(tt.strategy, settings, shuffler)
+			if err != nil {
+				t.Fatalf("Failed to make strategy: %v", err)
+			}
+
+			state := RunSimulator(strategy, settings, nil, shuffler)
+			if state.ExitReason != tt.want {
+				t.Errorf("ExitReason = %q should be %q", state.ExitReason, tt.want)
+			}
+			if tt.want == ExitRoundCap && state.RoundsPlayed != settings.MaxRounds {
+				t.Errorf("RoundsPlayed = %d should be %d", state.RoundsPlayed, settings.MaxRounds)
+			}
+			if tt.want == ExitShoeCap && state.ShoesPlayed != settings.MaxShoes {
+				t.Errorf("ShoesPlayed = %d should be %d", state.ShoesPlayed, settings.MaxShoes)
 			}
 		})
 	}
 }
 
-func TestMakeStrategy(t *testing.T) {
+// Counting strategy that records what the simulator shows it
+type observingStrategy struct {
+	CountingStrategy
+	shoes      []int
+	cardsDealt int
+}
+
+func (o *observingStrategy) OnNewShoe(cards int) {
+	o.shoes = append(o.shoes, cards)
+	o.CountingStrategy.OnNewShoe(cards)
+}
+
+func (o *observingStrategy) OnCardsDealt(cards []deck.Card) {
+	o.cardsDealt += len(cards)
+	o.CountingStrategy.OnCardsDealt(cards)
+}
+
+func TestRunSimulator_CardCounting(t *testing.T) {
+	settings := DefaultSettings()
+	strategy := &observingStrategy{CountingStrategy: *NewPuntoBancoCount(settings.Rules.MinimumBet)}
+	dataCollector := NewDataCollector(CardCountPB, settings.Rules.NumberOfDecks, settings.Bankroll, settings.Rules.MinimumBet, 1, 42)
+
+	state := RunSimulator(strategy, settings, dataCollector, deck.NewShuffler(42))
+
+	if state.ExitReason != ExitShoeCap || state.ShoesPlayed != DefaultCountingMaxShoes {
+		t.Errorf("game of a counting strategy should end at %d shoes, got %q after %d shoes", DefaultCountingMaxShoes, state.ExitReason, state.ShoesPlayed)
+	}
+	if len(strategy.shoes) != state.ShoesPlayed {
+		t.Errorf("strategy should see %d new shoes, got %d", state.ShoesPlayed, len(strategy.shoes))
+	}
+	// Every shoe has at least a few dozen coups, and most of them are sat out
+	if state.RoundsPlayed*4*10 > strategy.cardsDealt {
+		t.Errorf("strategy should sit out most coups, played %d rounds of %d dealt cards", state.RoundsPlayed, strategy.cardsDealt)
+	}
+	if len(state.BankrollHistory) != state.RoundsPlayed+1 {
+		t.Errorf("bankroll history should only have the played rounds, got %d for %d rounds", len(state.BankrollHistory), state.RoundsPlayed)
+	}
+
+	// Every dealt coup is saved, the sat out ones without a bet
+	hands := dataCollector.GetSimulationData().Games[0]
+	if len(hands) != state.RoundsObserved {
+		t.Fatalf("data should have %d observed rounds, got %d hands", state.RoundsObserved, len(hands))
+	}
+	satOut := 0
+	for _, hand := range hands {
+		if hand.Bet == (BetData{}) {
+			satOut++
+		}
+	}
+	if satOut != state.RoundsSatOut() || satOut == 0 {
+		t.Errorf("data should have %d sat out hands, got %d", state.RoundsSatOut(), satOut)
+	}
+	if last := hands[len(hands)-1]; last.ShoeNumber != state.ShoesPlayed {
+		t.Errorf("last hand should be dealt from shoe %d, got %d", state.ShoesPlayed, last.ShoeNumber)
+	}
+}
+
+// Strategy that never bets
+// Strategy which places a number of bets and sits out every coup after them
+type waitingStrategy struct {
+	bets int
+}
+
+func (w *waitingStrategy) NextBet(history History) (puntobanco.BetType, float64) {
+	if w.bets > 0 {
+		w.bets--
+		return puntobanco.BancoBanker, rules.DefaultMinimumBet
+	}
+	return puntobanco.BancoBanker, 0
+}
+
+func (w *waitingStrategy) OnResult(result BetResult) {}
+
+func (w *waitingStrategy) Reset() {}
+
+func TestRunSimulator_SittingOutForever(t *testing.T) {
 	tests := []struct {
-		name          string
-		strategy      StrategyType
-		state         *SimulatorState
-		wantBetType   puntobanco.BetType
-		wantBetAmount float64
+		name           string
+		bets           int
+		maxShoes       int
+		sitOutMaxShoes int
+		wantReason     ExitReason
+		wantShoes      int
+	}{
+		{"never bets", 0, 0, 3, ExitSitOutCap, 3},
+		{"bets in the first shoe", 1, 0, 3, ExitSitOutCap, 4},
+		{"shoe cap comes first", 0, 2, 3, ExitShoeCap, 2},
+		{"no sit-out cap", 0, 5, 0, ExitShoeCap, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := DefaultSettings()
+			settings.MaxShoes = tt.maxShoes
+			settings.SitOutMaxShoes = tt.sitOutMaxShoes
+
+			state := RunSimulator(&waitingStrategy{bets: tt.bets}, settings, nil, deck.NewShuffler(42))
+
+			if state.ExitReason != tt.wantReason || state.ShoesPlayed != tt.wantShoes {
+				t.Errorf("expected %q after %d shoes, got %q after %d shoes", tt.wantReason, tt.wantShoes, state.ExitReason, state.ShoesPlayed)
+			}
+			if state.RoundsPlayed != tt.bets || state.RoundsObserved <= tt.bets {
+				t.Errorf("game should have %d played rounds and more observed, got %d played of %d observed", tt.bets, state.RoundsPlayed, state.RoundsObserved)
+			}
+			if state.BettingOn != "" || state.BetAmount != 0 {
+				t.Errorf("skipped coup should leave no bet, got %q for %.2f", state.BettingOn, state.BetAmount)
+			}
+			if state.DealError != nil {
+				t.Errorf("unexpected deal error: %v", state.DealError)
+			}
+		})
+	}
+
+	// Gambler below the minimum bet is busted without waiting for the next bet
+	settings := DefaultSettings()
+	settings.Bankroll = settings.Rules.MinimumBet - 1
+	state := RunSimulator(&waitingStrategy{}, settings, nil, deck.NewShuffler(42))
+
+	if state.ExitReason != ExitBusted || state.RoundsObserved != 0 {
+		t.Errorf("game below the minimum bet should be busted before the first coup, got %q after %d rounds", state.ExitReason, state.RoundsObserved)
+	}
+}
+
+func TestRunSimulator_SideBets(t *testing.T) {
+	settings := DefaultSettings()
+	settings.Bankroll = 100000.0
+	settings.MaxRounds = 500
+	strategy := NewSideBetStrategy(NewFlatStrategy(puntobanco.BancoBanker, settings.Rules.MinimumBet),
+		SideBet{Type: puntobanco.PuntoPair, Amount: 5.0},
+		SideBet{Type: puntobanco.BancoPair, Amount: 5.0})
+	dataCollector := NewDataCollector(BancoWithEitherPair, settings.Rules.NumberOfDecks, settings.Bankroll, settings.Rules.MinimumBet, 1, 42)
+
+	state := RunSimulator(strategy, settings, dataCollector, deck.NewShuffler(42))
+
+	if state.SideBetsPlaced != 2*state.RoundsPlayed || state.SideBetsWagered != 10.0*float64(state.RoundsPlayed) {
+		t.Errorf("side bets should be placed with every bet, got %d side bets of %.2f in %d rounds", state.SideBetsPlaced, state.SideBetsWagered, state.RoundsPlayed)
+	}
+	if state.SideBetWins == 0 {
+		t.Error("some pairs should be dealt in 500 rounds")
+	}
+	if state.TotalWagered != state.WageredByBet[puntobanco.BancoBanker]+state.SideBetsWagered {
+		t.Errorf("TotalWagered = %.2f should include the side bets", state.TotalWagered)
+	}
+
+	// Bankroll follows the main bets and the side bets settled in the data
+	bankroll := settings.Bankroll
+	for _, hand := range dataCollector.GetSimulationData().Games[0] {
+		if len(hand.SideBets) != 2 {
+			t.Fatalf("hand %d should have 2 side bets, got %+v", hand.HandID, hand.SideBets)
+		}
+		bankroll -= hand.Bet.BetAmount
+		if hand.Bet.IsWin || hand.Bet.IsPush {
+			bankroll += hand.Bet.BetAmount + hand.Bet.Payout
+		}
+		for _, sideBet := range hand.SideBets {
+			bankroll -= sideBet.BetAmount
+			if sideBet.IsWin {
+				bankroll += sideBet.BetAmount + sideBet.Payout
+			}
+		}
+	}
+	if math.Abs(bankroll-state.CurrentBankroll) > 1e-6 {
+		t.Errorf("bankroll of the data %.2f should be the final bankroll %.2f", bankroll, state.CurrentBankroll)
+	}
+}
+
+// Strategy which bets the amounts one after another, then the last amount over and over
+type scriptedStrategy struct {
+	amounts []float64
+	bets    int
+}
+
+func (s *scriptedStrategy) NextBet(history History) (puntobanco.BetType, float64) {
+	amount := s.amounts[min(s.bets, len(s.amounts)-1)]
+	s.bets++
+	return puntobanco.BancoBanker, amount
+}
+
+func (s *scriptedStrategy) OnResult(result BetResult) {}
+
+func (s *scriptedStrategy) Reset() {}
+
+func TestRunSimulator_TableMaximumHits(t *testing.T) {
+	tests := []struct {
+		name     string
+		amounts  []float64
+		wantHits int
+	}{
+		{"within the maximum", []float64{10, 40, 20, 10}, 0},
+		{"capped progression counts once", []float64{10, 80, 160, 320, 10}, 1},
+		{"progression crossing twice", []float64{10, 80, 160, 20, 80, 10}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := DefaultSettings()
+			settings.Rules.MaximumBet = 40.0
+			settings.TableMaximumPolicy = TableMaximumCap
+			settings.MaxRounds = 10
+
+			state := RunSimulator(&scriptedStrategy{amounts: tt.amounts}, settings, nil, deck.NewShuffler(42))
+
+			if state.TableMaximumHits != tt.wantHits {
+				t.Errorf("TableMaximumHits = %d should be %d", state.TableMaximumHits, tt.wantHits)
+			}
+		})
+	}
+}
+
+func TestRunSimulator_TableMaximum(t *testing.T) {
+	tests := []struct {
+		name           string
+		policy         TableMaximumPolicy
+		wantExitReason ExitReason
 	}{
 		{
-			name:          "Bet on Punto returns PuntoPlayer with minimum bet",
-			strategy:      BetOnPunto,
-			state:         NewSimulatorState(),
-			wantBetType:   puntobanco.PuntoPlayer,
-			wantBetAmount: MinimumBet,
-		},
-		{
-			name:          "Bet on Banco returns BancoBanker with minimum bet",
-			strategy:      BetOnBanco,
-			state:         NewSimulatorState(),
-			wantBetType:   puntobanco.BancoBanker,
-			wantBetAmount: MinimumBet,
-		},
-		{
-			name:          "Bet on Égalité returns EgaliteTie with minimum bet",
-			strategy:      BetOnEgalite,
-			state:         NewSimulatorState(),
-			wantBetType:   puntobanco.EgaliteTie,
-			wantBetAmount: MinimumBet,
-		},
-		{
-			name:     "Bet on last hand returns last winning hand with minimum bet",
-			strategy: BetOnLastHand,
-			state: &SimulatorState{
-				LastWinningHand: puntobanco.BancoBanker,
-			},
-			wantBetType:   puntobanco.BancoBanker,
-			wantBetAmount: MinimumBet,
-		},
-		{
-			name:     "Bet on last hand PB returns last winning hand with minimum bet",
-			strategy: BetOnLastHand,
-			state: &SimulatorState{
-				LastWinningHand: puntobanco.PuntoPlayer,
-			},
-			wantBetType:   puntobanco.PuntoPlayer,
-			wantBetAmount: MinimumBet,
-		},
-		{
-			name:     "Bet on last hand PB returns BancoBanker with minimum bet in case of EgaliteTie",
-			strategy: BetOnLastHandPB,
-			state: &SimulatorState{
-				LastWinningHand: puntobanco.EgaliteTie,
-			},
-			wantBetType:   puntobanco.BancoBanker,
-			wantBetAmount: MinimumBet,
-		},
-		{
-			name:          "Bet on random returns Punto or Banco hand with minimum bet",
-			strategy:      BetOnRandom,
-			state:         NewSimulatorState(),
-			wantBetType:   GetRandomBetType(),
-			wantBetAmount: MinimumBet,
-		},
-		{
-			name:     "Martingale on Punto returns PuntoPlayer with current bet amount",
-			strategy: MartingaleOnPunto,
-			state: &SimulatorState{
-				BetAmount: 10.0,
-			},
-			wantBetType:   puntobanco.PuntoPlayer,
-			wantBetAmount: 10.0,
-		},
-		{
-			name:     "Martingale on Banco returns BancoBanker with current bet amount",
-			strategy: MartingaleOnBanco,
-			state: &SimulatorState{
-				BetAmount: 20.0,
-			},
-			wantBetType:   puntobanco.BancoBanker,
-			wantBetAmount: 20.0,
-		},
-		{
-			name:     "Paroli on Punto returns PuntoPlayer with current bet amount",
-			strategy: ParoliOnPunto,
-			state: &SimulatorState{
-				BetAmount: 50.0,
-			},
-			wantBetType:   puntobanco.PuntoPlayer,
-			wantBetAmount: 50.0,
-		},
-		{
-			name:     "Paroli on Banco returns BancoBanker with current bet amount",
-			strategy: ParoliOnBanco,
-			state: &SimulatorState{
-				BetAmount: 60.0,
-			},
-			wantBetType:   puntobanco.BancoBanker,
-			wantBetAmount: 60.0,
-		},
-		{
-			name:     "Fibonacci on Punto returns PuntoPlayer with current bet amount",
-			strategy: FibonacciOnPunto,
-			state: &SimulatorState{
-				BetAmount: 30.0,
-			},
-			wantBetType:   puntobanco.PuntoPlayer,
-			wantBetAmount: 30.0,
-		},
-		{
-			name:     "Fibonacci on Banco returns BancoBanker with current bet amount",
-			strategy: FibonacciOnBanco,
-			state: &SimulatorState{
-				BetAmount: 40.0,
-			},
-			wantBetType:   puntobanco.BancoBanker,
-			wantBetAmount: 40.0,
-		},
-		{
-			name:     "D'Alembert on Punto returns PuntoPlayer with current bet amount",
-			strategy: DAlembertOnPunto,
-			state: &SimulatorState{
-				BetAmount: 70.0,
-			},
-			wantBetType:   puntobanco.PuntoPlayer,
-			wantBetAmount: 70.0,
-		},
-		{
-			name:     "D'Alembert on Banco returns BancoBanker with current bet amount",
-			strategy: DAlembertOnBanco,
-			state: &SimulatorState{
-				BetAmount: 80.0,
-			},
-			wantBetType:   puntobanco.BancoBanker,
-			wantBetAmount: 80.0,
-		},
-		{
-			name:     "1-3-2-6 on Punto returns PuntoPlayer with current bet amount",
-			strategy: OneThreeTwoSixOnPunto,
-			state: &SimulatorState{
-				BetAmount: 90.0,
-			},
-			wantBetType:   puntobanco.PuntoPlayer,
-			wantBetAmount: 90.0,
+			name:   "cap the bet at the table maximum",
+			policy: TableMaximumCap,
 		},
 		{
-			name:     "1-3-2-6 on Punto returns BancoBanker with current bet amount",
-			strategy: OneThreeTwoSixOnBanco,
-			state: &SimulatorState{
-				BetAmount: 100.0,
-			},
-			wantBetType:   puntobanco.BancoBanker,
-			wantBetAmount: 100.0,
+			name:   "reset the progression",
+			policy: TableMaximumReset,
 		},
 		{
-			name:          "Unknown strategy defaults to PuntoPlayer with minimum bet",
-			strategy:      StrategyType("Unknown"),
-			state:         NewSimulatorState(),
-			wantBetType:   puntobanco.PuntoPlayer,
-			wantBetAmount: MinimumBet,
+			name:           "stop the session",
+			policy:         TableMaximumStop,
+			wantExitReason: ExitTableMaximum,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			betType, betAmount := MakeStrategy(tt.strategy, tt.state)
+			settings := DefaultSettings()
+			settings.Rules.MaximumBet = 40.0
+			settings.TableMaximumPolicy = tt.policy
+			settings.MaxRounds = 2000
+			dataCollector := NewDataCollector(MartingaleOnBanco, settings.Rules.NumberOfDecks, settings.Bankroll, settings.Rules.MinimumBet, 1, 42)
 
-			// Don't check for random bet type due to randomness
-			if tt.strategy != BetOnRandom {
-				if betType != tt.wantBetType {
-					t.Errorf("MakeStrategy() betType = %v, want %v", betType, tt.wantBetType)
-				}
+			state := RunSimulator(NewMartingale(puntobanco.BancoBanker, settings.Rules.MinimumBet), settings, dataCollector, deck.NewShuffler(42))
+
+			if state.TableMaximumHits == 0 {
+				t.Fatal("Martingale should hit the table maximum")
+			}
+			if tt.wantExitReason != "" && state.ExitReason != tt.wantExitReason {
+				t.Errorf("ExitReason = %q should be %q", state.ExitReason, tt.wantExitReason)
+			}
+			if tt.policy == TableMaximumStop && state.TableMaximumHits != 1 {
+				t.Errorf("session should stop at the first hit, got %d hits", state.TableMaximumHits)
 			}
 
-			if betAmount != tt.wantBetAmount {
-				t.Errorf("MakeStrategy() betAmount = %.2f, want %.2f", betAmount, tt.wantBetAmount)
+			hands := dataCollector.GetSimulationData().Games[0]
+			for i, hand := range hands {
+				if hand.Bet.BetAmount > settings.Rules.MaximumBet {
+					t.Fatalf("bet of %.2f in hand %d is over the table maximum", hand.Bet.BetAmount, hand.HandID)
+				}
+
+				// Martingale doubles the lost bet at the table maximum, the policy decides the next bet
+				previous := hands[max(i-1, 0)].Bet
+				if i == 0 || previous.BetAmount != settings.Rules.MaximumBet || previous.IsWin || previous.IsPush {
+					continue
+				}
+				if tt.policy == TableMaximumCap && hand.Bet.BetAmount != settings.Rules.MaximumBet {
+					t.Errorf("bet after a lost table maximum bet = %.2f should be capped at %.2f", hand.Bet.BetAmount, settings.Rules.MaximumBet)
+				}
+				if tt.policy == TableMaximumReset && hand.Bet.BetAmount != settings.Rules.MinimumBet {
+					t.Errorf("bet after a lost table maximum bet = %.2f should be reset to %.2f", hand.Bet.BetAmount, settings.Rules.MinimumBet)
+				}
 			}
 		})
 	}
 }
 
-func TestRunSimulator(t *testing.T) {
-	result := RunSimulator(BetOnPunto, nil)
-	if result == nil {
-		t.Fatal("simulator's result should not be nil")
+func TestRunMultipleSimulations_TableMaximumPolicyOfStrategy(t *testing.T) {
+	settings := DefaultSettings()
+	settings.Rules.MaximumBet = 100.0
+	settings.StrategyTableMaximumPolicies = map[StrategyType]TableMaximumPolicy{MartingaleOnPunto: TableMaximumStop}
+
+	stats, err := RunMultipleSimulations(context.Background(), MartingaleOnPunto, settings, 50, false, 42, nil)
+	if err != nil {
+		t.Fatalf("RunMultipleSimulations() error: %v", err)
 	}
-	if result.RoundsPlayed == 0 {
-		t.Fatal("simulator should play at least one round")
+	if stats.GamesWithTableMaximumExit == 0 || stats.TableMaximumHitRate == 0 {
+		t.Errorf("games should stop at the table maximum, got %d exits and %.2f%% hit rate", stats.GamesWithTableMaximumExit, stats.TableMaximumHitRate)
+	}
+
+	stats, err = RunMultipleSimulations(context.Background(), MartingaleOnBanco, settings, 50, false, 42, nil)
+	if err != nil {
+		t.Fatalf("RunMultipleSimulations() error: %v", err)
+	}
+	if stats.GamesWithTableMaximumExit != 0 || stats.AvgTableMaximumHitsPerGame == 0 {
+		t.Errorf("other strategies should cap the bet, got %d exits and %.2f hits per game", stats.GamesWithTableMaximumExit, stats.AvgTableMaximumHitsPerGame)
+	}
+}
+
+func TestRunMultipleSimulations_ExitReasons(t *testing.T) {
+	settings := DefaultSettings()
+	settings.StopWin = SessionLimit{Amount: 10, IsPercent: true}
+	settings.StopLoss = SessionLimit{Amount: 10, IsPercent: true}
+	settings.MaxRounds = 50
+
+	stats, err := RunMultipleSimulations(context.Background(), MartingaleOnBanco, settings, 200, false, 42, nil)
+	if err != nil {
+		t.Fatalf("RunMultipleSimulations() error: %v", err)
+	}
+
+	total := stats.GamesBusted + stats.GamesWithGoalHit + stats.GamesWithLossLimit + stats.GamesWithRoundCap + stats.GamesWithBetExceedingBankroll + stats.GamesWithTableMaximumExit
+	if total != stats.TotalSimulations {
+		t.Errorf("every game should have an exit reason: got %d of %d", total, stats.TotalSimulations)
+	}
+	rates := stats.BustedRate + stats.GoalHitRate + stats.LossLimitRate + stats.RoundCapRate + stats.BetExceedsBankrollRate + stats.TableMaximumExitRate
+	if rates < 99.99 || rates > 100.01 {
+		t.Errorf("exit reason rates should add up to 100%%, got %.2f", rates)
+	}
+	if stats.MaxRoundsPlayed > settings.MaxRounds {
+		t.Errorf("MaxRoundsPlayed = %d should not exceed the round cap of %d", stats.MaxRoundsPlayed, settings.MaxRounds)
+	}
+}
+
+func TestRunMultipleSimulations_ConfidenceIntervals(t *testing.T) {
+	settings := DefaultSettings()
+	settings.MaxRounds = 50
+
+	stats, err := RunMultipleSimulations(context.Background(), BetOnBanco, settings, 200, false, 42, nil)
+	if err != nil {
+		t.Fatalf("RunMultipleSimulations() error: %v", err)
+	}
+
+	intervals := []struct {
+		name     string
+		value    float64
+		interval ConfidenceInterval
+	}{
+		{"AvgRoundsPerGame", stats.AvgRoundsPerGame, stats.AvgRoundsPerGameInterval},
+		{"WinRate", stats.WinRate, stats.WinRateInterval},
+		{"ZeroWinsRate", stats.ZeroWinsRate, stats.ZeroWinsRateInterval},
+		{"AvgMaxBankrollReached", stats.AvgMaxBankrollReached, stats.AvgMaxBankrollReachedInterval},
+		{"ProfitableBankrollRate", stats.ProfitableBankrollRate, stats.ProfitableBankrollRateInterval},
+		{"ProfitableEndGamesRate", stats.ProfitableEndGamesRate, stats.ProfitableEndGamesRateInterval},
+	}
+	for _, tt := range intervals {
+		if tt.value < tt.interval.Low-1e-9 || tt.value > tt.interval.High+1e-9 {
+			t.Errorf("%s = %f should be within its interval %+v", tt.name, tt.value, tt.interval)
+		}
+	}
+	if stats.WinRateInterval.Low >= stats.WinRateInterval.High {
+		t.Errorf("win rate interval %+v should not be empty", stats.WinRateInterval)
+	}
+}
+
+func TestRunMultipleSimulations_MoneyFlow(t *testing.T) {
+	settings := DefaultSettings()
+	settings.MaxRounds = 50
+
+	stats, err := RunMultipleSimulations(context.Background(), BetOnBanco, settings, 200, false, 42, nil)
+	if err != nil {
+		t.Fatalf("RunMultipleSimulations() error: %v", err)
+	}
+
+	// Flat bets of the minimum bet are placed on every round
+	wantWagered := stats.AvgRoundsPerGame * settings.Rules.MinimumBet
+	if math.Abs(stats.AvgWageredPerGame-wantWagered) > 1e-9 {
+		t.Errorf("AvgWageredPerGame = %f should be %f", stats.AvgWageredPerGame, wantWagered)
+	}
+	wantNetResult := stats.FinalBankrollDistribution.Mean - settings.Bankroll
+	if math.Abs(stats.AvgNetResultPerGame-wantNetResult) > 1e-9 {
+		t.Errorf("AvgNetResultPerGame = %f should be the mean final bankroll minus the bankroll %f", stats.AvgNetResultPerGame, wantNetResult)
+	}
+	// Commission is taken from even money of Banco wins
+	wantCommission := stats.AvgPaidOutPerGame * settings.Rules.BancoCommission / (1 - settings.Rules.BancoCommission)
+	if math.Abs(stats.AvgCommissionPerGame-wantCommission) > 1e-9 {
+		t.Errorf("AvgCommissionPerGame = %f should be %f", stats.AvgCommissionPerGame, wantCommission)
+	}
+	wantEdge := -stats.TotalNetResult / stats.TotalWagered * 100
+	if math.Abs(stats.RealisedHouseEdge-wantEdge) > 1e-9 {
+		t.Errorf("RealisedHouseEdge = %f should be %f", stats.RealisedHouseEdge, wantEdge)
+	}
+	if stats.TheoreticalHouseEdge != TheoreticalHouseEdge(puntobanco.BancoBanker, settings.Rules) {
+		t.Errorf("TheoreticalHouseEdge = %f should be the edge of Banco", stats.TheoreticalHouseEdge)
 	}
 }
 
@@ -274,7 +543,7 @@ func TestNewMultipleSimulationsStats(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := NewMultipleSimulationsStats(tt.numSimulations)
+			result := NewMultipleSimulationsStats(tt.numSimulations, DefaultSettings())
 
 			if result.TotalSimulations != tt.wantTotalSimulations {
 				t.Errorf("TotalSimulations = %d should be %d",
@@ -326,6 +595,18 @@ func TestNewMultipleSimulationsStats(t *testing.T) {
 				t.Errorf("ZeroWinsRate = %.2f should be 0.0",
 					result.ZeroWinsRate)
 			}
+			if result.AvgPushesPerGame != 0.0 {
+				t.Errorf("AvgPushesPerGame = %.2f should be 0.0",
+					result.AvgPushesPerGame)
+			}
+			if result.MaxPushes != 0 {
+				t.Errorf("MaxPushes = %d should be 0",
+					result.MaxPushes)
+			}
+			if result.PushRate != 0.0 {
+				t.Errorf("PushRate = %.2f should be 0.0",
+					result.PushRate)
+			}
 			if result.AvgMaxWinsStreak != 0.0 {
 				t.Errorf("AvgMaxWinsStreak = %.2f should be 0.0",
 					result.AvgMaxWinsStreak)
@@ -346,9 +627,9 @@ func TestNewMultipleSimulationsStats(t *testing.T) {
 				t.Errorf("AvgMaxBankrollReached = %.2f should be 0.0",
 					result.AvgMaxBankrollReached)
 			}
-			if result.MaxBankrollReacorded != Bankroll {
+			if result.MaxBankrollReacorded != DefaultBankroll {
 				t.Errorf("MaxBankrollReacorded = %.2f should be  %.2f",
-					result.MaxBankrollReacorded, Bankroll)
+					result.MaxBankrollReacorded, DefaultBankroll)
 			}
 			if result.GamesWithProfitableBankroll != 0 {
 				t.Errorf("GamesWithProfitableBankroll = %d should be 0",
@@ -372,7 +653,7 @@ func TestNewMultipleSimulationsStats(t *testing.T) {
 
 func TestRunMultipleSimulations(t *testing.T) {
 	numberOfTestSimulations := 10
-	result := RunMultipleSimulations(BetOnPunto, numberOfTestSimulations, false)
+	result, _ := RunMultipleSimulations(context.Background(), BetOnPunto, DefaultSettings(), numberOfTestSimulations, false, deck.NewRandomSeed(), nil)
 	if result.TotalSimulations != numberOfTestSimulations {
 		t.Fatal("should run multiple simulations")
 	}
@@ -386,3 +667,262 @@ func TestRunMultipleSimulations(t *testing.T) {
 		t.Fatal("multiple simulations should play max at least one round")
 	}
 }
+
+func TestRunMultipleSimulations_UnknownStrategy(t *testing.T) {
+	_, err := RunMultipleSimulations(context.Background(), StrategyType("Unknown"), DefaultSettings(), 10, false, 42, nil)
+	if err == nil {
+		t.Errorf("simulations of an unknown strategy should fail")
+	}
+}
+
+func TestRunMultipleSimulations_ConcurrentTableRules(t *testing.T) {
+	var seed int64 = 42
+	numberOfTestSimulations := 10
+
+	highStakes := DefaultSettings()
+	highStakes.Rules.NumberOfDecks = 8
+	highStakes.Rules.MinimumBet = 100.0
+	highStakes.Bankroll = 10000.0
+
+	ruleSets := []Settings{DefaultSettings(), highStakes}
+	results := make([]MultipleSimulationsStats, len(ruleSets))
+
+	var wg sync.WaitGroup
+	for i, settings := range ruleSets {
+		wg.Add(1)
+		go func(i int, settings Settings) {
+			defer wg.Done()
+			results[i], _ = RunMultipleSimulations(context.Background(), MartingaleOnPunto, settings, numberOfTestSimulations, false, seed, nil)
+		}(i, settings)
+	}
+	wg.Wait()
+
+	for i, settings := range ruleSets {
+		want, _ := RunMultipleSimulations(context.Background(), MartingaleOnPunto, settings, numberOfTestSimulations, false, seed, nil)
+		if !reflect.DeepEqual(results[i], want) {
+			t.Errorf("concurrent simulation %d should be equal to the sequential one", i)
+		}
+	}
+
+	if results[1].MaxBankrollReacorded < highStakes.Bankroll {
+		t.Errorf("MaxBankrollReacorded = %.2f should not be less than starting bankroll %.2f", results[1].MaxBankrollReacorded, highStakes.Bankroll)
+	}
+}
+
+func TestRunMultipleSimulations_Seed(t *testing.T) {
+	var seed int64 = 42
+	numberOfTestSimulations := 10
+
+	first, _ := RunMultipleSimulations(context.Background(), MartingaleOnPunto, DefaultSettings(), numberOfTestSimulations, false, seed, nil)
+	second, _ := RunMultipleSimulations(context.Background(), MartingaleOnPunto, DefaultSettings(), numberOfTestSimulations, false, seed, nil)
+
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("simulations run with the same seed should have equal stats")
+	}
+}
+
+func TestRunMultipleSimulations_Workers(t *testing.T) {
+	var seed int64 = 42
+	numberOfTestSimulations := 20
+
+	sequential := DefaultSettings()
+	sequential.Workers = 1
+	want, _ := RunMultipleSimulations(context.Background(), FibonacciOnBanco, sequential, numberOfTestSimulations, false, seed, nil)
+
+	for _, workers := range []int{2, 4, 7} {
+		t.Run(fmt.Sprintf("%d workers", workers), func(t *testing.T) {
+			settings := DefaultSettings()
+			settings.Workers = workers
+
+			result, _ := RunMultipleSimulations(context.Background(), FibonacciOnBanco, settings, numberOfTestSimulations, false, seed, nil)
+			if !reflect.DeepEqual(result, want) {
+				t.Errorf("stats of %d workers should be equal to the stats of one worker", workers)
+			}
+		})
+	}
+}
+
+func TestRunSimulations_DataCollectorOrder(t *testing.T) {
+	var seed int64 = 42
+	numberOfTestSimulations := 12
+
+	collect := func(workers int) *SimulationData {
+		settings := DefaultSettings()
+		settings.Workers = workers
+
+		dc := NewDataCollector(BetOnRandom, settings.Rules.NumberOfDecks, settings.Bankroll, settings.Rules.MinimumBet, numberOfTestSimulations, seed)
+		runSimulations(context.Background(), BetOnRandom, settings, numberOfTestSimulations, dc, seed, nil)
+		return dc.GetSimulationData()
+	}
+
+	data := collect(4)
+
+	if len(data.Games) != numberOfTestSimulations {
+		t.Fatalf("collected %d games, want %d", len(data.Games), numberOfTestSimulations)
+	}
+
+	for i, game := range data.Games {
+		if len(game) == 0 {
+			t.Fatalf("game %d should have at least one hand", i+1)
+		}
+		for _, hand := range game {
+			if hand.GameID != i+1 {
+				t.Fatalf("hand of game %d has GameID %d", i+1, hand.GameID)
+			}
+		}
+		if game[0].HandID != 1 || game[0].ShoeNumber != 1 {
+			t.Errorf("game %d should start with the first hand of the first shoe", i+1)
+		}
+	}
+
+	if !reflect.DeepEqual(data, collect(1)) {
+		t.Errorf("collected data should not depend on the number of workers")
+	}
+
+	if len(data.ShoeSeeds) != numberOfTestSimulations {
+		t.Fatalf("collected %d shoe seeds, want %d", len(data.ShoeSeeds), numberOfTestSimulations)
+	}
+
+	// Shoe seed deals the same cards to another strategy
+	for i, shoeSeed := range data.ShoeSeeds {
+		dc := newGameDataCollector()
+		RunSimulator(NewFlatStrategy(puntobanco.BancoBanker, rules.DefaultMinimumBet), DefaultSettings(), dc, deck.NewShuffler(shoeSeed))
+		replayed := dc.data.Games[0]
+
+		for j := 0; j < min(len(replayed), len(data.Games[i])); j++ {
+			if !reflect.DeepEqual(replayed[j].PuntoHand, data.Games[i][j].PuntoHand) || !reflect.DeepEqual(replayed[j].BankoHand, data.Games[i][j].BankoHand) {
+				t.Fatalf("hand %d of game %d should be dealt the same from the shoe seed", j+1, i+1)
+			}
+		}
+	}
+}
+
+func TestGenerateSimulationJobs_Slots(t *testing.T) {
+	jobs := make(chan simulationJob, 10)
+	slots := make(chan struct{}, 3)
+
+	go generateSimulationJobs(context.Background(), 42, 10, jobs, slots)
+
+	// Games are not dispatched beyond the free slots until a game is folded
+	for i := 0; i < 3; i++ {
+		if job := <-jobs; job.gameIndex != i {
+			t.Fatalf("game %d should be dispatched, got %d", i, job.gameIndex)
+		}
+	}
+	select {
+	case job := <-jobs:
+		t.Fatalf("game %d should wait for a free slot", job.gameIndex)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Each folded game lets the next one be dispatched until the jobs channel is closed
+	dispatched := 3
+	for {
+		<-slots
+		if _, ok := <-jobs; !ok {
+			break
+		}
+		dispatched++
+	}
+	if dispatched != 10 {
+		t.Errorf("all 10 games should be dispatched, got %d", dispatched)
+	}
+}
+
+func TestRunMultipleSimulations_Progress(t *testing.T) {
+	numberOfTestSimulations := 10
+	var reports []Progress
+
+	result, err := RunMultipleSimulations(context.Background(), BetOnPunto, DefaultSettings(), numberOfTestSimulations, false, 42, func(p Progress) {
+		reports = append(reports, p)
+	})
+	if err != nil {
+		t.Fatalf("simulations should not fail: %v", err)
+	}
+
+	if len(reports) != numberOfTestSimulations {
+		t.Fatalf("progress should be reported %d times, got %d", numberOfTestSimulations, len(reports))
+	}
+
+	for i, p := range reports {
+		if p.CompletedSimulations != i+1 {
+			t.Errorf("CompletedSimulations = %d should be %d", p.CompletedSimulations, i+1)
+		}
+		if p.TotalSimulations != numberOfTestSimulations {
+			t.Errorf("TotalSimulations = %d should be %d", p.TotalSimulations, numberOfTestSimulations)
+		}
+		if p.Stats.TotalSimulations != i+1 {
+			t.Errorf("running stats should cover %d games, got %d", i+1, p.Stats.TotalSimulations)
+		}
+	}
+
+	if !reflect.DeepEqual(reports[len(reports)-1].Stats, result) {
+		t.Errorf("last progress report should have the final stats")
+	}
+}
+
+func TestRunMultipleSimulations_ProgressStatsInterval(t *testing.T) {
+	numberOfTestSimulations := 1000
+	// Games of a bankroll of a single bet are short
+	settings := DefaultSettings()
+	settings.Bankroll = settings.Rules.MinimumBet
+	var reports []Progress
+
+	result, err := RunMultipleSimulations(context.Background(), BetOnPunto, settings, numberOfTestSimulations, false, 42, func(p Progress) {
+		reports = append(reports, p)
+	})
+	if err != nil {
+		t.Fatalf("simulations should not fail: %v", err)
+	}
+
+	if len(reports) != numberOfTestSimulations {
+		t.Fatalf("progress should be reported %d times, got %d", numberOfTestSimulations, len(reports))
+	}
+
+	// Running stats are updated every 10 games of 1000
+	for i, p := range reports {
+		if want := (i + 1) / 10 * 10; p.Stats.TotalSimulations != want {
+			t.Errorf("running stats after %d games should cover %d games, got %d", i+1, want, p.Stats.TotalSimulations)
+		}
+	}
+
+	if !reflect.DeepEqual(reports[len(reports)-1].Stats, result) {
+		t.Errorf("last progress report should have the final stats")
+	}
+}
+
+func TestRunMultipleSimulations_Cancel(t *testing.T) {
+	t.Run("cancelled before start", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		result, err := RunMultipleSimulations(ctx, BetOnPunto, DefaultSettings(), 1000, false, 42, nil)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("error = %v should be %v", err, context.Canceled)
+		}
+		if result.TotalSimulations >= 1000 {
+			t.Errorf("cancelled run should not complete all simulations, got %d", result.TotalSimulations)
+		}
+	})
+
+	t.Run("cancelled in progress", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		cancelAfter := 5
+		result, err := RunMultipleSimulations(ctx, BetOnPunto, DefaultSettings(), 100000, false, 42, func(p Progress) {
+			if p.CompletedSimulations == cancelAfter {
+				cancel()
+			}
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("error = %v should be %v", err, context.Canceled)
+		}
+		if result.TotalSimulations < cancelAfter || result.TotalSimulations >= 100000 {
+			t.Errorf("partial results of %d games should cover at least %d games", result.TotalSimulations, cancelAfter)
+		}
+		if result.AvgRoundsPerGame <= 0 {
+			t.Errorf("partial results should have average rounds per game")
+		}
+	})
+}
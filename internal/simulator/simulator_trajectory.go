@@ -0,0 +1,108 @@
+package simulator
+
+const (
+	// Bands are kept at no more than this number of rounds, which is enough for a terminal chart
+	maxTrajectoryPoints = 100
+	// Bankrolls of the first games are kept as they are
+	numberOfSampleTrajectories = 5
+)
+
+// Spread of bankrolls of all games after a round,
+// the bankroll of a game that has ended stays at its final value
+type TrajectoryPoint struct {
+	Round  int     `json:"round"`
+	Mean   float64 `json:"mean"`
+	P5     float64 `json:"p5"`
+	P25    float64 `json:"p25"`
+	Median float64 `json:"median"`
+	P75    float64 `json:"p75"`
+	P95    float64 `json:"p95"`
+}
+
+// How bankrolls evolved during games
+type BankrollTrajectories struct {
+	Points []TrajectoryPoint `json:"points"`
+	// Bankrolls of the first games at the rounds of the points
+	SampleGames [][]float64 `json:"sampleGames"`
+}
+
+// Sketches of bankrolls at every stride of rounds. Once a game is longer than the points cover,
+// the stride is doubled and every other point is dropped, so the number of points stays bounded
+// however long games are.
+type trajectoryAccumulator struct {
+	stride int
+	points []*quantileSketch
+	// Final bankrolls of the games folded so far, which start the points added after these games
+	finals  *quantileSketch
+	samples [][]float64
+}
+
+func newTrajectoryAccumulator() *trajectoryAccumulator {
+	return &trajectoryAccumulator{
+		stride: 1,
+		finals: newQuantileSketch(),
+	}
+}
+
+// History starts with the bankroll before the first round followed by the bankroll after each round
+func (a *trajectoryAccumulator) add(history []float64) {
+	if len(history) == 0 {
+		return
+	}
+	lastRound := len(history) - 1
+
+	for lastRound/a.stride+1 > maxTrajectoryPoints {
+		a.stride *= 2
+		points := a.points[:0]
+		for i := 0; i < len(a.points); i += 2 {
+			points = append(points, a.points[i])
+		}
+		a.points = points
+	}
+
+	// Previous games have ended before the rounds of the new points
+	for len(a.points) < lastRound/a.stride+1 {
+		a.points = append(a.points, a.finals.clone())
+	}
+
+	for i, point := range a.points {
+		point.add(history[min(i*a.stride, lastRound)])
+	}
+	a.finals.add(history[lastRound])
+
+	if len(a.samples) < numberOfSampleTrajectories {
+		a.samples = append(a.samples, history)
+	}
+}
+
+func (a *trajectoryAccumulator) result() BankrollTrajectories {
+	var trajectories BankrollTrajectories
+	if len(a.points) == 0 {
+		return trajectories
+	}
+
+	trajectories.Points = make([]TrajectoryPoint, len(a.points))
+	for i, point := range a.points {
+		trajectories.Points[i] = TrajectoryPoint{
+			Round:  i * a.stride,
+			Mean:   point.moments.mean,
+			P5:     point.quantile(0.05),
+			P25:    point.quantile(0.25),
+			Median: point.quantile(0.5),
+			P75:    point.quantile(0.75),
+			P95:    point.quantile(0.95),
+		}
+	}
+
+	trajectories.SampleGames = make([][]float64, len(a.samples))
+	for i, history := range a.samples {
+		lastRound := len(history) - 1
+		bankrolls := make([]float64, len(a.points))
+		for j := range bankrolls {
+			bankrolls[j] = history[min(j*a.stride, lastRound)]
+		}
+		trajectories.SampleGames[i] = bankrolls
+	}
+
+	return trajectories
+}
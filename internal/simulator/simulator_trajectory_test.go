@@ -0,0 +1,89 @@
+package simulator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTrajectoryAccumulator(t *testing.T) {
+	t.Run("ended games keep their final bankroll", func(t *testing.T) {
+		a := newTrajectoryAccumulator()
+		a.add([]float64{100, 110})
+		a.add([]float64{100, 90, 80, 70})
+
+		got := a.result()
+		wantMeans := []float64{100, 100, 95, 90}
+		if len(got.Points) != len(wantMeans) {
+			t.Fatalf("trajectories should have %d points, got %d", len(wantMeans), len(got.Points))
+		}
+		for i, point := range got.Points {
+			if point.Round != i {
+				t.Errorf("point %d should be at round %d, got %d", i, i, point.Round)
+			}
+			if point.Mean != wantMeans[i] {
+				t.Errorf("mean of round %d = %f should be %f", i, point.Mean, wantMeans[i])
+			}
+		}
+
+		wantSamples := [][]float64{{100, 110, 110, 110}, {100, 90, 80, 70}}
+		if !reflect.DeepEqual(got.SampleGames, wantSamples) {
+			t.Errorf("sample games = %v should be %v", got.SampleGames, wantSamples)
+		}
+	})
+
+	t.Run("long games double the stride", func(t *testing.T) {
+		a := newTrajectoryAccumulator()
+		a.add([]float64{100, 100})
+
+		history := make([]float64, 251)
+		for i := range history {
+			history[i] = float64(1000 - i)
+		}
+		a.add(history)
+
+		got := a.result()
+		if len(got.Points) > maxTrajectoryPoints {
+			t.Fatalf("trajectories should have no more than %d points, got %d", maxTrajectoryPoints, len(got.Points))
+		}
+		for i, point := range got.Points {
+			if point.Round != i*4 {
+				t.Errorf("point %d should be at round %d, got %d", i, i*4, point.Round)
+			}
+			// The short game is over after the first round
+			wantMean := (100 + float64(1000-i*4)) / 2
+			if point.Mean != wantMean {
+				t.Errorf("mean of round %d = %f should be %f", point.Round, point.Mean, wantMean)
+			}
+		}
+		if last := got.Points[len(got.Points)-1].Round; last != 248 {
+			t.Errorf("last point should be at round 248, got %d", last)
+		}
+	})
+
+	t.Run("sample games are the first games", func(t *testing.T) {
+		a := newTrajectoryAccumulator()
+		for i := 0; i < numberOfSampleTrajectories+3; i++ {
+			a.add([]float64{100, float64(i)})
+		}
+
+		got := a.result()
+		if len(got.SampleGames) != numberOfSampleTrajectories {
+			t.Fatalf("trajectories should have %d sample games, got %d", numberOfSampleTrajectories, len(got.SampleGames))
+		}
+		for i, game := range got.SampleGames {
+			if game[1] != float64(i) {
+				t.Errorf("sample game %d should end at %d, got %f", i, i, game[1])
+			}
+		}
+		if p := got.Points[1]; p.P5 > p.Median || p.Median > p.P95 {
+			t.Errorf("percentiles should be ordered, got %+v", p)
+		}
+	})
+
+	t.Run("no games", func(t *testing.T) {
+		got := newTrajectoryAccumulator().result()
+		if got.Points != nil || got.SampleGames != nil {
+			t.Errorf("trajectories of no games should be empty, got %+v", got)
+		}
+	})
+}
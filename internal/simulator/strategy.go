@@ -0,0 +1,269 @@
+package simulator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/adequatica/punto-banco-golango/internal/deck"
+	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
+)
+
+type StrategyType string
+
+const (
+	// Flat betting strategies
+	BetOnPunto      StrategyType = "Bet on Punto (player)"
+	BetOnBanco      StrategyType = "Bet on Banco (banker)"
+	BetOnEgalite    StrategyType = "Bet on Égalité (tie)"
+	BetOnLastHand   StrategyType = "Bet on Last Hand"
+	BetOnLastHandPB StrategyType = "Bet on Last Hand PB"
+	BetOnRandom     StrategyType = "Bet on Random PB"
+	// Progressive betting strategies
+	MartingaleOnPunto     StrategyType = "Martingale on Punto"
+	MartingaleOnBanco     StrategyType = "Martingale on Banco"
+	ParoliOnPunto         StrategyType = "Paroli on Punto"
+	ParoliOnBanco         StrategyType = "Paroli on Banco"
+	FibonacciOnPunto      StrategyType = "Fibonacci on Punto"
+	FibonacciOnBanco      StrategyType = "Fibonacci on Banco"
+	DAlembertOnPunto      StrategyType = "D'Alembert on Punto"
+	DAlembertOnBanco      StrategyType = "D'Alembert on Banco"
+	OneThreeTwoSixOnPunto StrategyType = "1-3-2-6 on Punto"
+	OneThreeTwoSixOnBanco StrategyType = "1-3-2-6 on Banco"
+	OscarsGrindOnPunto    StrategyType = "Oscar's Grind on Punto"
+	OscarsGrindOnBanco    StrategyType = "Oscar's Grind on Banco"
+	// Pattern betting strategies
+	FollowTheStreakPB     StrategyType = "Follow the Streak PB"
+	BetTheChopPB          StrategyType = "Bet the Chop PB"
+	FollowBigRoadColumnPB StrategyType = "Follow the Big Road Column PB"
+	TwoInARowThenSwitchPB StrategyType = "2 in a Row then Switch PB"
+	// Cancellation betting strategies
+	LabouchereOnPunto        StrategyType = "Labouchère on Punto"
+	LabouchereOnBanco        StrategyType = "Labouchère on Banco"
+	ReverseLabouchereOnPunto StrategyType = "Reverse Labouchère on Punto"
+	ReverseLabouchereOnBanco StrategyType = "Reverse Labouchère on Banco"
+	// Card counting strategies
+	CardCountPB        StrategyType = "Card Count PB"
+	CardCountOnEgalite StrategyType = "Card Count on Égalité (tie)"
+	// Side betting strategies
+	BancoWithEitherPair  StrategyType = "Bet on Banco with Either pair"
+	BancoWithPerfectPair StrategyType = "Bet on Banco with Perfect pair"
+)
+
+// What a strategy can see of the game before placing the next bet
+type History struct {
+	LastWinningHand puntobanco.BetType
+	RoundsPlayed    int
+	// Coups dealt in the game, including the rounds sat out
+	RoundsObserved  int
+	CurrentBankroll float64
+	// Last results of the game including Égalité, the oldest first.
+	// The slice belongs to the simulator state and must not be modified.
+	Results []puntobanco.BetType
+}
+
+// Settled bet of a coup
+type BetResult struct {
+	Outcome   Outcome
+	BetOn     puntobanco.BetType
+	BetAmount float64
+	// Winnings on top of the returned bet, 0 for a loss or a push
+	Payout float64
+}
+
+// A betting strategy keeps its own progression state.
+// A new instance is made for every game, so it does not have to be safe for concurrent use.
+type Strategy interface {
+	// Side and amount of the next bet, a zero amount sits the coup out
+	NextBet(history History) (puntobanco.BetType, float64)
+	// Move the progression after the bet is settled, a sat out coup has no bet outcome
+	OnResult(result BetResult)
+	// Start over from the base bet
+	Reset()
+}
+
+// Cancellation strategies report how many numbers are left on their line
+type LineStrategy interface {
+	LineLength() int
+}
+
+// Strategies get the settings of the run and the random source of the game
+type StrategyFactory func(settings Settings, random deck.Shuffler) Strategy
+
+type registeredStrategy struct {
+	strategy StrategyType
+	factory  StrategyFactory
+}
+
+// Strategies in the order of the menu
+var strategyRegistry []registeredStrategy
+
+// Add a strategy to the registry, a strategy registered twice replaces the previous one
+func RegisterStrategy(strategy StrategyType, factory StrategyFactory) {
+	for i, registered := range strategyRegistry {
+		if registered.strategy == strategy {
+			strategyRegistry[i].factory = factory
+			return
+		}
+	}
+
+	strategyRegistry = append(strategyRegistry, registeredStrategy{strategy: strategy, factory: factory})
+}
+
+func NewStrategy(strategy StrategyType, settings Settings, random deck.Shuffler) (Strategy, error) {
+	for _, registered := range strategyRegistry {
+		if registered.strategy == strategy {
+			return registered.factory(settings, random), nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown strategy %q", strategy)
+}
+
+func GetStrategyOptions() []string {
+	options := make([]string, 0, len(strategyRegistry))
+	for _, registered := range strategyRegistry {
+		options = append(options, string(registered.strategy))
+	}
+
+	return options
+}
+
+// Short name of a strategy for the command line, e.g. "martingale-on-punto"
+func StrategySlug(strategy StrategyType) string {
+	replacer := strings.NewReplacer("é", "e", "É", "e", "è", "e", "'", "")
+	name := replacer.Replace(strings.ToLower(string(strategy)))
+
+	var slug strings.Builder
+	isSeparator := false
+	for _, r := range name {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if isSeparator && slug.Len() > 0 {
+				slug.WriteRune('-')
+			}
+			slug.WriteRune(r)
+			isSeparator = false
+		} else {
+			isSeparator = true
+		}
+	}
+
+	return slug.String()
+}
+
+// Find a strategy by its name or its short name, case insensitive
+func ParseStrategy(name string) (StrategyType, error) {
+	for _, option := range GetStrategyOptions() {
+		strategy := StrategyType(option)
+		if strings.EqualFold(name, option) || strings.EqualFold(name, StrategySlug(strategy)) {
+			return strategy, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown strategy %q", name)
+}
+
+func init() {
+	// Flat betting strategies
+	RegisterStrategy(BetOnPunto, func(s Settings, _ deck.Shuffler) Strategy {
+		return NewFlatStrategy(puntobanco.PuntoPlayer, s.Rules.MinimumBet)
+	})
+	RegisterStrategy(BetOnBanco, func(s Settings, _ deck.Shuffler) Strategy {
+		return NewFlatStrategy(puntobanco.BancoBanker, s.Rules.MinimumBet)
+	})
+	RegisterStrategy(BetOnEgalite, func(s Settings, _ deck.Shuffler) Strategy {
+		return NewFlatStrategy(puntobanco.EgaliteTie, s.Rules.MinimumBet)
+	})
+	RegisterStrategy(BetOnLastHand, func(s Settings, _ deck.Shuffler) Strategy {
+		return NewLastHandStrategy(false, s.Rules.MinimumBet)
+	})
+	RegisterStrategy(BetOnLastHandPB, func(s Settings, _ deck.Shuffler) Strategy {
+		return NewLastHandStrategy(true, s.Rules.MinimumBet)
+	})
+	RegisterStrategy(BetOnRandom, func(s Settings, random deck.Shuffler) Strategy {
+		return NewRandomStrategy(random, s.Rules.MinimumBet)
+	})
+
+	// Progressive betting strategies
+	RegisterStrategy(MartingaleOnPunto, func(s Settings, _ deck.Shuffler) Strategy {
+		return NewMartingale(puntobanco.PuntoPlayer, s.Rules.MinimumBet)
+	})
+	RegisterStrategy(MartingaleOnBanco, func(s Settings, _ deck.Shuffler) Strategy {
+		return NewMartingale(puntobanco.BancoBanker, s.Rules.MinimumBet)
+	})
+	RegisterStrategy(ParoliOnPunto, func(s Settings, _ deck.Shuffler) Strategy {
+		return NewParoli(puntobanco.PuntoPlayer, s.Rules.MinimumBet, s.ParoliMaxLevel)
+	})
+	RegisterStrategy(ParoliOnBanco, func(s Settings, _ deck.Shuffler) Strategy {
+		return NewParoli(puntobanco.BancoBanker, s.Rules.MinimumBet, s.ParoliMaxLevel)
+	})
+	RegisterStrategy(FibonacciOnPunto, func(s Settings, _ deck.Shuffler) Strategy {
+		return NewFibonacci(puntobanco.PuntoPlayer, s.Rules.MinimumBet)
+	})
+	RegisterStrategy(FibonacciOnBanco, func(s Settings, _ deck.Shuffler) Strategy {
+		return NewFibonacci(puntobanco.BancoBanker, s.Rules.MinimumBet)
+	})
+	RegisterStrategy(DAlembertOnPunto, func(s Settings, _ deck.Shuffler) Strategy {
+		return NewDAlembert(puntobanco.PuntoPlayer, s.Rules.MinimumBet)
+	})
+	RegisterStrategy(DAlembertOnBanco, func(s Settings, _ deck.Shuffler) Strategy {
+		return NewDAlembert(puntobanco.BancoBanker, s.Rules.MinimumBet)
+	})
+	RegisterStrategy(OneThreeTwoSixOnPunto, func(s Settings, _ deck.Shuffler) Strategy {
+		return NewOneThreeTwoSix(puntobanco.PuntoPlayer, s.Rules.MinimumBet)
+	})
+	RegisterStrategy(OneThreeTwoSixOnBanco, func(s Settings, _ deck.Shuffler) Strategy {
+		return NewOneThreeTwoSix(puntobanco.BancoBanker, s.Rules.MinimumBet)
+	})
+	RegisterStrategy(OscarsGrindOnPunto, func(s Settings, _ deck.Shuffler) Strategy {
+		return NewOscarsGrind(puntobanco.PuntoPlayer, s.Rules.MinimumBet)
+	})
+	RegisterStrategy(OscarsGrindOnBanco, func(s Settings, _ deck.Shuffler) Strategy {
+		return NewOscarsGrind(puntobanco.BancoBanker, s.Rules.MinimumBet)
+	})
+
+	// Pattern betting strategies
+	RegisterStrategy(FollowTheStreakPB, func(s Settings, _ deck.Shuffler) Strategy {
+		return NewPatternStrategy(FollowTheStreak, newStake(s.PatternStake, s.Rules.MinimumBet))
+	})
+	RegisterStrategy(BetTheChopPB, func(s Settings, _ deck.Shuffler) Strategy {
+		return NewPatternStrategy(BetTheChop, newStake(s.PatternStake, s.Rules.MinimumBet))
+	})
+	RegisterStrategy(FollowBigRoadColumnPB, func(s Settings, _ deck.Shuffler) Strategy {
+		return NewPatternStrategy(FollowTheBigRoadColumn, newStake(s.PatternStake, s.Rules.MinimumBet))
+	})
+	RegisterStrategy(TwoInARowThenSwitchPB, func(s Settings, _ deck.Shuffler) Strategy {
+		return NewPatternStrategy(TwoInARowThenSwitch, newStake(s.PatternStake, s.Rules.MinimumBet))
+	})
+
+	// Cancellation betting strategies
+	RegisterStrategy(LabouchereOnPunto, func(s Settings, _ deck.Shuffler) Strategy {
+		return NewLabouchere(puntobanco.PuntoPlayer, s.Rules.MinimumBet, s.LabouchereLine)
+	})
+	RegisterStrategy(LabouchereOnBanco, func(s Settings, _ deck.Shuffler) Strategy {
+		return NewLabouchere(puntobanco.BancoBanker, s.Rules.MinimumBet, s.LabouchereLine)
+	})
+	RegisterStrategy(ReverseLabouchereOnPunto, func(s Settings, _ deck.Shuffler) Strategy {
+		return NewReverseLabouchere(puntobanco.PuntoPlayer, s.Rules.MinimumBet, s.LabouchereLine)
+	})
+	RegisterStrategy(ReverseLabouchereOnBanco, func(s Settings, _ deck.Shuffler) Strategy {
+		return NewReverseLabouchere(puntobanco.BancoBanker, s.Rules.MinimumBet, s.LabouchereLine)
+	})
+
+	// Card counting strategies
+	RegisterStrategy(CardCountPB, func(s Settings, _ deck.Shuffler) Strategy {
+		return NewPuntoBancoCount(s.Rules.MinimumBet)
+	})
+	RegisterStrategy(CardCountOnEgalite, func(s Settings, _ deck.Shuffler) Strategy {
+		return NewEgaliteCount(s.Rules.MinimumBet)
+	})
+
+	// Side betting strategies
+	RegisterStrategy(BancoWithEitherPair, func(s Settings, _ deck.Shuffler) Strategy {
+		return NewSideBetStrategy(NewFlatStrategy(puntobanco.BancoBanker, s.Rules.MinimumBet),
+			SideBet{Type: puntobanco.EitherPair, Amount: s.Rules.MinimumBet})
+	})
+	RegisterStrategy(BancoWithPerfectPair, func(s Settings, _ deck.Shuffler) Strategy {
+		return NewSideBetStrategy(NewFlatStrategy(puntobanco.BancoBanker, s.Rules.MinimumBet),
+			SideBet{Type: puntobanco.PerfectPair, Amount: s.Rules.MinimumBet})
+	})
+}
@@ -0,0 +1,118 @@
+package simulator
+
+import (
+	"github.com/adequatica/punto-banco-golango/internal/deck"
+	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
+)
+
+// Counting strategies sit out most coups, so their games end after this number of shoes unless the settings cap them.
+const DefaultCountingMaxShoes = 100
+
+// Points added to the running count for each card value seen, the index is the value of the card.
+// Both counts are balanced, so a full shoe counts to zero.
+type CountTags [10]int
+
+var (
+	// Effects of removal of each card on the edge of Banco from the exact analysis of 8 decks, scaled and rounded.
+	// A positive count favours Banco, a negative count favours Punto.
+	PuntoBancoCountTags = CountTags{1, 1, 2, 2, 4, -3, -4, -3, -2, -1}
+	// Effects of removal of each card on the edge of Égalité, a positive count favours Égalité
+	EgaliteCountTags = CountTags{4, 1, -2, -2, -2, -2, -9, -8, 5, 3}
+)
+
+// True counts at which the estimated edge of the bet turns in favour of the gambler,
+// a point of the true count moves the edge by about 0.022% for Punto and Banco and by about 0.105% for Égalité
+const (
+	BancoCountThreshold   = 48.0
+	PuntoCountThreshold   = -56.0
+	EgaliteCountThreshold = 137.0
+)
+
+// Strategies see every card dealt from the shoe, including the coups they sit out
+type CardObserver interface {
+	// A new shoe is started, burned cards are not seen
+	OnNewShoe(cards int)
+	// Cards of a dealt coup
+	OnCardsDealt(cards []deck.Card)
+}
+
+// Side of a counting strategy and the true count to bet on it,
+// a negative threshold is crossed by counts at or below it
+type countedBet struct {
+	side      puntobanco.BetType
+	threshold float64
+}
+
+func (b countedBet) isCrossed(trueCount float64) bool {
+	if b.threshold < 0 {
+		return trueCount <= b.threshold
+	}
+	return trueCount >= b.threshold
+}
+
+// Flat bet only when the true count of the shoe crosses a threshold, otherwise the coup is sat out
+type CountingStrategy struct {
+	tags         CountTags
+	bets         []countedBet
+	minimumBet   float64
+	runningCount int
+	cardsLeft    int
+}
+
+// Bet on Banco or Punto when the count favours one of them
+func NewPuntoBancoCount(minimumBet float64) *CountingStrategy {
+	return &CountingStrategy{
+		tags: PuntoBancoCountTags,
+		bets: []countedBet{
+			{side: puntobanco.BancoBanker, threshold: BancoCountThreshold},
+			{side: puntobanco.PuntoPlayer, threshold: PuntoCountThreshold},
+		},
+		minimumBet: minimumBet,
+	}
+}
+
+// Bet on Égalité when the count favours it
+func NewEgaliteCount(minimumBet float64) *CountingStrategy {
+	return &CountingStrategy{
+		tags:       EgaliteCountTags,
+		bets:       []countedBet{{side: puntobanco.EgaliteTie, threshold: EgaliteCountThreshold}},
+		minimumBet: minimumBet,
+	}
+}
+
+// Running count per deck left in the shoe
+func (c *CountingStrategy) TrueCount() float64 {
+	if c.cardsLeft <= 0 {
+		return 0
+	}
+	decksLeft := float64(c.cardsLeft) / float64(len(deck.Cards)*len(deck.Suits))
+	return float64(c.runningCount) / decksLeft
+}
+
+func (c *CountingStrategy) NextBet(history History) (puntobanco.BetType, float64) {
+	trueCount := c.TrueCount()
+	for _, bet := range c.bets {
+		if bet.isCrossed(trueCount) {
+			return bet.side, c.minimumBet
+		}
+	}
+	// Zero bet sits the coup out
+	return c.bets[0].side, 0
+}
+
+func (c *CountingStrategy) OnResult(result BetResult) {}
+
+// The count belongs to the shoe, so it is not reset with the progression
+func (c *CountingStrategy) Reset() {}
+
+func (c *CountingStrategy) OnNewShoe(cards int) {
+	c.runningCount = 0
+	c.cardsLeft = cards
+}
+
+func (c *CountingStrategy) OnCardsDealt(cards []deck.Card) {
+	for _, card := range cards {
+		c.runningCount += c.tags[card.Value]
+	}
+	c.cardsLeft -= len(cards)
+}
@@ -0,0 +1,144 @@
+package simulator
+
+import (
+	"testing"
+
+	"github.com/adequatica/punto-banco-golango/internal/analysis"
+	"github.com/adequatica/punto-banco-golango/internal/deck"
+	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
+	"github.com/adequatica/punto-banco-golango/internal/rules"
+)
+
+func TestCountTags_Balanced(t *testing.T) {
+	composition := analysis.NewComposition(1)
+
+	for name, tags := range map[string]CountTags{"Punto and Banco": PuntoBancoCountTags, "Égalité": EgaliteCountTags} {
+		count := 0
+		for value, cards := range composition {
+			count += tags[value] * cards
+		}
+		if count != 0 {
+			t.Errorf("%s count of a full deck = %d should be 0", name, count)
+		}
+	}
+}
+
+func TestCountTags_EffectsOfRemoval(t *testing.T) {
+	tableRules := rules.DefaultTableRules()
+	tableRules.NumberOfDecks = 8
+	full, err := analysis.Analyze(analysis.NewComposition(8), tableRules)
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+
+	// A card that lowers the edge of a bet when it is dealt should add to the count of the bet
+	for value := range len(PuntoBancoCountTags) {
+		composition := analysis.NewComposition(8)
+		composition[value]--
+		removed, err := analysis.Analyze(composition, tableRules)
+		if err != nil {
+			t.Fatalf("Analyze() error = %v", err)
+		}
+
+		bancoEffect := removed.BancoHouseEdge - full.BancoHouseEdge
+		if (bancoEffect < 0) != (PuntoBancoCountTags[value] > 0) {
+			t.Errorf("Banco tag of %d is %d, effect of removal is %.5f", value, PuntoBancoCountTags[value], bancoEffect)
+		}
+		egaliteEffect := removed.TieHouseEdge - full.TieHouseEdge
+		if (egaliteEffect < 0) != (EgaliteCountTags[value] > 0) {
+			t.Errorf("Égalité tag of %d is %d, effect of removal is %.5f", value, EgaliteCountTags[value], egaliteEffect)
+		}
+	}
+}
+
+// Cards of the same value for counting tests
+func cardsOfValue(value int, number int) []deck.Card {
+	cards := make([]deck.Card, number)
+	for i := range cards {
+		cards[i] = deck.Card{Value: value}
+	}
+	return cards
+}
+
+func TestCountingStrategy_TrueCount(t *testing.T) {
+	strategy := NewPuntoBancoCount(10.0)
+	if strategy.TrueCount() != 0 {
+		t.Errorf("TrueCount() before the first shoe = %f should be 0", strategy.TrueCount())
+	}
+
+	strategy.OnNewShoe(104)
+	strategy.OnCardsDealt(cardsOfValue(4, 6))
+	strategy.OnCardsDealt(cardsOfValue(6, 2))
+
+	// 6 fours and 2 sixes count 24 - 8 = 16 with 96 cards left, that is 16 / (96 / 52)
+	if got, want := strategy.TrueCount(), 16/(96.0/52); got != want {
+		t.Errorf("TrueCount() = %f should be %f", got, want)
+	}
+
+	strategy.OnNewShoe(312)
+	if strategy.TrueCount() != 0 {
+		t.Errorf("TrueCount() of a new shoe = %f should be 0", strategy.TrueCount())
+	}
+}
+
+func TestCountingStrategy_NextBet(t *testing.T) {
+	tests := []struct {
+		name          string
+		strategy      *CountingStrategy
+		dealt         []deck.Card
+		wantBetType   puntobanco.BetType
+		wantBetAmount float64
+	}{
+		{
+			name:          "neutral count sits out",
+			strategy:      NewPuntoBancoCount(10.0),
+			wantBetType:   puntobanco.BancoBanker,
+			wantBetAmount: 0,
+		},
+		{
+			name:     "small cards dealt favour Banco",
+			strategy: NewPuntoBancoCount(10.0),
+			// Count of 40 with 20 cards left is a true count of 104
+			dealt:         cardsOfValue(4, 10),
+			wantBetType:   puntobanco.BancoBanker,
+			wantBetAmount: 10.0,
+		},
+		{
+			name:          "large cards dealt favour Punto",
+			strategy:      NewPuntoBancoCount(10.0),
+			dealt:         cardsOfValue(6, 10),
+			wantBetType:   puntobanco.PuntoPlayer,
+			wantBetAmount: 10.0,
+		},
+		{
+			name:     "eights dealt favour Égalité",
+			strategy: NewEgaliteCount(10.0),
+			// Count of 60 with 18 cards left is a true count of 173
+			dealt:         cardsOfValue(8, 12),
+			wantBetType:   puntobanco.EgaliteTie,
+			wantBetAmount: 10.0,
+		},
+		{
+			name:          "sixes dealt sit out Égalité",
+			strategy:      NewEgaliteCount(10.0),
+			dealt:         cardsOfValue(6, 10),
+			wantBetType:   puntobanco.EgaliteTie,
+			wantBetAmount: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.strategy.OnNewShoe(30)
+			tt.strategy.OnCardsDealt(tt.dealt)
+
+			betType, betAmount := tt.strategy.NextBet(History{})
+			if betType != tt.wantBetType {
+				t.Errorf("NextBet() bet type = %v, want %v", betType, tt.wantBetType)
+			}
+			if betAmount != tt.wantBetAmount {
+				t.Errorf("NextBet() bet amount = %.2f, want %.2f", betAmount, tt.wantBetAmount)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package simulator
+
+import (
+	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
+)
+
+// Increase the bet by one unit after a loss and decrease it by one unit after a win
+type DAlembert struct {
+	side              puntobanco.BetType
+	minimumBet        float64
+	BetAmount         float64
+	DAlembertUnitSize float64 // Base unit size for D'Alembert progression
+	DAlembertLevel    int     // Current level in D'Alembert progression
+}
+
+func NewDAlembert(side puntobanco.BetType, minimumBet float64) *DAlembert {
+	return &DAlembert{
+		side:              side,
+		minimumBet:        minimumBet,
+		BetAmount:         minimumBet,
+		DAlembertUnitSize: minimumBet,
+	}
+}
+
+func (d *DAlembert) NextBet(history History) (puntobanco.BetType, float64) {
+	return d.side, d.BetAmount
+}
+
+func (d *DAlembert) OnResult(result BetResult) {
+	switch result.Outcome {
+	case OutcomeWin:
+		if d.DAlembertLevel > 0 {
+			d.DAlembertLevel--
+		}
+		// Calculate new bet amount: base + (level * unit size)
+		newBetAmount := d.DAlembertUnitSize + (float64(d.DAlembertLevel) * d.DAlembertUnitSize)
+		// Ensure bet doesn't go below minimum bet
+		if newBetAmount < d.minimumBet {
+			newBetAmount = d.minimumBet
+			d.DAlembertLevel = 0
+		}
+		d.BetAmount = newBetAmount
+
+	case OutcomeLoss:
+		d.DAlembertLevel++
+		// Calculate new bet amount: base + (level * unit size)
+		d.BetAmount = d.DAlembertUnitSize + (float64(d.DAlembertLevel) * d.DAlembertUnitSize)
+	}
+}
+
+func (d *DAlembert) Reset() {
+	d.DAlembertLevel = 0
+	d.BetAmount = d.DAlembertUnitSize
+}
@@ -0,0 +1,55 @@
+package simulator
+
+import (
+	"testing"
+
+	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
+)
+
+func TestDAlembert(t *testing.T) {
+	tests := []struct {
+		name          string
+		outcomes      []Outcome
+		wantLevel     int
+		wantBetAmount float64
+	}{
+		{
+			name:          "loss adds one unit",
+			outcomes:      []Outcome{OutcomeLoss},
+			wantLevel:     1,
+			wantBetAmount: 20.0,
+		},
+		{
+			name:          "win removes one unit",
+			outcomes:      []Outcome{OutcomeLoss, OutcomeLoss, OutcomeWin},
+			wantLevel:     1,
+			wantBetAmount: 20.0,
+		},
+		{
+			name:          "bet does not go below the base unit",
+			outcomes:      []Outcome{OutcomeWin, OutcomeWin},
+			wantLevel:     0,
+			wantBetAmount: 10.0,
+		},
+		{
+			name:          "push keeps the level",
+			outcomes:      []Outcome{OutcomeLoss, OutcomePush},
+			wantLevel:     1,
+			wantBetAmount: 20.0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy := NewDAlembert(puntobanco.BancoBanker, 10.0)
+			playOutcomes(strategy, tt.outcomes)
+
+			if strategy.DAlembertLevel != tt.wantLevel {
+				t.Errorf("DAlembertLevel = %d, want %d", strategy.DAlembertLevel, tt.wantLevel)
+			}
+			if _, betAmount := strategy.NextBet(History{}); betAmount != tt.wantBetAmount {
+				t.Errorf("NextBet() bet amount = %.2f, want %.2f", betAmount, tt.wantBetAmount)
+			}
+		})
+	}
+}
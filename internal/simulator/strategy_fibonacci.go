@@ -0,0 +1,94 @@
+package simulator
+
+import (
+	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
+)
+
+// Fibonacci sequence 0, 1, 1, 2, 3, 5, 8, 13, 21, 34, 55, 89, 144, ...
+func GetFibonacciValue(index int) int {
+	if index <= 0 {
+		return 1
+	}
+	if index == 1 {
+		return 1
+	}
+	// Prevent memory exhaustion
+	if index > 100000 {
+		return 1
+	}
+
+	// Calculation for larger indices
+	fib := make([]int, index+1)
+	fib[0] = 1
+	fib[1] = 1
+
+	for i := 2; i <= index; i++ {
+		fib[i] = fib[i-1] + fib[i-2]
+	}
+
+	return fib[index]
+}
+
+// Move one number forward in the Fibonacci sequence after a loss and two numbers back after a win
+type Fibonacci struct {
+	side                   puntobanco.BetType
+	minimumBet             float64
+	BetAmount              float64
+	FibonacciSequenceIndex int     // Current position in Fibonacci sequence (0=based)
+	FibonacciProfit        float64 // Current profit in wager units (1 unit = MinimumBet)
+}
+
+func NewFibonacci(side puntobanco.BetType, minimumBet float64) *Fibonacci {
+	return &Fibonacci{
+		side:       side,
+		minimumBet: minimumBet,
+		BetAmount:  minimumBet,
+	}
+}
+
+func (f *Fibonacci) NextBet(history History) (puntobanco.BetType, float64) {
+	return f.side, f.BetAmount
+}
+
+func (f *Fibonacci) OnResult(result BetResult) {
+	switch result.Outcome {
+	case OutcomeWin:
+		// Calculate profit in wager units (1 unit = minimumBet)
+		payoutUnits := result.Payout / f.minimumBet
+		f.FibonacciProfit += payoutUnits
+
+		// Move back two places in the Fibonacci sequence
+		if f.FibonacciSequenceIndex >= 2 {
+			f.FibonacciSequenceIndex -= 2
+		} else {
+			f.FibonacciSequenceIndex = 0
+		}
+
+		// Calculate new bet amount based on Fibonacci sequence
+		fibValue := GetFibonacciValue(f.FibonacciSequenceIndex)
+		f.BetAmount = float64(fibValue) * f.minimumBet
+
+		// Reset if profit reaches +1 wager unit
+		if f.FibonacciProfit >= 1.0 {
+			f.Reset()
+		}
+
+	case OutcomeLoss:
+		// Calculate loss in wager units (1 unit = minimumBet)
+		lossUnits := result.BetAmount / f.minimumBet
+		f.FibonacciProfit -= lossUnits
+
+		// Move to next number in the Fibonacci sequence
+		f.FibonacciSequenceIndex++
+
+		// Calculate new bet amount based on Fibonacci sequence
+		fibValue := GetFibonacciValue(f.FibonacciSequenceIndex)
+		f.BetAmount = float64(fibValue) * f.minimumBet
+	}
+}
+
+func (f *Fibonacci) Reset() {
+	f.FibonacciSequenceIndex = 0
+	f.FibonacciProfit = 0.0
+	f.BetAmount = f.minimumBet
+}
@@ -0,0 +1,132 @@
+package simulator
+
+import (
+	"testing"
+
+	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
+	"github.com/adequatica/punto-banco-golango/internal/rules"
+)
+
+func TestGetFibonacciValue(t *testing.T) {
+	tests := []struct {
+		index int
+		want  int
+		name  string
+	}{
+		{0, 1, "Fibonacci(0) should be 1"},
+		{1, 1, "Fibonacci(1) should be 1"},
+		{2, 2, "Fibonacci(2) should be 2"},
+		{3, 3, "Fibonacci(3) should be 3"},
+		{4, 5, "Fibonacci(4) should be 5"},
+		{5, 8, "Fibonacci(5) should be 8"},
+		{6, 13, "Fibonacci(6) should be 13"},
+		{7, 21, "Fibonacci(7) should be 21"},
+		{8, 34, "Fibonacci(8) should be 34"},
+		{9, 55, "Fibonacci(9) should be 55"},
+		{10, 89, "Fibonacci(10) should be 89"},
+		{11, 144, "Fibonacci(11) should be 144"},
+		{12, 233, "Fibonacci(12) should be 233"},
+		{13, 377, "Fibonacci(13) should be 377"},
+		{14, 610, "Fibonacci(14) should be 610"},
+		{15, 987, "Fibonacci(15) should be 987"},
+		{-1, 1, "Fibonacci(-1) should be 1 (handles negative)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := GetFibonacciValue(tt.index)
+			if result != tt.want {
+				t.Errorf("GetFibonacciValue(%d) = %d should be %d", tt.index, result, tt.want)
+			}
+		})
+	}
+}
+
+func TestFibonacci_Win(t *testing.T) {
+	tests := []struct {
+		name    string
+		betType puntobanco.BetType
+	}{
+		{
+			name:    "Fibonacci on Punto",
+			betType: puntobanco.PuntoPlayer,
+		},
+		{
+			name:    "Fibonacci on Banco",
+			betType: puntobanco.BancoBanker,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy := NewFibonacci(tt.betType, rules.DefaultMinimumBet)
+
+			// Precondition of Fibonacci sequence position with enough negative profit to avoid reset
+			strategy.FibonacciSequenceIndex = 5                                          // This corresponds to fib(5) = 8
+			strategy.BetAmount = float64(GetFibonacciValue(5)) * rules.DefaultMinimumBet // Calculate bet amount based on Fibonacci sequence
+			strategy.FibonacciProfit = -10.0                                             // Significant losses to avoid reset
+
+			strategy.OnResult(winResult(tt.betType, strategy.BetAmount))
+
+			if strategy.FibonacciSequenceIndex != 3 {
+				t.Errorf("Fibonacci sequence should move back by 2 after win: got %d, want 3", strategy.FibonacciSequenceIndex)
+			}
+
+			originalBetAmount := float64(GetFibonacciValue(5)) * rules.DefaultMinimumBet
+			expectedPayout := CalculatePayout(tt.betType, originalBetAmount, rules.DefaultTableRules())
+			expectedProfit := -10.0 + expectedPayout/rules.DefaultMinimumBet
+			if strategy.FibonacciProfit != expectedProfit {
+				t.Errorf("Fibonacci profit should update: got %.2f, want %.2f", strategy.FibonacciProfit, expectedProfit)
+			}
+
+			expectedBetAmount := float64(GetFibonacciValue(3)) * rules.DefaultMinimumBet
+			if strategy.BetAmount != expectedBetAmount {
+				t.Errorf("BetAmount should update based on new sequence position: got %.2f, want %.2f", strategy.BetAmount, expectedBetAmount)
+			}
+
+			strategy.FibonacciSequenceIndex = 1
+			strategy.BetAmount = float64(GetFibonacciValue(1)) * rules.DefaultMinimumBet
+			strategy.OnResult(winResult(tt.betType, strategy.BetAmount))
+			if strategy.FibonacciSequenceIndex != 0 {
+				t.Errorf("Fibonacci sequence index should not go below 0: got %d, want 0", strategy.FibonacciSequenceIndex)
+			}
+		})
+	}
+}
+
+func TestFibonacci_ProfitReset(t *testing.T) {
+	strategy := NewFibonacci(puntobanco.PuntoPlayer, rules.DefaultMinimumBet)
+	strategy.FibonacciProfit = 0.9
+	strategy.FibonacciSequenceIndex = 2
+	strategy.BetAmount = float64(GetFibonacciValue(2)) * rules.DefaultMinimumBet
+
+	strategy.OnResult(winResult(puntobanco.PuntoPlayer, strategy.BetAmount))
+
+	if strategy.FibonacciSequenceIndex != 0 {
+		t.Errorf("Fibonacci sequence should reset when profit >= 1.0: got %d, want 0", strategy.FibonacciSequenceIndex)
+	}
+	if strategy.FibonacciProfit != 0.0 {
+		t.Errorf("Fibonacci profit should reset: got %.2f, want 0.0", strategy.FibonacciProfit)
+	}
+	if strategy.BetAmount != rules.DefaultMinimumBet {
+		t.Errorf("BetAmount should reset to minimum when profit >= 1.0: got %.2f, want %.2f", strategy.BetAmount, rules.DefaultMinimumBet)
+	}
+}
+
+func TestFibonacci_Loss(t *testing.T) {
+	strategy := NewFibonacci(puntobanco.BancoBanker, rules.DefaultMinimumBet)
+
+	playOutcomes(strategy, []Outcome{OutcomeLoss, OutcomeLoss, OutcomeLoss})
+
+	if strategy.FibonacciSequenceIndex != 3 {
+		t.Errorf("Fibonacci sequence should move forward after each loss: got %d, want 3", strategy.FibonacciSequenceIndex)
+	}
+	// Lost 1 + 1 + 2 units
+	if strategy.FibonacciProfit != -4.0 {
+		t.Errorf("Fibonacci profit should update: got %.2f, want -4.00", strategy.FibonacciProfit)
+	}
+	expectedBetAmount := float64(GetFibonacciValue(3)) * rules.DefaultMinimumBet
+	if strategy.BetAmount != expectedBetAmount {
+		t.Errorf("BetAmount should update based on new sequence position: got %.2f, want %.2f", strategy.BetAmount, expectedBetAmount)
+	}
+}
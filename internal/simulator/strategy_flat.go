@@ -0,0 +1,80 @@
+package simulator
+
+import (
+	"github.com/adequatica/punto-banco-golango/internal/deck"
+	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
+)
+
+// Always the same bet on the same side
+type FlatStrategy struct {
+	side       puntobanco.BetType
+	minimumBet float64
+}
+
+func NewFlatStrategy(side puntobanco.BetType, minimumBet float64) *FlatStrategy {
+	return &FlatStrategy{side: side, minimumBet: minimumBet}
+}
+
+func (f *FlatStrategy) NextBet(history History) (puntobanco.BetType, float64) {
+	return f.side, f.minimumBet
+}
+
+func (f *FlatStrategy) OnResult(result BetResult) {}
+
+func (f *FlatStrategy) Reset() {}
+
+func GetOnlyPuntoBanco(lastWinningHand puntobanco.BetType) puntobanco.BetType {
+	if lastWinningHand == puntobanco.EgaliteTie {
+		// Bet on Banco to maximize chance of winning
+		return puntobanco.BancoBanker
+	} else {
+		return lastWinningHand
+	}
+}
+
+// Flat bet on the side of the last winning hand
+type LastHandStrategy struct {
+	onlyPuntoBanco bool
+	minimumBet     float64
+}
+
+func NewLastHandStrategy(onlyPuntoBanco bool, minimumBet float64) *LastHandStrategy {
+	return &LastHandStrategy{onlyPuntoBanco: onlyPuntoBanco, minimumBet: minimumBet}
+}
+
+func (l *LastHandStrategy) NextBet(history History) (puntobanco.BetType, float64) {
+	if l.onlyPuntoBanco {
+		return GetOnlyPuntoBanco(history.LastWinningHand), l.minimumBet
+	}
+	return history.LastWinningHand, l.minimumBet
+}
+
+func (l *LastHandStrategy) OnResult(result BetResult) {}
+
+func (l *LastHandStrategy) Reset() {}
+
+func GetRandomBetType(random deck.Shuffler) puntobanco.BetType {
+	if random.Intn(2) == 0 {
+		return puntobanco.PuntoPlayer
+	} else {
+		return puntobanco.BancoBanker
+	}
+}
+
+// Flat bet on Punto or Banco chosen at random
+type RandomStrategy struct {
+	random     deck.Shuffler
+	minimumBet float64
+}
+
+func NewRandomStrategy(random deck.Shuffler, minimumBet float64) *RandomStrategy {
+	return &RandomStrategy{random: random, minimumBet: minimumBet}
+}
+
+func (r *RandomStrategy) NextBet(history History) (puntobanco.BetType, float64) {
+	return GetRandomBetType(r.random), r.minimumBet
+}
+
+func (r *RandomStrategy) OnResult(result BetResult) {}
+
+func (r *RandomStrategy) Reset() {}
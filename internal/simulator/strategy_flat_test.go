@@ -0,0 +1,77 @@
+package simulator
+
+import (
+	"testing"
+
+	"github.com/adequatica/punto-banco-golango/internal/deck"
+	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
+)
+
+func TestGetRandomBetType(t *testing.T) {
+	result := GetRandomBetType(deck.NewShuffler(deck.NewRandomSeed()))
+
+	if result != puntobanco.PuntoPlayer && result != puntobanco.BancoBanker {
+		t.Errorf("GetRandomBetType() returned unexpected value: %s", result)
+	}
+}
+
+func TestGetOnlyPuntoBanco(t *testing.T) {
+	tests := []struct {
+		name            string
+		lastWinningHand puntobanco.BetType
+		want            puntobanco.BetType
+	}{
+		{
+			name:            "if last winning hand is PuntoPlayer, return PuntoPlayer",
+			lastWinningHand: puntobanco.PuntoPlayer,
+			want:            puntobanco.PuntoPlayer,
+		},
+		{
+			name:            "if last winning hand is BancoBanker, return BancoBanker",
+			lastWinningHand: puntobanco.BancoBanker,
+			want:            puntobanco.BancoBanker,
+		},
+		{
+			name:            "if last winning hand is EgaliteTie, return BancoBanker",
+			lastWinningHand: puntobanco.EgaliteTie,
+			want:            puntobanco.BancoBanker,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetOnlyPuntoBanco(tt.lastWinningHand)
+			if got != tt.want {
+				t.Errorf("GetOnlyPuntoBanco(%v) = %v, want %v", tt.lastWinningHand, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLastHandStrategy(t *testing.T) {
+	strategy := NewLastHandStrategy(false, 10.0)
+
+	for _, lastWinningHand := range []puntobanco.BetType{puntobanco.BancoBanker, puntobanco.PuntoPlayer, puntobanco.EgaliteTie} {
+		betType, betAmount := strategy.NextBet(History{LastWinningHand: lastWinningHand})
+		if betType != lastWinningHand {
+			t.Errorf("NextBet() bet type = %v, want %v", betType, lastWinningHand)
+		}
+		if betAmount != 10.0 {
+			t.Errorf("NextBet() bet amount = %.2f, want 10.00", betAmount)
+		}
+		strategy.OnResult(lossResult(betType, betAmount))
+	}
+}
+
+func TestFlatStrategy_KeepsBet(t *testing.T) {
+	strategy := NewFlatStrategy(puntobanco.BancoBanker, 10.0)
+
+	strategy.OnResult(lossResult(puntobanco.BancoBanker, 10.0))
+	strategy.OnResult(lossResult(puntobanco.BancoBanker, 10.0))
+	strategy.OnResult(winResult(puntobanco.BancoBanker, 10.0))
+
+	betType, betAmount := strategy.NextBet(History{})
+	if betType != puntobanco.BancoBanker || betAmount != 10.0 {
+		t.Errorf("NextBet() = %v %.2f should be %v 10.00", betType, betAmount, puntobanco.BancoBanker)
+	}
+}
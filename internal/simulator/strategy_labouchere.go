@@ -0,0 +1,158 @@
+package simulator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
+)
+
+// Tolerance for fractions of a unit left by Banco commission
+const lineEpsilon = 1e-9
+
+func DefaultLabouchereLine() []int {
+	return []int{1, 2, 3, 4}
+}
+
+// Parse a Labouchère line written as units separated by dashes, e.g. "1-2-3-4"
+func ParseLabouchereLine(line string) ([]int, error) {
+	parts := strings.Split(strings.TrimSpace(line), "-")
+	units := make([]int, 0, len(parts))
+	for _, part := range parts {
+		unit, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse Labouchère line %q: %w", line, err)
+		}
+		units = append(units, unit)
+	}
+
+	if err := validateLabouchereLine(units); err != nil {
+		return nil, err
+	}
+
+	return units, nil
+}
+
+func FormatLabouchereLine(line []int) string {
+	parts := make([]string, 0, len(line))
+	for _, unit := range line {
+		parts = append(parts, strconv.Itoa(unit))
+	}
+	return strings.Join(parts, "-")
+}
+
+func validateLabouchereLine(line []int) error {
+	if len(line) == 0 {
+		return fmt.Errorf("Labouchère line should have at least one number")
+	}
+	for _, unit := range line {
+		if unit < 1 {
+			return fmt.Errorf("Labouchère line should have only positive numbers, got %d", unit)
+		}
+	}
+
+	return nil
+}
+
+// Cancellation system: bet the sum of the first and the last numbers of the line.
+// Labouchère crosses the numbers off after a win and adds the lost bet to the line after a loss,
+// reverse Labouchère does the opposite. The cycle starts over once the line is crossed off.
+type Labouchere struct {
+	side         puntobanco.BetType
+	minimumBet   float64
+	reverse      bool
+	startingLine []int
+	Line         []float64 // Numbers of the line in wager units (1 unit = MinimumBet)
+}
+
+func NewLabouchere(side puntobanco.BetType, minimumBet float64, line []int) *Labouchere {
+	l := &Labouchere{
+		side:         side,
+		minimumBet:   minimumBet,
+		startingLine: append([]int(nil), line...),
+	}
+	l.Reset()
+	return l
+}
+
+func NewReverseLabouchere(side puntobanco.BetType, minimumBet float64, line []int) *Labouchere {
+	l := NewLabouchere(side, minimumBet, line)
+	l.reverse = true
+	return l
+}
+
+func (l *Labouchere) NextBet(history History) (puntobanco.BetType, float64) {
+	units := 0.0
+	switch len(l.Line) {
+	case 0:
+	case 1:
+		units = l.Line[0]
+	default:
+		units = l.Line[0] + l.Line[len(l.Line)-1]
+	}
+
+	// A fraction of a unit left by Banco commission is still bet with at least the minimum bet
+	if units < 1 {
+		units = 1
+	}
+
+	return l.side, units * l.minimumBet
+}
+
+func (l *Labouchere) OnResult(result BetResult) {
+	switch result.Outcome {
+	case OutcomeWin:
+		// Banco wins pay 0.95 units per unit, so the net win does not always cover both numbers
+		winUnits := result.Payout / l.minimumBet
+		if l.reverse {
+			l.Line = append(l.Line, winUnits)
+		} else {
+			l.crossOff(winUnits)
+		}
+	case OutcomeLoss:
+		lossUnits := result.BetAmount / l.minimumBet
+		if l.reverse {
+			l.crossOff(lossUnits)
+		} else {
+			l.Line = append(l.Line, lossUnits)
+		}
+	}
+
+	// Line is crossed off: the cycle is complete
+	if len(l.Line) == 0 {
+		l.Reset()
+	}
+}
+
+func (l *Labouchere) Reset() {
+	l.Line = make([]float64, 0, len(l.startingLine))
+	for _, unit := range l.startingLine {
+		l.Line = append(l.Line, float64(unit))
+	}
+}
+
+func (l *Labouchere) LineLength() int {
+	return len(l.Line)
+}
+
+// Cross off the numbers from both ends of the line, first, last, first, and so on.
+// A number which is not covered completely is reduced by the rest of the amount.
+func (l *Labouchere) crossOff(units float64) {
+	fromFront := true
+	for units > lineEpsilon && len(l.Line) > 0 {
+		i := len(l.Line) - 1
+		if fromFront {
+			i = 0
+		}
+
+		if l.Line[i] <= units+lineEpsilon {
+			units -= l.Line[i]
+			l.Line = append(l.Line[:i], l.Line[i+1:]...)
+		} else {
+			l.Line[i] -= units
+			units = 0
+		}
+		fromFront = !fromFront
+	}
+}
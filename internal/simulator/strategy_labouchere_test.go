@@ -0,0 +1,192 @@
+package simulator
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
+)
+
+func TestParseLabouchereLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		want    []int
+		wantErr bool
+	}{
+		{"default line", "1-2-3-4", []int{1, 2, 3, 4}, false},
+		{"spaces around numbers", " 2 - 2 - 2 ", []int{2, 2, 2}, false},
+		{"single number", "5", []int{5}, false},
+		{"empty line", "", nil, true},
+		{"zero unit", "1-0-1", nil, true},
+		{"not a number", "1-two-3", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseLabouchereLine(tt.line)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLabouchereLine(%q) error = %v, wantErr %v", tt.line, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(result, tt.want) {
+				t.Errorf("ParseLabouchereLine(%q) = %v should be %v", tt.line, result, tt.want)
+			}
+		})
+	}
+
+	if result := FormatLabouchereLine(DefaultLabouchereLine()); result != "1-2-3-4" {
+		t.Errorf("FormatLabouchereLine() = %q should be \"1-2-3-4\"", result)
+	}
+}
+
+func TestLabouchere(t *testing.T) {
+	tests := []struct {
+		name          string
+		side          puntobanco.BetType
+		reverse       bool
+		outcomes      []Outcome
+		wantLine      []float64
+		wantBetAmount float64
+	}{
+		{
+			name:          "first bet is the sum of the first and the last numbers",
+			side:          puntobanco.PuntoPlayer,
+			wantLine:      []float64{1, 2, 3, 4},
+			wantBetAmount: 50.0,
+		},
+		{
+			name:          "Punto win crosses off both numbers",
+			side:          puntobanco.PuntoPlayer,
+			outcomes:      []Outcome{OutcomeWin},
+			wantLine:      []float64{2, 3},
+			wantBetAmount: 50.0,
+		},
+		{
+			name:          "Banco win leaves the rest of the last number",
+			side:          puntobanco.BancoBanker,
+			outcomes:      []Outcome{OutcomeWin},
+			wantLine:      []float64{2, 3, 0.25},
+			wantBetAmount: 22.5,
+		},
+		{
+			name:          "loss adds the lost bet to the line",
+			side:          puntobanco.PuntoPlayer,
+			outcomes:      []Outcome{OutcomeLoss},
+			wantLine:      []float64{1, 2, 3, 4, 5},
+			wantBetAmount: 60.0,
+		},
+		{
+			name:          "crossed off line starts over",
+			side:          puntobanco.PuntoPlayer,
+			outcomes:      []Outcome{OutcomeWin, OutcomeWin},
+			wantLine:      []float64{1, 2, 3, 4},
+			wantBetAmount: 50.0,
+		},
+		{
+			name:          "push keeps the line",
+			side:          puntobanco.BancoBanker,
+			outcomes:      []Outcome{OutcomeLoss, OutcomePush},
+			wantLine:      []float64{1, 2, 3, 4, 5},
+			wantBetAmount: 60.0,
+		},
+		{
+			name:          "reverse Punto win adds the winnings to the line",
+			side:          puntobanco.PuntoPlayer,
+			reverse:       true,
+			outcomes:      []Outcome{OutcomeWin},
+			wantLine:      []float64{1, 2, 3, 4, 5},
+			wantBetAmount: 60.0,
+		},
+		{
+			name:          "reverse Banco win adds the winnings after commission",
+			side:          puntobanco.BancoBanker,
+			reverse:       true,
+			outcomes:      []Outcome{OutcomeWin},
+			wantLine:      []float64{1, 2, 3, 4, 4.75},
+			wantBetAmount: 57.5,
+		},
+		{
+			name:          "reverse loss crosses off both numbers",
+			side:          puntobanco.BancoBanker,
+			reverse:       true,
+			outcomes:      []Outcome{OutcomeLoss},
+			wantLine:      []float64{2, 3},
+			wantBetAmount: 50.0,
+		},
+		{
+			name:          "reverse line crossed off by losses starts over",
+			side:          puntobanco.PuntoPlayer,
+			reverse:       true,
+			outcomes:      []Outcome{OutcomeLoss, OutcomeLoss},
+			wantLine:      []float64{1, 2, 3, 4},
+			wantBetAmount: 50.0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy := NewLabouchere(tt.side, 10.0, DefaultLabouchereLine())
+			if tt.reverse {
+				strategy = NewReverseLabouchere(tt.side, 10.0, DefaultLabouchereLine())
+			}
+			playOutcomes(strategy, tt.outcomes)
+
+			if !equalLines(strategy.Line, tt.wantLine) {
+				t.Errorf("Line = %v should be %v", strategy.Line, tt.wantLine)
+			}
+			if strategy.LineLength() != len(tt.wantLine) {
+				t.Errorf("LineLength() = %d should be %d", strategy.LineLength(), len(tt.wantLine))
+			}
+
+			betType, betAmount := strategy.NextBet(History{})
+			if betType != tt.side {
+				t.Errorf("NextBet() bet type = %v, want %v", betType, tt.side)
+			}
+			if math.Abs(betAmount-tt.wantBetAmount) > lineEpsilon {
+				t.Errorf("NextBet() bet amount = %.2f, want %.2f", betAmount, tt.wantBetAmount)
+			}
+		})
+	}
+}
+
+func TestLabouchere_MinimumBet(t *testing.T) {
+	strategy := NewLabouchere(puntobanco.BancoBanker, 10.0, []int{1})
+	strategy.Line = []float64{0.25}
+
+	// A fraction of a unit is bet with the minimum bet
+	_, betAmount := strategy.NextBet(History{})
+	if betAmount != 10.0 {
+		t.Errorf("NextBet() bet amount = %.2f, want 10.00", betAmount)
+	}
+
+	// The winnings of the minimum bet cross off the fraction and complete the cycle
+	strategy.OnResult(winResult(puntobanco.BancoBanker, betAmount))
+	if !equalLines(strategy.Line, []float64{1}) {
+		t.Errorf("Line = %v should start over", strategy.Line)
+	}
+}
+
+func TestLabouchere_StartingLineIsCopied(t *testing.T) {
+	line := []int{1, 2, 3}
+	strategy := NewLabouchere(puntobanco.PuntoPlayer, 10.0, line)
+	line[0] = 10
+
+	strategy.OnResult(lossResult(puntobanco.PuntoPlayer, 40.0))
+	strategy.Reset()
+	if !equalLines(strategy.Line, []float64{1, 2, 3}) {
+		t.Errorf("Line = %v should not be affected by changes of the settings", strategy.Line)
+	}
+}
+
+func equalLines(line []float64, want []float64) bool {
+	if len(line) != len(want) {
+		return false
+	}
+	for i := range line {
+		if math.Abs(line[i]-want[i]) > lineEpsilon {
+			return false
+		}
+	}
+	return true
+}
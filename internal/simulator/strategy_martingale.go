@@ -0,0 +1,39 @@
+package simulator
+
+import (
+	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
+)
+
+// Double the bet after each loss until a win returns the bet to the base
+type Martingale struct {
+	side          puntobanco.BetType
+	BaseBetAmount float64
+	BetAmount     float64
+}
+
+func NewMartingale(side puntobanco.BetType, minimumBet float64) *Martingale {
+	return &Martingale{
+		side:          side,
+		BaseBetAmount: minimumBet,
+		BetAmount:     minimumBet,
+	}
+}
+
+func (m *Martingale) NextBet(history History) (puntobanco.BetType, float64) {
+	return m.side, m.BetAmount
+}
+
+func (m *Martingale) OnResult(result BetResult) {
+	switch result.Outcome {
+	case OutcomeWin:
+		// Once you win a hand, you return to your original bet unit and start the process over again
+		m.BetAmount = m.BaseBetAmount
+	case OutcomeLoss:
+		// You continue doubling your bet after each loss until you win a hand
+		m.BetAmount = m.BetAmount * 2
+	}
+}
+
+func (m *Martingale) Reset() {
+	m.BetAmount = m.BaseBetAmount
+}
@@ -0,0 +1,51 @@
+package simulator
+
+import (
+	"testing"
+
+	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
+)
+
+func TestMartingale(t *testing.T) {
+	tests := []struct {
+		name          string
+		outcomes      []Outcome
+		wantBetAmount float64
+	}{
+		{
+			name:          "loss doubles the bet",
+			outcomes:      []Outcome{OutcomeLoss},
+			wantBetAmount: 20.0,
+		},
+		{
+			name:          "three losses in a row",
+			outcomes:      []Outcome{OutcomeLoss, OutcomeLoss, OutcomeLoss},
+			wantBetAmount: 80.0,
+		},
+		{
+			name:          "win returns the bet to the base",
+			outcomes:      []Outcome{OutcomeLoss, OutcomeLoss, OutcomeWin},
+			wantBetAmount: 10.0,
+		},
+		{
+			name:          "push keeps the bet",
+			outcomes:      []Outcome{OutcomeLoss, OutcomePush},
+			wantBetAmount: 20.0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy := NewMartingale(puntobanco.BancoBanker, 10.0)
+			playOutcomes(strategy, tt.outcomes)
+
+			betType, betAmount := strategy.NextBet(History{})
+			if betType != puntobanco.BancoBanker {
+				t.Errorf("NextBet() bet type = %v, want %v", betType, puntobanco.BancoBanker)
+			}
+			if betAmount != tt.wantBetAmount {
+				t.Errorf("NextBet() bet amount = %.2f, want %.2f", betAmount, tt.wantBetAmount)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package simulator
+
+import (
+	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
+)
+
+func GetOneThreeTwoSixValue(index int) int {
+	sequence := []int{1, 3, 2, 6}
+	if index < 0 || index >= len(sequence) {
+		return 1 // Default to 1 unit if index is out of bounds
+	}
+	return sequence[index]
+}
+
+// Bet 1, 3, 2 and 6 units on consecutive wins and start over after a loss or the last step
+type OneThreeTwoSix struct {
+	side                        puntobanco.BetType
+	minimumBet                  float64
+	BetAmount                   float64
+	OneThreeTwoSixSequenceIndex int // Current position in 1-3-2-6 sequence (0=1, 1=3, 2=2, 3=6)
+}
+
+func NewOneThreeTwoSix(side puntobanco.BetType, minimumBet float64) *OneThreeTwoSix {
+	return &OneThreeTwoSix{
+		side:       side,
+		minimumBet: minimumBet,
+		BetAmount:  minimumBet,
+	}
+}
+
+func (o *OneThreeTwoSix) NextBet(history History) (puntobanco.BetType, float64) {
+	return o.side, o.BetAmount
+}
+
+func (o *OneThreeTwoSix) OnResult(result BetResult) {
+	switch result.Outcome {
+	case OutcomeWin:
+		// Move to next position in the sequence
+		o.OneThreeTwoSixSequenceIndex++
+
+		// Reset to start if the sequence is completed (reached index 3)
+		if o.OneThreeTwoSixSequenceIndex >= 4 {
+			o.OneThreeTwoSixSequenceIndex = 0
+		}
+
+		// Calculate new bet amount based on current position in sequence
+		sequenceValue := GetOneThreeTwoSixValue(o.OneThreeTwoSixSequenceIndex)
+		o.BetAmount = float64(sequenceValue) * o.minimumBet
+
+	case OutcomeLoss:
+		// Reset to the beginning of the sequence (1 unit)
+		o.Reset()
+	}
+}
+
+func (o *OneThreeTwoSix) Reset() {
+	o.OneThreeTwoSixSequenceIndex = 0
+	o.BetAmount = o.minimumBet
+}
@@ -0,0 +1,91 @@
+package simulator
+
+import (
+	"testing"
+
+	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
+)
+
+func TestGetOneThreeTwoSixValue(t *testing.T) {
+	tests := []struct {
+		index int
+		want  int
+		name  string
+	}{
+		{0, 1, "1-3-2-6(0) should be 1"},
+		{1, 3, "1-3-2-6(1) should be 3"},
+		{2, 2, "1-3-2-6(2) should be 2"},
+		{3, 6, "1-3-2-6(3) should be 6"},
+		{4, 1, "1-3-2-6(4) should be 1 (out of bounds)"},
+		{-1, 1, "1-3-2-6(-1) should be 1 (handles negative)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := GetOneThreeTwoSixValue(tt.index)
+			if result != tt.want {
+				t.Errorf("GetOneThreeTwoSixValue(%d) = %d should be %d", tt.index, result, tt.want)
+			}
+		})
+	}
+}
+
+func TestOneThreeTwoSix(t *testing.T) {
+	tests := []struct {
+		name          string
+		outcomes      []Outcome
+		wantIndex     int
+		wantBetAmount float64
+	}{
+		{
+			name:          "first win bets 3 units",
+			outcomes:      []Outcome{OutcomeWin},
+			wantIndex:     1,
+			wantBetAmount: 30.0,
+		},
+		{
+			name:          "second win bets 2 units",
+			outcomes:      []Outcome{OutcomeWin, OutcomeWin},
+			wantIndex:     2,
+			wantBetAmount: 20.0,
+		},
+		{
+			name:          "third win bets 6 units",
+			outcomes:      []Outcome{OutcomeWin, OutcomeWin, OutcomeWin},
+			wantIndex:     3,
+			wantBetAmount: 60.0,
+		},
+		{
+			name:          "fourth win completes the sequence",
+			outcomes:      []Outcome{OutcomeWin, OutcomeWin, OutcomeWin, OutcomeWin},
+			wantIndex:     0,
+			wantBetAmount: 10.0,
+		},
+		{
+			name:          "loss starts over",
+			outcomes:      []Outcome{OutcomeWin, OutcomeWin, OutcomeLoss},
+			wantIndex:     0,
+			wantBetAmount: 10.0,
+		},
+		{
+			name:          "push keeps the position",
+			outcomes:      []Outcome{OutcomeWin, OutcomePush},
+			wantIndex:     1,
+			wantBetAmount: 30.0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy := NewOneThreeTwoSix(puntobanco.PuntoPlayer, 10.0)
+			playOutcomes(strategy, tt.outcomes)
+
+			if strategy.OneThreeTwoSixSequenceIndex != tt.wantIndex {
+				t.Errorf("OneThreeTwoSixSequenceIndex = %d, want %d", strategy.OneThreeTwoSixSequenceIndex, tt.wantIndex)
+			}
+			if _, betAmount := strategy.NextBet(History{}); betAmount != tt.wantBetAmount {
+				t.Errorf("NextBet() bet amount = %.2f, want %.2f", betAmount, tt.wantBetAmount)
+			}
+		})
+	}
+}
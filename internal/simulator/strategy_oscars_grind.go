@@ -0,0 +1,59 @@
+package simulator
+
+import (
+	"math"
+
+	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
+)
+
+// Series of bets aiming at a profit of one unit: raise the bet by one unit after a win,
+// keep it after a loss, and start a new series once the goal is reached
+type OscarsGrind struct {
+	side              puntobanco.BetType
+	minimumBet        float64
+	BetAmount         float64
+	OscarsGrindProfit float64 // Profit of the current series in wager units (1 unit = MinimumBet)
+}
+
+func NewOscarsGrind(side puntobanco.BetType, minimumBet float64) *OscarsGrind {
+	return &OscarsGrind{
+		side:       side,
+		minimumBet: minimumBet,
+		BetAmount:  minimumBet,
+	}
+}
+
+func (o *OscarsGrind) NextBet(history History) (puntobanco.BetType, float64) {
+	return o.side, o.BetAmount
+}
+
+func (o *OscarsGrind) OnResult(result BetResult) {
+	switch result.Outcome {
+	case OutcomeWin:
+		o.OscarsGrindProfit += result.Payout / o.minimumBet
+
+		// Series goal of +1 unit is reached
+		if o.OscarsGrindProfit >= 1.0-lineEpsilon {
+			o.Reset()
+			return
+		}
+
+		// Raise by one unit, but do not bet more than the win needed to reach the goal
+		betUnits := o.BetAmount/o.minimumBet + 1
+		payoutRatio := result.Payout / result.BetAmount
+		neededUnits := math.Ceil((1.0-o.OscarsGrindProfit)/payoutRatio - lineEpsilon)
+		if betUnits > neededUnits {
+			betUnits = math.Max(neededUnits, 1)
+		}
+		o.BetAmount = betUnits * o.minimumBet
+
+	case OutcomeLoss:
+		// The bet is never raised after a loss
+		o.OscarsGrindProfit -= result.BetAmount / o.minimumBet
+	}
+}
+
+func (o *OscarsGrind) Reset() {
+	o.OscarsGrindProfit = 0.0
+	o.BetAmount = o.minimumBet
+}
@@ -0,0 +1,145 @@
+package simulator
+
+import (
+	"math"
+	"testing"
+
+	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
+	"github.com/adequatica/punto-banco-golango/internal/rules"
+)
+
+func TestOscarsGrind_Win(t *testing.T) {
+	tests := []struct {
+		name          string
+		betType       puntobanco.BetType
+		profit        float64
+		betAmount     float64
+		wantProfit    float64
+		wantBetAmount float64
+	}{
+		{
+			name:          "Punto win raises the bet by one unit below the goal",
+			betType:       puntobanco.PuntoPlayer,
+			profit:        -3.0,
+			betAmount:     rules.DefaultMinimumBet,
+			wantProfit:    -2.0,
+			wantBetAmount: 2 * rules.DefaultMinimumBet,
+		},
+		{
+			name:          "Banco win raises the bet by one unit below the goal",
+			betType:       puntobanco.BancoBanker,
+			profit:        -3.0,
+			betAmount:     rules.DefaultMinimumBet,
+			wantProfit:    -2.05,
+			wantBetAmount: 2 * rules.DefaultMinimumBet,
+		},
+		{
+			name:          "Punto win reaching the goal starts a new series",
+			betType:       puntobanco.PuntoPlayer,
+			profit:        -1.0,
+			betAmount:     2 * rules.DefaultMinimumBet,
+			wantProfit:    0.0,
+			wantBetAmount: rules.DefaultMinimumBet,
+		},
+		{
+			name:          "Banco win one unit short of the goal because of commission",
+			betType:       puntobanco.BancoBanker,
+			profit:        -1.0,
+			betAmount:     2 * rules.DefaultMinimumBet,
+			wantProfit:    0.9,
+			wantBetAmount: rules.DefaultMinimumBet,
+		},
+		{
+			name:          "Punto win is not raised above the bet needed to reach the goal",
+			betType:       puntobanco.PuntoPlayer,
+			profit:        -4.0,
+			betAmount:     3 * rules.DefaultMinimumBet,
+			wantProfit:    -1.0,
+			wantBetAmount: 2 * rules.DefaultMinimumBet,
+		},
+		{
+			name:          "first Banco win of a series keeps the bet",
+			betType:       puntobanco.BancoBanker,
+			profit:        0.0,
+			betAmount:     rules.DefaultMinimumBet,
+			wantProfit:    0.95,
+			wantBetAmount: rules.DefaultMinimumBet,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy := NewOscarsGrind(tt.betType, rules.DefaultMinimumBet)
+
+			// Precondition of the series
+			strategy.OscarsGrindProfit = tt.profit
+			strategy.BetAmount = tt.betAmount
+
+			strategy.OnResult(winResult(tt.betType, tt.betAmount))
+
+			if math.Abs(strategy.OscarsGrindProfit-tt.wantProfit) > lineEpsilon {
+				t.Errorf("OscarsGrindProfit should update: got %.2f, want %.2f", strategy.OscarsGrindProfit, tt.wantProfit)
+			}
+			if strategy.BetAmount != tt.wantBetAmount {
+				t.Errorf("BetAmount should update: got %.2f, want %.2f", strategy.BetAmount, tt.wantBetAmount)
+			}
+		})
+	}
+}
+
+func TestOscarsGrind_Loss(t *testing.T) {
+	tests := []struct {
+		name      string
+		betType   puntobanco.BetType
+		profit    float64
+		betAmount float64
+	}{
+		{
+			name:      "Oscar's Grind on Punto",
+			betType:   puntobanco.PuntoPlayer,
+			profit:    -1.0,
+			betAmount: 2 * rules.DefaultMinimumBet,
+		},
+		{
+			name:      "Oscar's Grind on Banco",
+			betType:   puntobanco.BancoBanker,
+			profit:    0.95,
+			betAmount: rules.DefaultMinimumBet,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy := NewOscarsGrind(tt.betType, rules.DefaultMinimumBet)
+
+			// Precondition of the series
+			strategy.OscarsGrindProfit = tt.profit
+			strategy.BetAmount = tt.betAmount
+
+			strategy.OnResult(lossResult(tt.betType, tt.betAmount))
+
+			wantProfit := tt.profit - tt.betAmount/rules.DefaultMinimumBet
+			if math.Abs(strategy.OscarsGrindProfit-wantProfit) > lineEpsilon {
+				t.Errorf("OscarsGrindProfit should update: got %.2f, want %.2f", strategy.OscarsGrindProfit, wantProfit)
+			}
+			if strategy.BetAmount != tt.betAmount {
+				t.Errorf("BetAmount should not change after a loss: got %.2f, want %.2f", strategy.BetAmount, tt.betAmount)
+			}
+		})
+	}
+}
+
+func TestOscarsGrind_Series(t *testing.T) {
+	strategy := NewOscarsGrind(puntobanco.PuntoPlayer, rules.DefaultMinimumBet)
+
+	// Two losses, then two wins of 1 and 2 units complete the series
+	playOutcomes(strategy, []Outcome{OutcomeLoss, OutcomeLoss, OutcomeWin})
+	if strategy.BetAmount != 2*rules.DefaultMinimumBet {
+		t.Errorf("BetAmount after the first win = %.2f should be %.2f", strategy.BetAmount, 2*rules.DefaultMinimumBet)
+	}
+
+	playOutcomes(strategy, []Outcome{OutcomePush, OutcomeWin})
+	if strategy.OscarsGrindProfit != 0.0 || strategy.BetAmount != rules.DefaultMinimumBet {
+		t.Errorf("series should start over: got profit %.2f and bet %.2f", strategy.OscarsGrindProfit, strategy.BetAmount)
+	}
+}
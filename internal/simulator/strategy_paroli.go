@@ -0,0 +1,59 @@
+package simulator
+
+import (
+	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
+)
+
+// Double the bet after each win until the goal of consecutive wins is reached
+type Paroli struct {
+	side                   puntobanco.BetType
+	BaseBetAmount          float64
+	BetAmount              float64
+	IsInParoliProgression  bool
+	ParoliProgressionLevel int // Current level in the Paroli progression (1, 2, 3)
+	ParoliGoal             int // Goal to reach before resetting
+}
+
+func NewParoli(side puntobanco.BetType, minimumBet float64, maxLevel int) *Paroli {
+	return &Paroli{
+		side:          side,
+		BaseBetAmount: minimumBet,
+		BetAmount:     minimumBet,
+		ParoliGoal:    maxLevel,
+	}
+}
+
+func (p *Paroli) NextBet(history History) (puntobanco.BetType, float64) {
+	return p.side, p.BetAmount
+}
+
+func (p *Paroli) OnResult(result BetResult) {
+	switch result.Outcome {
+	case OutcomeWin:
+		if !p.IsInParoliProgression {
+			// Start Paroli progression with base bet
+			p.IsInParoliProgression = true
+			p.ParoliProgressionLevel = 1
+			p.BetAmount = p.BaseBetAmount
+		} else {
+			// Continue progression
+			p.ParoliProgressionLevel++
+			if p.ParoliProgressionLevel <= p.ParoliGoal {
+				// Double the bet for next round
+				p.BetAmount = p.BetAmount * 2
+			} else {
+				// Goal reached: reset to base bet and end progression
+				p.Reset()
+			}
+		}
+	case OutcomeLoss:
+		// Reset to base bet when a loss occurs
+		p.Reset()
+	}
+}
+
+func (p *Paroli) Reset() {
+	p.BetAmount = p.BaseBetAmount
+	p.IsInParoliProgression = false
+	p.ParoliProgressionLevel = 0
+}
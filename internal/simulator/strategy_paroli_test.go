@@ -0,0 +1,77 @@
+package simulator
+
+import (
+	"testing"
+
+	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
+)
+
+func TestParoli(t *testing.T) {
+	tests := []struct {
+		name            string
+		outcomes        []Outcome
+		wantProgression bool
+		wantLevel       int
+		wantBetAmount   float64
+	}{
+		{
+			name:            "first win starts the progression with the base bet",
+			outcomes:        []Outcome{OutcomeWin},
+			wantProgression: true,
+			wantLevel:       1,
+			wantBetAmount:   10.0,
+		},
+		{
+			name:            "second win doubles the bet",
+			outcomes:        []Outcome{OutcomeWin, OutcomeWin},
+			wantProgression: true,
+			wantLevel:       2,
+			wantBetAmount:   20.0,
+		},
+		{
+			name:            "third win doubles the bet again",
+			outcomes:        []Outcome{OutcomeWin, OutcomeWin, OutcomeWin},
+			wantProgression: true,
+			wantLevel:       3,
+			wantBetAmount:   40.0,
+		},
+		{
+			name:            "goal reached resets the progression",
+			outcomes:        []Outcome{OutcomeWin, OutcomeWin, OutcomeWin, OutcomeWin},
+			wantProgression: false,
+			wantLevel:       0,
+			wantBetAmount:   10.0,
+		},
+		{
+			name:            "loss resets the progression",
+			outcomes:        []Outcome{OutcomeWin, OutcomeWin, OutcomeLoss},
+			wantProgression: false,
+			wantLevel:       0,
+			wantBetAmount:   10.0,
+		},
+		{
+			name:            "push keeps the progression",
+			outcomes:        []Outcome{OutcomeWin, OutcomeWin, OutcomePush},
+			wantProgression: true,
+			wantLevel:       2,
+			wantBetAmount:   20.0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy := NewParoli(puntobanco.PuntoPlayer, 10.0, DefaultParoliMaxLevel)
+			playOutcomes(strategy, tt.outcomes)
+
+			if strategy.IsInParoliProgression != tt.wantProgression {
+				t.Errorf("IsInParoliProgression = %v, want %v", strategy.IsInParoliProgression, tt.wantProgression)
+			}
+			if strategy.ParoliProgressionLevel != tt.wantLevel {
+				t.Errorf("ParoliProgressionLevel = %d, want %d", strategy.ParoliProgressionLevel, tt.wantLevel)
+			}
+			if _, betAmount := strategy.NextBet(History{}); betAmount != tt.wantBetAmount {
+				t.Errorf("NextBet() bet amount = %.2f, want %.2f", betAmount, tt.wantBetAmount)
+			}
+		})
+	}
+}
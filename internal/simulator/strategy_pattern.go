@@ -0,0 +1,156 @@
+package simulator
+
+import (
+	"fmt"
+	"strings"
+
+	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
+)
+
+// Progression of the stake of pattern strategies
+type Stake string
+
+const (
+	StakeFlat       Stake = "flat"
+	StakeMartingale Stake = "martingale"
+	StakeDAlembert  Stake = "dalembert"
+	StakeFibonacci  Stake = "fibonacci"
+)
+
+func GetStakeOptions() []Stake {
+	return []Stake{StakeFlat, StakeMartingale, StakeDAlembert, StakeFibonacci}
+}
+
+func ParseStake(name string) (Stake, error) {
+	for _, stake := range GetStakeOptions() {
+		if strings.EqualFold(name, string(stake)) {
+			return stake, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown stake %q, use flat, martingale, dalembert or fibonacci", name)
+}
+
+// Progression strategies only move the amount, the side of the bet is chosen by the pattern
+func newStake(stake Stake, minimumBet float64) Strategy {
+	switch stake {
+	case StakeMartingale:
+		return NewMartingale(puntobanco.BancoBanker, minimumBet)
+	case StakeDAlembert:
+		return NewDAlembert(puntobanco.BancoBanker, minimumBet)
+	case StakeFibonacci:
+		return NewFibonacci(puntobanco.BancoBanker, minimumBet)
+	default:
+		return NewFlatStrategy(puntobanco.BancoBanker, minimumBet)
+	}
+}
+
+// Choice of the side from the results of the game, the oldest first.
+// An empty side sits the coup out until the pattern shows up.
+type PatternFunc func(results []puntobanco.BetType) puntobanco.BetType
+
+// Bet on Punto or Banco following a pattern of the road, with a flat or progression stake
+type PatternStrategy struct {
+	pattern PatternFunc
+	stake   Strategy
+}
+
+func NewPatternStrategy(pattern PatternFunc, stake Strategy) *PatternStrategy {
+	return &PatternStrategy{pattern: pattern, stake: stake}
+}
+
+func (p *PatternStrategy) NextBet(history History) (puntobanco.BetType, float64) {
+	side := p.pattern(history.Results)
+	if side == "" {
+		return side, 0
+	}
+	_, betAmount := p.stake.NextBet(history)
+	return side, betAmount
+}
+
+func (p *PatternStrategy) OnResult(result BetResult) {
+	p.stake.OnResult(result)
+}
+
+func (p *PatternStrategy) Reset() {
+	p.stake.Reset()
+}
+
+func OppositeSide(side puntobanco.BetType) puntobanco.BetType {
+	if side == puntobanco.PuntoPlayer {
+		return puntobanco.BancoBanker
+	}
+	return puntobanco.PuntoPlayer
+}
+
+// Side and length of the current column of the Big Road, Égalité does not start a new column.
+// The length of the previous column is 0 if there is only one column.
+func bigRoadColumns(results []puntobanco.BetType) (side puntobanco.BetType, length int, previousLength int) {
+	i := len(results) - 1
+	for ; i >= 0; i-- {
+		if results[i] == puntobanco.EgaliteTie {
+			continue
+		}
+		if length > 0 && results[i] != side {
+			break
+		}
+		side = results[i]
+		length++
+	}
+
+	for ; i >= 0; i-- {
+		if results[i] == puntobanco.EgaliteTie {
+			continue
+		}
+		if results[i] == side {
+			break
+		}
+		previousLength++
+	}
+
+	return side, length, previousLength
+}
+
+// Bet on the side of the current streak
+func FollowTheStreak(results []puntobanco.BetType) puntobanco.BetType {
+	side, length, _ := bigRoadColumns(results)
+	if length == 0 {
+		// Bet on Banco to maximize chance of winning
+		return puntobanco.BancoBanker
+	}
+	return side
+}
+
+// Bet against the last winner while Punto and Banco chop (the last two columns of the Big Road have one result each),
+// and sit out during streaks, so the ping-pong is bet only once it has started
+func BetTheChop(results []puntobanco.BetType) puntobanco.BetType {
+	side, length, previousLength := bigRoadColumns(results)
+	if length != 1 || previousLength != 1 {
+		return ""
+	}
+	return OppositeSide(side)
+}
+
+// Expect the current column of the Big Road to be as long as the previous one
+func FollowTheBigRoadColumn(results []puntobanco.BetType) puntobanco.BetType {
+	side, length, previousLength := bigRoadColumns(results)
+	if length == 0 {
+		return puntobanco.BancoBanker
+	}
+	if previousLength > 0 && length >= previousLength {
+		return OppositeSide(side)
+	}
+	return side
+}
+
+// Follow the last winner until it wins twice in a row, then switch to the other side
+func TwoInARowThenSwitch(results []puntobanco.BetType) puntobanco.BetType {
+	side, length, _ := bigRoadColumns(results)
+	if length == 0 {
+		return puntobanco.BancoBanker
+	}
+	if length >= 2 {
+		return OppositeSide(side)
+	}
+	return side
+}
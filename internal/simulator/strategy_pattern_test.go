@@ -0,0 +1,141 @@
+package simulator
+
+import (
+	"testing"
+
+	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
+	"github.com/adequatica/punto-banco-golango/internal/rules"
+)
+
+// Results written as a road, e.g. "PBBT" for Punto, Banco, Banco, Égalité
+func road(results string) []puntobanco.BetType {
+	road := make([]puntobanco.BetType, 0, len(results))
+	for _, r := range results {
+		switch r {
+		case 'P':
+			road = append(road, puntobanco.PuntoPlayer)
+		case 'B':
+			road = append(road, puntobanco.BancoBanker)
+		case 'T':
+			road = append(road, puntobanco.EgaliteTie)
+		}
+	}
+	return road
+}
+
+func TestPatterns(t *testing.T) {
+	// Empty side sits the coup out
+	P, B, N := puntobanco.PuntoPlayer, puntobanco.BancoBanker, puntobanco.BetType("")
+
+	tests := []struct {
+		name    string
+		results string
+		streak  puntobanco.BetType
+		chop    puntobanco.BetType
+		column  puntobanco.BetType
+		twice   puntobanco.BetType
+	}{
+		{"no results", "", B, N, B, B},
+		{"only Égalité", "TT", B, N, B, B},
+		{"single Punto", "P", P, N, P, P},
+		{"ping-pong", "PBPB", B, P, P, B},
+		{"Banco streak", "PBBB", B, N, P, P},
+		{"Égalité does not break the streak", "BTB", B, N, B, P},
+		{"Égalité after Punto", "BPT", P, B, B, P},
+		{"column shorter than the previous one", "PPPBB", B, N, B, P},
+		{"column as long as the previous one", "PPBBTB", B, N, P, P},
+		{"streak broken by a single Punto", "BBBP", P, N, P, P},
+		{"chop after a streak", "PPPBP", P, B, B, P},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := road(tt.results)
+
+			if result := FollowTheStreak(results); result != tt.streak {
+				t.Errorf("FollowTheStreak(%s) = %v should be %v", tt.results, result, tt.streak)
+			}
+			if result := BetTheChop(results); result != tt.chop {
+				t.Errorf("BetTheChop(%s) = %v should be %v", tt.results, result, tt.chop)
+			}
+			if result := FollowTheBigRoadColumn(results); result != tt.column {
+				t.Errorf("FollowTheBigRoadColumn(%s) = %v should be %v", tt.results, result, tt.column)
+			}
+			if result := TwoInARowThenSwitch(results); result != tt.twice {
+				t.Errorf("TwoInARowThenSwitch(%s) = %v should be %v", tt.results, result, tt.twice)
+			}
+		})
+	}
+}
+
+func TestBigRoadColumns(t *testing.T) {
+	side, length, previousLength := bigRoadColumns(road("BBTPPTP"))
+
+	if side != puntobanco.PuntoPlayer || length != 3 || previousLength != 2 {
+		t.Errorf("bigRoadColumns() = %v, %d, %d should be %v, 3, 2", side, length, previousLength, puntobanco.PuntoPlayer)
+	}
+}
+
+func TestPatternStrategy_Stake(t *testing.T) {
+	tests := []struct {
+		name          string
+		stake         Stake
+		outcomes      []Outcome
+		wantBetAmount float64
+	}{
+		{"flat stake after losses", StakeFlat, []Outcome{OutcomeLoss, OutcomeLoss}, 10.0},
+		{"Martingale stake after losses", StakeMartingale, []Outcome{OutcomeLoss, OutcomeLoss}, 40.0},
+		{"D'Alembert stake after losses", StakeDAlembert, []Outcome{OutcomeLoss, OutcomeLoss}, 30.0},
+		{"Fibonacci stake after losses", StakeFibonacci, []Outcome{OutcomeLoss, OutcomeLoss}, 20.0},
+		{"Martingale stake after a win", StakeMartingale, []Outcome{OutcomeLoss, OutcomeWin}, 10.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy := NewPatternStrategy(BetTheChop, newStake(tt.stake, rules.DefaultMinimumBet))
+			playOutcomes(strategy, tt.outcomes)
+
+			betType, betAmount := strategy.NextBet(History{Results: road("PB")})
+			if betType != puntobanco.PuntoPlayer {
+				t.Errorf("NextBet() bet type = %v, want %v", betType, puntobanco.PuntoPlayer)
+			}
+			if betAmount != tt.wantBetAmount {
+				t.Errorf("NextBet() bet amount = %.2f, want %.2f", betAmount, tt.wantBetAmount)
+			}
+		})
+	}
+}
+
+func TestPatternStrategy_SitOut(t *testing.T) {
+	strategy := NewPatternStrategy(BetTheChop, newStake(StakeMartingale, rules.DefaultMinimumBet))
+	playOutcomes(strategy, []Outcome{OutcomeLoss})
+
+	// Streak does not trigger the chop
+	betType, betAmount := strategy.NextBet(History{Results: road("PBBB")})
+	if betType != "" || betAmount != 0 {
+		t.Errorf("NextBet() during a streak = %v, %.2f, want no bet", betType, betAmount)
+	}
+	strategy.OnResult(BetResult{Outcome: OutcomeNoBet})
+
+	// Progression of the stake goes on once the chop shows up
+	betType, betAmount = strategy.NextBet(History{Results: road("PBBBPB")})
+	if betType != puntobanco.PuntoPlayer || betAmount != 20.0 {
+		t.Errorf("NextBet() after the chop = %v, %.2f, want %v, 20.00", betType, betAmount, puntobanco.PuntoPlayer)
+	}
+}
+
+func TestParseStake(t *testing.T) {
+	for _, stake := range GetStakeOptions() {
+		result, err := ParseStake(string(stake))
+		if err != nil || result != stake {
+			t.Errorf("ParseStake(%q) = %q, %v should be %q", stake, result, err, stake)
+		}
+	}
+
+	if result, err := ParseStake("Martingale"); err != nil || result != StakeMartingale {
+		t.Errorf("ParseStake() should be case insensitive, got %q, %v", result, err)
+	}
+	if _, err := ParseStake("paroli"); err == nil {
+		t.Error("ParseStake() should return an error for an unknown stake")
+	}
+}
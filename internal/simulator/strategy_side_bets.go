@@ -0,0 +1,31 @@
+package simulator
+
+import (
+	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
+)
+
+// Side bet of a coup and its amount
+type SideBet struct {
+	Type   puntobanco.SideBetType
+	Amount float64
+}
+
+// Strategies placing side bets alongside their main bet
+type SideBettor interface {
+	// Side bets of the next coup, they are placed only together with a main bet
+	NextSideBets(history History) []SideBet
+}
+
+// Main strategy with the same side bets on every coup it bets on
+type SideBetStrategy struct {
+	Strategy
+	sideBets []SideBet
+}
+
+func NewSideBetStrategy(strategy Strategy, sideBets ...SideBet) *SideBetStrategy {
+	return &SideBetStrategy{Strategy: strategy, sideBets: sideBets}
+}
+
+func (s *SideBetStrategy) NextSideBets(history History) []SideBet {
+	return s.sideBets
+}
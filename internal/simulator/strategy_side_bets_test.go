@@ -0,0 +1,30 @@
+package simulator
+
+import (
+	"reflect"
+	"testing"
+
+	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
+)
+
+func TestSideBetStrategy(t *testing.T) {
+	sideBets := []SideBet{{Type: puntobanco.PerfectPair, Amount: 5.0}}
+	strategy := NewSideBetStrategy(NewMartingale(puntobanco.PuntoPlayer, 10.0), sideBets...)
+
+	// Main bet follows the wrapped progression
+	strategy.OnResult(BetResult{Outcome: OutcomeLoss, BetOn: puntobanco.PuntoPlayer, BetAmount: 10.0})
+	if side, amount := strategy.NextBet(History{}); side != puntobanco.PuntoPlayer || amount != 20.0 {
+		t.Errorf("NextBet() = %s %.2f should be the doubled bet on Punto", side, amount)
+	}
+	strategy.Reset()
+	if _, amount := strategy.NextBet(History{}); amount != 10.0 {
+		t.Errorf("NextBet() after Reset() = %.2f should be the base bet", amount)
+	}
+
+	// Side bets stay the same
+	if result := strategy.NextSideBets(History{}); !reflect.DeepEqual(result, sideBets) {
+		t.Errorf("NextSideBets() = %+v should be %+v", result, sideBets)
+	}
+
+	var _ SideBettor = strategy
+}
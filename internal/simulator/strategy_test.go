@@ -0,0 +1,312 @@
+package simulator
+
+import (
+	"testing"
+
+	"github.com/adequatica/punto-banco-golango/internal/deck"
+	puntobanco "github.com/adequatica/punto-banco-golango/internal/punto_banco"
+	"github.com/adequatica/punto-banco-golango/internal/rules"
+)
+
+// Settled results of a bet for strategy tests
+func winResult(betOn puntobanco.BetType, betAmount float64) BetResult {
+	return BetResult{
+		Outcome:   OutcomeWin,
+		BetOn:     betOn,
+		BetAmount: betAmount,
+		Payout:    CalculatePayout(betOn, betAmount, rules.DefaultTableRules()),
+	}
+}
+
+func lossResult(betOn puntobanco.BetType, betAmount float64) BetResult {
+	return BetResult{Outcome: OutcomeLoss, BetOn: betOn, BetAmount: betAmount}
+}
+
+func pushResult(betOn puntobanco.BetType, betAmount float64) BetResult {
+	return BetResult{Outcome: OutcomePush, BetOn: betOn, BetAmount: betAmount}
+}
+
+func TestGetStrategyOptions(t *testing.T) {
+	options := GetStrategyOptions()
+
+	if len(options) != 30 {
+		t.Fatalf("GetStrategyOptions() returned %d options, want 30", len(options))
+	}
+	if options[0] != string(BetOnPunto) {
+		t.Errorf("first option = %q should be %q", options[0], BetOnPunto)
+	}
+	if options[len(options)-1] != string(BancoWithPerfectPair) {
+		t.Errorf("last option = %q should be %q", options[len(options)-1], BancoWithPerfectPair)
+	}
+}
+
+func TestNewStrategy_Unknown(t *testing.T) {
+	_, err := NewStrategy("Unknown", DefaultSettings(), deck.NewShuffler(1))
+	if err == nil {
+		t.Error("NewStrategy() should return an error for an unknown strategy")
+	}
+}
+
+func TestRegisterStrategy_Replace(t *testing.T) {
+	original := make([]registeredStrategy, len(strategyRegistry))
+	copy(original, strategyRegistry)
+	t.Cleanup(func() { strategyRegistry = original })
+
+	RegisterStrategy(BetOnPunto, func(s Settings, _ deck.Shuffler) Strategy {
+		return NewFlatStrategy(puntobanco.BancoBanker, s.Rules.MinimumBet)
+	})
+
+	if len(GetStrategyOptions()) != len(original) {
+		t.Errorf("registering a strategy twice should not add an option: got %d, want %d", len(GetStrategyOptions()), len(original))
+	}
+
+	strategy, err := NewStrategy(BetOnPunto, DefaultSettings(), deck.NewShuffler(1))
+	if err != nil {
+		t.Fatalf("NewStrategy() error: %v", err)
+	}
+	if betType, _ := strategy.NextBet(History{}); betType != puntobanco.BancoBanker {
+		t.Errorf("replaced strategy should bet on %s, got %s", puntobanco.BancoBanker, betType)
+	}
+}
+
+func TestNewStrategy_FirstBet(t *testing.T) {
+	tests := []struct {
+		name          string
+		strategy      StrategyType
+		history       History
+		wantBetType   puntobanco.BetType
+		wantBetAmount float64
+	}{
+		{
+			name:          "Bet on Punto returns PuntoPlayer with minimum bet",
+			strategy:      BetOnPunto,
+			wantBetType:   puntobanco.PuntoPlayer,
+			wantBetAmount: rules.DefaultMinimumBet,
+		},
+		{
+			name:          "Bet on Banco returns BancoBanker with minimum bet",
+			strategy:      BetOnBanco,
+			wantBetType:   puntobanco.BancoBanker,
+			wantBetAmount: rules.DefaultMinimumBet,
+		},
+		{
+			name:          "Bet on Égalité returns EgaliteTie with minimum bet",
+			strategy:      BetOnEgalite,
+			wantBetType:   puntobanco.EgaliteTie,
+			wantBetAmount: rules.DefaultMinimumBet,
+		},
+		{
+			name:          "Bet on last hand returns last winning hand with minimum bet",
+			strategy:      BetOnLastHand,
+			history:       History{LastWinningHand: puntobanco.EgaliteTie},
+			wantBetType:   puntobanco.EgaliteTie,
+			wantBetAmount: rules.DefaultMinimumBet,
+		},
+		{
+			name:          "Bet on last hand PB returns BancoBanker with minimum bet in case of EgaliteTie",
+			strategy:      BetOnLastHandPB,
+			history:       History{LastWinningHand: puntobanco.EgaliteTie},
+			wantBetType:   puntobanco.BancoBanker,
+			wantBetAmount: rules.DefaultMinimumBet,
+		},
+		{
+			name:          "Bet on random returns the first bet of the seeded shuffler",
+			strategy:      BetOnRandom,
+			wantBetType:   GetRandomBetType(deck.NewShuffler(1)),
+			wantBetAmount: rules.DefaultMinimumBet,
+		},
+		{
+			name:          "Martingale on Punto starts with minimum bet",
+			strategy:      MartingaleOnPunto,
+			wantBetType:   puntobanco.PuntoPlayer,
+			wantBetAmount: rules.DefaultMinimumBet,
+		},
+		{
+			name:          "Paroli on Banco starts with minimum bet",
+			strategy:      ParoliOnBanco,
+			wantBetType:   puntobanco.BancoBanker,
+			wantBetAmount: rules.DefaultMinimumBet,
+		},
+		{
+			name:          "Fibonacci on Punto starts with minimum bet",
+			strategy:      FibonacciOnPunto,
+			wantBetType:   puntobanco.PuntoPlayer,
+			wantBetAmount: rules.DefaultMinimumBet,
+		},
+		{
+			name:          "D'Alembert on Banco starts with minimum bet",
+			strategy:      DAlembertOnBanco,
+			wantBetType:   puntobanco.BancoBanker,
+			wantBetAmount: rules.DefaultMinimumBet,
+		},
+		{
+			name:          "Oscar's Grind on Banco starts with minimum bet",
+			strategy:      OscarsGrindOnBanco,
+			wantBetType:   puntobanco.BancoBanker,
+			wantBetAmount: rules.DefaultMinimumBet,
+		},
+		{
+			name:          "Follow the streak bets on the last winner ignoring Égalité",
+			strategy:      FollowTheStreakPB,
+			history:       History{Results: road("PT")},
+			wantBetType:   puntobanco.PuntoPlayer,
+			wantBetAmount: rules.DefaultMinimumBet,
+		},
+		{
+			name:          "Labouchère on Banco starts with the first and the last numbers of the line",
+			strategy:      LabouchereOnBanco,
+			wantBetType:   puntobanco.BancoBanker,
+			wantBetAmount: 5 * rules.DefaultMinimumBet,
+		},
+		{
+			name:          "1-3-2-6 on Punto starts with minimum bet",
+			strategy:      OneThreeTwoSixOnPunto,
+			wantBetType:   puntobanco.PuntoPlayer,
+			wantBetAmount: rules.DefaultMinimumBet,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy, err := NewStrategy(tt.strategy, DefaultSettings(), deck.NewShuffler(1))
+			if err != nil {
+				t.Fatalf("NewStrategy(%q) error: %v", tt.strategy, err)
+			}
+
+			betType, betAmount := strategy.NextBet(tt.history)
+			if betType != tt.wantBetType {
+				t.Errorf("NextBet() bet type = %v, want %v", betType, tt.wantBetType)
+			}
+			if betAmount != tt.wantBetAmount {
+				t.Errorf("NextBet() bet amount = %.2f, want %.2f", betAmount, tt.wantBetAmount)
+			}
+		})
+	}
+}
+
+func TestStrategy_PushKeepsBet(t *testing.T) {
+	for _, option := range GetStrategyOptions() {
+		t.Run(option, func(t *testing.T) {
+			strategy, err := NewStrategy(StrategyType(option), DefaultSettings(), deck.NewShuffler(1))
+			if err != nil {
+				t.Fatalf("NewStrategy(%q) error: %v", option, err)
+			}
+
+			// Move the progression away from the base bet
+			betType, betAmount := strategy.NextBet(History{})
+			strategy.OnResult(lossResult(betType, betAmount))
+			betType, betAmount = strategy.NextBet(History{})
+			strategy.OnResult(winResult(betType, betAmount))
+
+			history := History{LastWinningHand: puntobanco.PuntoPlayer}
+			betType, betAmount = strategy.NextBet(history)
+			strategy.OnResult(pushResult(betType, betAmount))
+
+			if option == string(BetOnRandom) {
+				return
+			}
+			afterType, afterAmount := strategy.NextBet(history)
+			if afterType != betType || afterAmount != betAmount {
+				t.Errorf("bet after a push = %v %.2f should be %v %.2f", afterType, afterAmount, betType, betAmount)
+			}
+		})
+	}
+}
+
+func TestStrategy_Reset(t *testing.T) {
+	for _, option := range GetStrategyOptions() {
+		t.Run(option, func(t *testing.T) {
+			strategy, err := NewStrategy(StrategyType(option), DefaultSettings(), deck.NewShuffler(1))
+			if err != nil {
+				t.Fatalf("NewStrategy(%q) error: %v", option, err)
+			}
+			_, firstBetAmount := strategy.NextBet(History{})
+
+			for range 3 {
+				betType, betAmount := strategy.NextBet(History{})
+				strategy.OnResult(lossResult(betType, betAmount))
+			}
+			strategy.Reset()
+
+			if _, betAmount := strategy.NextBet(History{}); betAmount != firstBetAmount {
+				t.Errorf("bet after Reset() = %.2f should be the first bet of %.2f", betAmount, firstBetAmount)
+			}
+		})
+	}
+}
+
+func TestStrategySlug(t *testing.T) {
+	tests := []struct {
+		strategy StrategyType
+		want     string
+	}{
+		{BetOnPunto, "bet-on-punto-player"},
+		{BetOnEgalite, "bet-on-egalite-tie"},
+		{BetOnLastHandPB, "bet-on-last-hand-pb"},
+		{DAlembertOnBanco, "dalembert-on-banco"},
+		{OneThreeTwoSixOnPunto, "1-3-2-6-on-punto"},
+		{OscarsGrindOnPunto, "oscars-grind-on-punto"},
+		{TwoInARowThenSwitchPB, "2-in-a-row-then-switch-pb"},
+		{ReverseLabouchereOnBanco, "reverse-labouchere-on-banco"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			result := StrategySlug(tt.strategy)
+			if result != tt.want {
+				t.Errorf("StrategySlug(%q) = %q should be %q", tt.strategy, result, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseStrategy(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    StrategyType
+		wantErr bool
+	}{
+		{"full name", "Martingale on Banco", MartingaleOnBanco, false},
+		{"case insensitive full name", "bet on égalité (tie)", BetOnEgalite, false},
+		{"slug", "fibonacci-on-banco", FibonacciOnBanco, false},
+		{"unknown strategy", "unknown-strategy", "", true},
+		{"empty name", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseStrategy(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseStrategy(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if result != tt.want {
+				t.Errorf("ParseStrategy(%q) = %q should be %q", tt.input, result, tt.want)
+			}
+		})
+	}
+
+	// Every strategy can be found by its slug
+	for _, option := range GetStrategyOptions() {
+		strategy := StrategyType(option)
+		result, err := ParseStrategy(StrategySlug(strategy))
+		if err != nil || result != strategy {
+			t.Errorf("ParseStrategy(%q) = %q, %v should be %q", StrategySlug(strategy), result, err, strategy)
+		}
+	}
+}
+
+// Settle the next bets of a strategy with the given outcomes
+func playOutcomes(strategy Strategy, outcomes []Outcome) {
+	for _, outcome := range outcomes {
+		betType, betAmount := strategy.NextBet(History{})
+		switch outcome {
+		case OutcomeWin:
+			strategy.OnResult(winResult(betType, betAmount))
+		case OutcomePush:
+			strategy.OnResult(pushResult(betType, betAmount))
+		default:
+			strategy.OnResult(lossResult(betType, betAmount))
+		}
+	}
+}
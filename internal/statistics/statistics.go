@@ -11,6 +11,9 @@ type SessionStatistics struct {
 	Ties        int
 	UserWins    int
 	UserBets    map[puntobanco.BetType]int
+	// Side bets placed alongside the bets of the rounds
+	SideBets    int
+	SideBetWins int
 }
 
 func NewSessionStatistics() SessionStatistics {
@@ -45,6 +48,13 @@ func (s *SessionStatistics) UpdateStatistics(gameResult puntobanco.BetType, user
 	s.UserBets[userBet]++
 }
 
+func (s *SessionStatistics) UpdateSideBetStatistics(isWon bool) {
+	s.SideBets++
+	if isWon {
+		s.SideBetWins++
+	}
+}
+
 func (s *SessionStatistics) GetPuntoWinsPercentage() float64 {
 	if s.TotalRounds == 0 {
 		return 0.0
@@ -77,6 +87,14 @@ func (s *SessionStatistics) GetUserWinsPercentage() float64 {
 	return float64(s.UserWins) / float64(s.TotalRounds) * 100.0
 }
 
+func (s *SessionStatistics) GetSideBetWinsPercentage() float64 {
+	if s.SideBets == 0 {
+		return 0.0
+	}
+
+	return float64(s.SideBetWins) / float64(s.SideBets) * 100.0
+}
+
 func (s *SessionStatistics) GetUserBetsDistribution() map[puntobanco.BetType]float64 {
 	distribution := make(map[puntobanco.BetType]float64)
 
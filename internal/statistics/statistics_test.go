@@ -401,6 +401,29 @@ func TestGetUserWinsPercentage(t *testing.T) {
 	}
 }
 
+func TestUpdateSideBetStatistics(t *testing.T) {
+	stats := NewSessionStatistics()
+	if result := stats.GetSideBetWinsPercentage(); result != 0.0 {
+		t.Errorf("GetSideBetWinsPercentage() without side bets = %.1f, want 0.0", result)
+	}
+
+	stats.UpdateSideBetStatistics(true)
+	stats.UpdateSideBetStatistics(false)
+	stats.UpdateSideBetStatistics(false)
+	stats.UpdateSideBetStatistics(false)
+
+	if stats.SideBets != 4 || stats.SideBetWins != 1 {
+		t.Errorf("side bets = %d with %d wins, want 4 with 1 win", stats.SideBets, stats.SideBetWins)
+	}
+	if result := stats.GetSideBetWinsPercentage(); result != 25.0 {
+		t.Errorf("GetSideBetWinsPercentage() = %.1f, want 25.0", result)
+	}
+	// Side bets do not count as rounds
+	if stats.TotalRounds != 0 {
+		t.Errorf("TotalRounds should be 0, got %d", stats.TotalRounds)
+	}
+}
+
 func TestGetUserBetsDistribution(t *testing.T) {
 	tests := []struct {
 		name  string